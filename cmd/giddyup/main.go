@@ -3,21 +3,33 @@ package main
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/auth"
 	"github.com/lachiem1/giddyUp/internal/storage"
+	"github.com/lachiem1/giddyUp/internal/syncer"
 	"github.com/lachiem1/giddyUp/internal/tui"
+	"github.com/lachiem1/giddyUp/internal/upapi"
 )
 
 func main() {
 	if len(os.Args) >= 2 {
-		fmt.Fprintln(os.Stderr, "CLI subcommands were removed. Launch giddyup with no args and use slash commands in the TUI (for example: /connect, /ping, /db-wipe).")
-		os.Exit(1)
+		os.Exit(runCLI(os.Args[1:]))
 	}
 
 	db, _, err := initDB()
+	var tooNew *storage.SchemaTooNewError
+	if errors.As(err, &tooNew) {
+		db, err = recoverNewerSchema(tooNew)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "db setup error: %v\n", err)
 		os.Exit(1)
@@ -30,17 +42,358 @@ func main() {
 	}
 }
 
+// runCLI handles the headless subcommands and returns the process exit code.
+func runCLI(args []string) int {
+	var run func([]string, io.Writer) error
+	name, rest := "", []string(nil)
+	switch {
+	case len(args) >= 2 && args[0] == "export" && args[1] == "transactions":
+		run, name, rest = runExportTransactions, "export transactions", args[2:]
+	case len(args) >= 2 && args[0] == "accounts" && args[1] == "list":
+		run, name, rest = runAccountsList, "accounts list", args[2:]
+	case args[0] == "sync":
+		run, name, rest = runSync, "sync", args[1:]
+	case len(args) >= 2 && args[0] == "db" && args[1] == "optimize":
+		run, name, rest = runDBOptimize, "db optimize", args[2:]
+	case args[0] == "ping":
+		run, name, rest = runPing, "ping", args[1:]
+	default:
+		fmt.Fprintln(os.Stderr, "Interactive CLI subcommands were removed. Launch giddyup with no args and use slash commands in the TUI (for example: /connect, /ping, /db-wipe).")
+		fmt.Fprintln(os.Stderr, "Headless commands:")
+		fmt.Fprintln(os.Stderr, "  giddyup export transactions [--from YYYY-MM-DD] [--to YYYY-MM-DD] [--format csv|json]")
+		fmt.Fprintln(os.Stderr, "  giddyup accounts list [--json]")
+		fmt.Fprintln(os.Stderr, "  giddyup sync [accounts|transactions] [--since YYYY-MM-DD|latest]")
+		fmt.Fprintln(os.Stderr, "  giddyup db optimize")
+		fmt.Fprintln(os.Stderr, "  giddyup ping [--json]")
+		return 1
+	}
+	if err := run(rest, os.Stdout); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "%s error: %v\n", name, err)
+		}
+		return 1
+	}
+	return 0
+}
+
 func initDB() (*sql.DB, storage.Config, error) {
 	return storage.Open(context.Background())
 }
 
+// recoverNewerSchema asks whether to back up and wipe a database written by
+// a newer build, then opens a fresh one if the user agreed.
+func recoverNewerSchema(tooNew *storage.SchemaTooNewError) (*sql.DB, error) {
+	wipe, err := tui.RunSchemaMismatchPrompt(tooNew.Found, tooNew.Supported, tooNew.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !wipe {
+		return nil, fmt.Errorf("%w; install a giddyup build that supports it, or relaunch to back up and wipe", tooNew)
+	}
+	_, backup, err := storage.BackupAndWipe()
+	if err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "previous database backed up to %s\n", backup)
+	db, _, err := initDB()
+	return db, err
+}
+
 func runTUI(db *sql.DB) error {
 	program := tea.NewProgram(
 		tui.New(db),
 		tea.WithAltScreen(),
-		tea.WithMouseCellMotion(),
 	)
 
 	_, err := program.Run()
 	return err
 }
+
+// runExportTransactions streams stored transactions to out so they can be
+// scripted without entering the TUI.
+func runExportTransactions(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("giddyup export transactions", flag.ContinueOnError)
+	from := fs.String("from", "", "earliest transaction date to include (YYYY-MM-DD)")
+	to := fs.String("to", "", "latest transaction date to include (YYYY-MM-DD)")
+	format := fs.String("format", "csv", "output format: csv or json")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	filter := tui.TransactionsFilter{}
+	if strings.TrimSpace(*from) != "" {
+		digits, err := tui.ParseTransactionsDate(*from)
+		if err != nil {
+			return fmt.Errorf("--from: %w", err)
+		}
+		filter.FromDigits = digits
+	}
+	if strings.TrimSpace(*to) != "" {
+		digits, err := tui.ParseTransactionsDate(*to)
+		if err != nil {
+			return fmt.Errorf("--to: %w", err)
+		}
+		filter.ToDigits = digits
+	}
+
+	var write func(context.Context, *sql.DB, tui.TransactionsFilter, io.Writer) (int, error)
+	switch strings.ToLower(strings.TrimSpace(*format)) {
+	case "csv":
+		write = tui.WriteTransactionsCSV
+	case "json":
+		write = tui.WriteTransactionsJSON
+	default:
+		return fmt.Errorf("--format must be csv or json, got %q", *format)
+	}
+
+	db, _, err := initDB()
+	if err != nil {
+		return fmt.Errorf("db setup error: %w", err)
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+	hasAny, err := storage.NewTransactionsRepo(db).HasAny(ctx)
+	if err != nil {
+		return err
+	}
+	if !hasAny {
+		return errors.New("no transactions stored locally yet; launch giddyup and open /transactions to sync them first")
+	}
+
+	_, err = write(ctx, db, filter, out)
+	return err
+}
+
+// runAccountsList prints the locally cached account balances without a
+// network round trip.
+func runAccountsList(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("giddyup accounts list", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print accounts as a JSON array")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	db, _, err := initDB()
+	if err != nil {
+		return fmt.Errorf("db setup error: %w", err)
+	}
+	defer db.Close()
+
+	accounts, err := tui.ListAccounts(db)
+	if err != nil {
+		return err
+	}
+	if len(accounts) == 0 {
+		return errors.New("no accounts cached locally yet; launch giddyup and open /accounts to sync them first")
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(accounts)
+	}
+	if _, err := fmt.Fprintln(out, "name\ttype\tbalance\tgoal"); err != nil {
+		return err
+	}
+	for _, acct := range accounts {
+		goal := acct.GoalBalance
+		if strings.TrimSpace(goal) == "" {
+			goal = "-"
+		}
+		if _, err := fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", acct.DisplayName, acct.AccountType, acct.BalanceValue, goal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runSync forces a sync of accounts, transactions or both, then reports when
+// each collection last synced successfully. --since scopes the transactions
+// sync to a date, or to the newest stored transaction with "latest".
+func runSync(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("giddyup sync", flag.ContinueOnError)
+	sinceRaw := fs.String("since", "", "only sync transactions created on or after YYYY-MM-DD, or \"latest\" for those newer than the newest stored")
+	scope := ""
+	// Accept the scope before or after the flags: sync transactions --since ...
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		scope, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	rest := fs.Args()
+	if scope == "" && len(rest) > 0 {
+		scope, rest = rest[0], rest[1:]
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("unexpected argument %q", rest[0])
+	}
+	collections := []string{syncer.CollectionAccounts, syncer.CollectionTransactions}
+	switch scope {
+	case "":
+	case syncer.CollectionAccounts, syncer.CollectionTransactions:
+		collections = []string{scope}
+	default:
+		return fmt.Errorf("unexpected argument %q; expected accounts or transactions", scope)
+	}
+	sinceRawTrimmed := strings.TrimSpace(*sinceRaw)
+	if sinceRawTrimmed != "" && scope == syncer.CollectionAccounts {
+		return errors.New("--since only applies to transactions")
+	}
+
+	db, _, err := initDB()
+	if err != nil {
+		return fmt.Errorf("db setup error: %w", err)
+	}
+	defer db.Close()
+
+	var since time.Time
+	switch {
+	case sinceRawTrimmed == "":
+	case strings.EqualFold(sinceRawTrimmed, "latest"):
+		latest, found, err := storage.NewTransactionsRepo(db).LatestCreatedAt(context.Background())
+		if err != nil {
+			return err
+		}
+		// With nothing stored yet there is nothing to be incremental from.
+		if found {
+			since = latest
+		}
+	default:
+		since, err = time.ParseInLocation("2006-01-02", sinceRawTrimmed, time.Local)
+		if err != nil {
+			return fmt.Errorf("--since: expected YYYY-MM-DD or latest, got %q", sinceRawTrimmed)
+		}
+	}
+
+	repo := storage.NewSyncStateRepo(db)
+	var skipped []string
+	for _, collection := range collections {
+		label := collection
+		sync := tui.SyncAccounts
+		if collection == syncer.CollectionTransactions {
+			sync = tui.SyncTransactions
+			if !since.IsZero() {
+				label += " since " + since.In(time.Local).Format("2006-01-02 15:04:05")
+				sync = func(db *sql.DB) error { return tui.SyncTransactionsSince(db, since) }
+			}
+		}
+		if _, err := fmt.Fprintf(out, "syncing %s...\n", label); err != nil {
+			return err
+		}
+		if err := sync(db); err != nil {
+			var skip *tui.SyncSkippedError
+			if !errors.As(err, &skip) {
+				return fmt.Errorf("%s: %w", collection, err)
+			}
+			if _, err := fmt.Fprintf(out, "%s skipped: next sync allowed at %s\n",
+				label, skip.NextAllowed.In(time.Local).Format("2006-01-02 15:04:05")); err != nil {
+				return err
+			}
+			skipped = append(skipped, collection)
+			continue
+		}
+
+		state, found, err := repo.Get(context.Background(), collection)
+		if err != nil {
+			return err
+		}
+		// Check the recorded state too, so an error the attempt stored is
+		// never reported as a success.
+		if found && strings.TrimSpace(state.LastErrorMsg) != "" && state.LastAttempt != nil &&
+			(state.LastSuccess == nil || state.LastAttempt.After(*state.LastSuccess)) {
+			return fmt.Errorf("%s: %s", collection, state.LastErrorMsg)
+		}
+		lastSuccess := "never"
+		if found && state.LastSuccess != nil {
+			lastSuccess = state.LastSuccess.In(time.Local).Format("2006-01-02 15:04:05")
+		}
+		if _, err := fmt.Fprintf(out, "%s last synced %s\n", collection, lastSuccess); err != nil {
+			return err
+		}
+	}
+	if len(skipped) > 0 {
+		return fmt.Errorf("%s not synced: the minimum sync interval has not passed", strings.Join(skipped, " and "))
+	}
+	return nil
+}
+
+// runDBOptimize compacts the local database file and reports its size
+// before and after.
+func runDBOptimize(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("giddyup db optimize", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	db, _, err := initDB()
+	if err != nil {
+		return fmt.Errorf("db setup error: %w", err)
+	}
+	defer db.Close()
+
+	result, err := storage.Optimize(context.Background(), db)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(out, "optimized %s: %s\n", result.Path, result)
+	return err
+}
+
+// pingResult is the --json output of runPing, shaped for health checks.
+type pingResult struct {
+	Connected bool   `json:"connected"`
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// runPing checks the saved token against the Up API and reports how long the
+// round trip took. It never touches the local database.
+func runPing(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("giddyup ping", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "print the result as a JSON object")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", fs.Arg(0))
+	}
+
+	var latency time.Duration
+	pat, err := auth.LoadPAT()
+	if err == nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		started := time.Now()
+		err = upapi.New(pat).Ping(ctx)
+		latency = time.Since(started)
+	}
+
+	if *asJSON {
+		result := pingResult{Connected: err == nil, LatencyMS: latency.Milliseconds()}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if encErr := enc.Encode(result); encErr != nil {
+			return encErr
+		}
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, "connected successfully")
+	return err
+}
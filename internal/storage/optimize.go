@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// OptimizeResult is the on-disk size of the database and its -wal/-shm
+// files before and after Optimize.
+type OptimizeResult struct {
+	Path        string
+	BeforeBytes int64
+	AfterBytes  int64
+}
+
+func (r OptimizeResult) String() string {
+	return fmt.Sprintf("%s -> %s", formatByteSize(r.BeforeBytes), formatByteSize(r.AfterBytes))
+}
+
+// Optimize folds the WAL back into the database file and rebuilds it with
+// VACUUM, returning the space left behind by repeated syncs. Both run on one
+// pooled connection, which was opened with the sqlcipher key, so the rebuilt
+// file is encrypted with the same key.
+func Optimize(ctx context.Context, db *sql.DB) (OptimizeResult, error) {
+	cfg, err := configFromEnv()
+	if err != nil {
+		return OptimizeResult{}, err
+	}
+	before, err := localDBFilesSize(cfg.Path)
+	if err != nil {
+		return OptimizeResult{}, fmt.Errorf("measure db files: %w", err)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return OptimizeResult{}, err
+	}
+	defer conn.Close()
+	if err := checkpointTruncate(ctx, conn); err != nil {
+		return OptimizeResult{}, err
+	}
+	if _, err := conn.ExecContext(ctx, "VACUUM"); err != nil {
+		return OptimizeResult{}, fmt.Errorf("vacuum: %w", err)
+	}
+	// In WAL mode VACUUM writes the rebuilt pages to the WAL, so fold them
+	// in again for the main file to shrink.
+	if err := checkpointTruncate(ctx, conn); err != nil {
+		return OptimizeResult{}, err
+	}
+
+	after, err := localDBFilesSize(cfg.Path)
+	if err != nil {
+		return OptimizeResult{}, fmt.Errorf("measure db files: %w", err)
+	}
+	return OptimizeResult{Path: cfg.Path, BeforeBytes: before, AfterBytes: after}, nil
+}
+
+func checkpointTruncate(ctx context.Context, conn *sql.Conn) error {
+	var busy, logFrames, checkpointed int
+	err := conn.QueryRowContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)").Scan(&busy, &logFrames, &checkpointed)
+	if err != nil {
+		return fmt.Errorf("wal checkpoint: %w", err)
+	}
+	if busy != 0 {
+		return errors.New("wal checkpoint: database is busy; try again once syncing finishes")
+	}
+	return nil
+}
+
+// localDBFilesSize totals the db and its -wal/-shm companions, skipping
+// any that don't exist.
+func localDBFilesSize(path string) (int64, error) {
+	var total int64
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		info, err := os.Stat(path + suffix)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		total += info.Size()
+	}
+	return total, nil
+}
+
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGT"[exp])
+}
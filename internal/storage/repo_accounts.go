@@ -35,7 +35,38 @@ func (r *AccountsRepo) HasActiveAccounts(ctx context.Context) (bool, error) {
 	return exists == 1, nil
 }
 
+// SkipAutoSyncIDs returns the active accounts flagged to be left out of
+// automatic refreshes.
+func (r *AccountsRepo) SkipAutoSyncIDs(ctx context.Context) (map[string]bool, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM accounts WHERE is_active = 1 AND skip_auto_sync = 1`)
+	if err != nil {
+		return nil, fmt.Errorf("query skip auto-sync accounts: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]bool)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan skip auto-sync account: %w", err)
+		}
+		out[id] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate skip auto-sync accounts: %w", err)
+	}
+	return out, nil
+}
+
 func (r *AccountsRepo) ReplaceSnapshot(ctx context.Context, accounts []Account, fetchedAt time.Time) error {
+	return r.ReplaceSnapshotKeeping(ctx, accounts, nil, fetchedAt)
+}
+
+// ReplaceSnapshotKeeping is ReplaceSnapshot for a sync that deliberately
+// left some accounts out: rows for keepIDs are neither updated nor
+// deactivated. Local-only columns such as goal_balance and
+// display_name_override are never written here.
+func (r *AccountsRepo) ReplaceSnapshotKeeping(ctx context.Context, accounts []Account, keepIDs []string, fetchedAt time.Time) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin accounts snapshot transaction: %w", err)
@@ -89,7 +120,7 @@ ON CONFLICT(id) DO UPDATE SET
 		}
 	}
 
-	if err = deactivateMissingAccounts(ctx, tx, accounts); err != nil {
+	if err = deactivateMissingAccounts(ctx, tx, accounts, keepIDs); err != nil {
 		return err
 	}
 
@@ -99,19 +130,23 @@ ON CONFLICT(id) DO UPDATE SET
 	return nil
 }
 
-func deactivateMissingAccounts(ctx context.Context, tx *sql.Tx, accounts []Account) error {
-	if len(accounts) == 0 {
+func deactivateMissingAccounts(ctx context.Context, tx *sql.Tx, accounts []Account, keepIDs []string) error {
+	if len(accounts) == 0 && len(keepIDs) == 0 {
 		if _, err := tx.ExecContext(ctx, `UPDATE accounts SET is_active = 0`); err != nil {
 			return fmt.Errorf("deactivate all accounts: %w", err)
 		}
 		return nil
 	}
 
-	placeholders := make([]string, len(accounts))
-	args := make([]any, len(accounts))
-	for i, acct := range accounts {
-		placeholders[i] = "?"
-		args[i] = acct.ID
+	placeholders := make([]string, 0, len(accounts)+len(keepIDs))
+	args := make([]any, 0, len(accounts)+len(keepIDs))
+	for _, acct := range accounts {
+		placeholders = append(placeholders, "?")
+		args = append(args, acct.ID)
+	}
+	for _, id := range keepIDs {
+		placeholders = append(placeholders, "?")
+		args = append(args, id)
 	}
 
 	q := fmt.Sprintf(
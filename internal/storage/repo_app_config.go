@@ -27,6 +27,34 @@ func (r *AppConfigRepo) Get(ctx context.Context, key string) (string, bool, erro
 	return value, true, nil
 }
 
+// ListByPrefix returns every stored key that starts with prefix, with its
+// value.
+func (r *AppConfigRepo) ListByPrefix(ctx context.Context, prefix string) (map[string]string, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT key, value FROM app_config WHERE substr(key, 1, ?) = ?",
+		len(prefix),
+		prefix,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list app config %q: %w", prefix, err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("scan app config %q: %w", prefix, err)
+		}
+		out[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate app config %q: %w", prefix, err)
+	}
+	return out, nil
+}
+
 func (r *AppConfigRepo) UpsertMany(ctx context.Context, values map[string]string) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type CategoryAliasesRepo struct {
+	db *sql.DB
+}
+
+func NewCategoryAliasesRepo(db *sql.DB) *CategoryAliasesRepo {
+	return &CategoryAliasesRepo{db: db}
+}
+
+// List returns every alias keyed by category ID.
+func (r *CategoryAliasesRepo) List(ctx context.Context) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT category_id, alias FROM category_aliases")
+	if err != nil {
+		return nil, fmt.Errorf("list category aliases: %w", err)
+	}
+	defer rows.Close()
+
+	out := map[string]string{}
+	for rows.Next() {
+		var id, alias string
+		if err := rows.Scan(&id, &alias); err != nil {
+			return nil, fmt.Errorf("scan category alias: %w", err)
+		}
+		out[id] = alias
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate category aliases: %w", err)
+	}
+	return out, nil
+}
+
+func (r *CategoryAliasesRepo) Upsert(ctx context.Context, categoryID, alias string) error {
+	if _, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO category_aliases (category_id, alias, updated_at) VALUES (?, ?, ?)
+		 ON CONFLICT(category_id) DO UPDATE SET alias = excluded.alias, updated_at = excluded.updated_at`,
+		categoryID,
+		alias,
+		time.Now().UTC().Format(time.RFC3339Nano),
+	); err != nil {
+		return fmt.Errorf("upsert category alias %q: %w", categoryID, err)
+	}
+	return nil
+}
+
+func (r *CategoryAliasesRepo) Delete(ctx context.Context, categoryID string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM category_aliases WHERE category_id = ?", categoryID); err != nil {
+		return fmt.Errorf("delete category alias %q: %w", categoryID, err)
+	}
+	return nil
+}
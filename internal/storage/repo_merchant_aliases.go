@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// MerchantAlias maps a merchant pattern to the name shown in its place.
+type MerchantAlias struct {
+	Pattern string
+	Alias   string
+}
+
+type MerchantAliasesRepo struct {
+	db *sql.DB
+}
+
+func NewMerchantAliasesRepo(db *sql.DB) *MerchantAliasesRepo {
+	return &MerchantAliasesRepo{db: db}
+}
+
+func (r *MerchantAliasesRepo) List(ctx context.Context) ([]MerchantAlias, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT pattern, alias FROM merchant_aliases ORDER BY pattern")
+	if err != nil {
+		return nil, fmt.Errorf("list merchant aliases: %w", err)
+	}
+	defer rows.Close()
+
+	var out []MerchantAlias
+	for rows.Next() {
+		var a MerchantAlias
+		if err := rows.Scan(&a.Pattern, &a.Alias); err != nil {
+			return nil, fmt.Errorf("scan merchant alias: %w", err)
+		}
+		out = append(out, a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate merchant aliases: %w", err)
+	}
+	return out, nil
+}
+
+func (r *MerchantAliasesRepo) Upsert(ctx context.Context, pattern, alias string) error {
+	if _, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO merchant_aliases (pattern, alias, updated_at) VALUES (?, ?, ?)
+		 ON CONFLICT(pattern) DO UPDATE SET alias = excluded.alias, updated_at = excluded.updated_at`,
+		pattern,
+		alias,
+		time.Now().UTC().Format(time.RFC3339Nano),
+	); err != nil {
+		return fmt.Errorf("upsert merchant alias %q: %w", pattern, err)
+	}
+	return nil
+}
+
+func (r *MerchantAliasesRepo) Delete(ctx context.Context, pattern string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM merchant_aliases WHERE pattern = ?", pattern); err != nil {
+		return fmt.Errorf("delete merchant alias %q: %w", pattern, err)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// TransactionNotesRepo stores the user's own notes on transactions, kept
+// apart from Up's read-only note_text.
+type TransactionNotesRepo struct {
+	db *sql.DB
+}
+
+func NewTransactionNotesRepo(db *sql.DB) *TransactionNotesRepo {
+	return &TransactionNotesRepo{db: db}
+}
+
+func (r *TransactionNotesRepo) Get(ctx context.Context, transactionID string) (string, bool, error) {
+	var note string
+	err := r.db.QueryRowContext(ctx, "SELECT note FROM transaction_notes WHERE transaction_id = ?", transactionID).Scan(&note)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("get transaction note %q: %w", transactionID, err)
+	}
+	return note, true, nil
+}
+
+func (r *TransactionNotesRepo) Upsert(ctx context.Context, transactionID, note string) error {
+	if _, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO transaction_notes (transaction_id, note, updated_at) VALUES (?, ?, ?)
+		 ON CONFLICT(transaction_id) DO UPDATE SET note = excluded.note, updated_at = excluded.updated_at`,
+		transactionID,
+		note,
+		time.Now().UTC().Format(time.RFC3339Nano),
+	); err != nil {
+		return fmt.Errorf("upsert transaction note %q: %w", transactionID, err)
+	}
+	return nil
+}
+
+func (r *TransactionNotesRepo) Delete(ctx context.Context, transactionID string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM transaction_notes WHERE transaction_id = ?", transactionID); err != nil {
+		return fmt.Errorf("delete transaction note %q: %w", transactionID, err)
+	}
+	return nil
+}
@@ -91,6 +91,22 @@ func (r *TransactionsRepo) HasAny(ctx context.Context) (bool, error) {
 	return exists == 1, nil
 }
 
+// LatestCreatedAt returns the created_at of the newest active transaction.
+func (r *TransactionsRepo) LatestCreatedAt(ctx context.Context) (time.Time, bool, error) {
+	var raw sql.NullString
+	if err := r.db.QueryRowContext(ctx, `SELECT MAX(created_at) FROM transactions WHERE is_active = 1`).Scan(&raw); err != nil {
+		return time.Time{}, false, fmt.Errorf("query latest transaction: %w", err)
+	}
+	if !raw.Valid || strings.TrimSpace(raw.String) == "" {
+		return time.Time{}, false, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw.String)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("parse latest transaction created_at: %w", err)
+	}
+	return t, true, nil
+}
+
 func (r *TransactionsRepo) KnownIDs(ctx context.Context, ids []string) (map[string]bool, error) {
 	out := make(map[string]bool, len(ids))
 	if len(ids) == 0 {
@@ -121,6 +137,110 @@ func (r *TransactionsRepo) KnownIDs(ctx context.Context, ids []string) (map[stri
 	return out, nil
 }
 
+// deactivateChunkSize caps the ids bound into one UPDATE so it stays under
+// SQLite's variable limit.
+const deactivateChunkSize = 500
+
+// DeactivateMissing marks active transactions created in [since, until] that
+// are not in seen as inactive, so ones removed from Up (such as reversed
+// holds) stop showing. seen must hold every id the API returned for that
+// window; a zero since reaches back to the first transaction. It returns how
+// many rows were deactivated.
+func (r *TransactionsRepo) DeactivateMissing(ctx context.Context, since, until time.Time, seen map[string]bool) (int, error) {
+	window, args := transactionsWindowClause(since, until)
+	rows, err := r.db.QueryContext(ctx, "SELECT id FROM transactions WHERE is_active = 1 AND "+window, args...)
+	if err != nil {
+		return 0, fmt.Errorf("query transactions in sync window: %w", err)
+	}
+	var stored []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("scan transaction in sync window: %w", err)
+		}
+		stored = append(stored, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("iterate transactions in sync window: %w", err)
+	}
+
+	missing := missingTransactionIDs(stored, seen)
+	if len(missing) == 0 {
+		return 0, nil
+	}
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("begin transactions deactivate transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for start := 0; start < len(missing); start += deactivateChunkSize {
+		chunk := missing[start:min(len(missing), start+deactivateChunkSize)]
+		placeholders := make([]string, len(chunk))
+		chunkArgs := make([]any, len(chunk))
+		for i, id := range chunk {
+			placeholders[i] = "?"
+			chunkArgs[i] = id
+		}
+		q := fmt.Sprintf("UPDATE transactions SET is_active = 0 WHERE id IN (%s)", strings.Join(placeholders, ","))
+		if _, err = tx.ExecContext(ctx, q, chunkArgs...); err != nil {
+			return 0, fmt.Errorf("deactivate missing transactions: %w", err)
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("commit transactions deactivate transaction: %w", err)
+	}
+	return len(missing), nil
+}
+
+// DeactivateBefore marks active transactions created before floor as
+// inactive, so rows a narrower sync window no longer fetches stop showing.
+// It returns how many rows were deactivated.
+func (r *TransactionsRepo) DeactivateBefore(ctx context.Context, floor time.Time) (int, error) {
+	res, err := r.db.ExecContext(
+		ctx,
+		"UPDATE transactions SET is_active = 0 WHERE is_active = 1 AND datetime(created_at) < datetime(?)",
+		floor.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("deactivate transactions before sync window: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("count transactions deactivated before sync window: %w", err)
+	}
+	return int(n), nil
+}
+
+// transactionsWindowClause bounds created_at to [since, until]. created_at
+// keeps Up's local offset, so both sides are compared through datetime().
+func transactionsWindowClause(since, until time.Time) (string, []any) {
+	clause := "datetime(created_at) <= datetime(?)"
+	args := []any{until.UTC().Format(time.RFC3339)}
+	if !since.IsZero() {
+		clause = "datetime(created_at) >= datetime(?) AND " + clause
+		args = append([]any{since.UTC().Format(time.RFC3339)}, args...)
+	}
+	return clause, args
+}
+
+// missingTransactionIDs lists the stored ids the sync didn't see, in stored
+// order.
+func missingTransactionIDs(stored []string, seen map[string]bool) []string {
+	var missing []string
+	for _, id := range stored {
+		if !seen[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
 func (r *TransactionsRepo) UpsertBatch(ctx context.Context, records []TransactionRecord, fetchedAt time.Time) error {
 	if len(records) == 0 {
 		return nil
@@ -375,3 +495,105 @@ func emptyIfBlank(s string) string {
 	}
 	return s
 }
+
+// AddTag records a tag locally for a transaction after it has been applied
+// through the API, so the change is visible before the next full sync.
+func (r *TransactionsRepo) AddTag(ctx context.Context, transactionID, tagID string) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO transaction_tags (transaction_id, tag_id, tag_type, relationship_link_self, last_fetched_at, is_active)
+		 VALUES (?, ?, 'tags', NULL, ?, 1)
+		 ON CONFLICT(transaction_id, tag_id) DO UPDATE SET
+		   last_fetched_at = excluded.last_fetched_at,
+		   is_active = 1`,
+		transactionID,
+		tagID,
+		time.Now().UTC().Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return fmt.Errorf("add transaction tag %q/%q: %w", transactionID, tagID, err)
+	}
+	return nil
+}
+
+// GetRecord loads the stored fields of a single transaction, including its
+// active tags. The bool result is false when no transaction has the given id.
+func (r *TransactionsRepo) GetRecord(ctx context.Context, id string) (TransactionRecord, bool, error) {
+	var rcd TransactionRecord
+	var isCategorizable int
+	var resourceType sql.NullString
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT
+		  id, account_id, status, description, message,
+		  amount_currency_code, amount_value, amount_value_in_base_units,
+		  created_at, settled_at,
+		  resource_type, raw_text, COALESCE(is_categorizable, 0),
+		  hold_amount_currency_code, hold_amount_value, hold_amount_value_in_base_units,
+		  hold_foreign_amount_currency_code, hold_foreign_amount_value, hold_foreign_amount_value_in_base_units,
+		  round_up_amount_currency_code, round_up_amount_value, round_up_amount_value_in_base_units,
+		  round_up_boost_portion_currency_code, round_up_boost_portion_value, round_up_boost_portion_value_in_base_units,
+		  cashback_description, cashback_amount_currency_code, cashback_amount_value, cashback_amount_value_in_base_units,
+		  foreign_amount_currency_code, foreign_amount_value, foreign_amount_value_in_base_units,
+		  card_purchase_method_method, card_purchase_method_card_number_suffix,
+		  transaction_type, note_text, performing_customer_display_name, deep_link_url,
+		  account_resource_type, account_link_related,
+		  transfer_account_resource_type, transfer_account_id, transfer_account_link_related,
+		  category_resource_type, category_id, category_link_self, category_link_related,
+		  parent_category_resource_type, parent_category_id, parent_category_link_related,
+		  tags_link_self, attachment_resource_type, attachment_id, attachment_link_related, resource_link_self
+		 FROM transactions
+		 WHERE id = ?`,
+		id,
+	).Scan(
+		&rcd.ID, &rcd.AccountID, &rcd.Status, &rcd.Description, &rcd.Message,
+		&rcd.AmountCurrencyCode, &rcd.AmountValue, &rcd.AmountValueInBaseUnits,
+		&rcd.CreatedAt, &rcd.SettledAt,
+		&resourceType, &rcd.RawText, &isCategorizable,
+		&rcd.HoldAmountCurrencyCode, &rcd.HoldAmountValue, &rcd.HoldAmountValueInBaseUnits,
+		&rcd.HoldForeignAmountCurrencyCode, &rcd.HoldForeignAmountValue, &rcd.HoldForeignAmountValueInBaseUnits,
+		&rcd.RoundUpAmountCurrencyCode, &rcd.RoundUpAmountValue, &rcd.RoundUpAmountValueInBaseUnits,
+		&rcd.RoundUpBoostPortionCurrencyCode, &rcd.RoundUpBoostPortionValue, &rcd.RoundUpBoostPortionValueInBaseUnits,
+		&rcd.CashbackDescription, &rcd.CashbackAmountCurrencyCode, &rcd.CashbackAmountValue, &rcd.CashbackAmountValueInBaseUnits,
+		&rcd.ForeignAmountCurrencyCode, &rcd.ForeignAmountValue, &rcd.ForeignAmountValueInBaseUnits,
+		&rcd.CardPurchaseMethodMethod, &rcd.CardPurchaseMethodCardNumberSuffix,
+		&rcd.TransactionType, &rcd.NoteText, &rcd.PerformingCustomerDisplayName, &rcd.DeepLinkURL,
+		&rcd.AccountResourceType, &rcd.AccountLinkRelated,
+		&rcd.TransferAccountResourceType, &rcd.TransferAccountID, &rcd.TransferAccountLinkRelated,
+		&rcd.CategoryResourceType, &rcd.CategoryID, &rcd.CategoryLinkSelf, &rcd.CategoryLinkRelated,
+		&rcd.ParentCategoryResourceType, &rcd.ParentCategoryID, &rcd.ParentCategoryLinkRelated,
+		&rcd.TagsLinkSelf, &rcd.AttachmentResourceType, &rcd.AttachmentID, &rcd.AttachmentLinkRelated, &rcd.ResourceLinkSelf,
+	)
+	if err == sql.ErrNoRows {
+		return TransactionRecord{}, false, nil
+	}
+	if err != nil {
+		return TransactionRecord{}, false, fmt.Errorf("get transaction %q: %w", id, err)
+	}
+	rcd.ResourceType = resourceType.String
+	rcd.IsCategorizable = isCategorizable == 1
+
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT tag_id, tag_type, relationship_link_self
+		 FROM transaction_tags
+		 WHERE transaction_id = ? AND is_active = 1
+		 ORDER BY tag_id ASC`,
+		id,
+	)
+	if err != nil {
+		return TransactionRecord{}, false, fmt.Errorf("query transaction tags %q: %w", id, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tag TransactionTag
+		if err := rows.Scan(&tag.TagID, &tag.TagType, &tag.LinkSelf); err != nil {
+			return TransactionRecord{}, false, fmt.Errorf("scan transaction tag %q: %w", id, err)
+		}
+		rcd.Tags = append(rcd.Tags, tag)
+	}
+	if err := rows.Err(); err != nil {
+		return TransactionRecord{}, false, fmt.Errorf("iterate transaction tags %q: %w", id, err)
+	}
+	return rcd, true, nil
+}
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTransactionsWindowClause(t *testing.T) {
+	t.Parallel()
+
+	until := time.Date(2026, 10, 16, 9, 30, 0, 0, time.FixedZone("AEST", 10*60*60))
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	clause, args := transactionsWindowClause(since, until)
+	if want := "datetime(created_at) >= datetime(?) AND datetime(created_at) <= datetime(?)"; clause != want {
+		t.Fatalf("transactionsWindowClause() clause = %q, want %q", clause, want)
+	}
+	if want := []any{"2026-10-01T00:00:00Z", "2026-10-15T23:30:00Z"}; !reflect.DeepEqual(args, want) {
+		t.Fatalf("transactionsWindowClause() args = %v, want %v", args, want)
+	}
+
+	// A full history walk has no lower bound, but still stops at the start
+	// of the sync so rows stored by a later sync are never touched.
+	clause, args = transactionsWindowClause(time.Time{}, until)
+	if want := "datetime(created_at) <= datetime(?)"; clause != want {
+		t.Fatalf("transactionsWindowClause(zero since) clause = %q, want %q", clause, want)
+	}
+	if want := []any{"2026-10-15T23:30:00Z"}; !reflect.DeepEqual(args, want) {
+		t.Fatalf("transactionsWindowClause(zero since) args = %v, want %v", args, want)
+	}
+}
+
+func TestMissingTransactionIDs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		stored []string
+		seen   map[string]bool
+		want   []string
+	}{
+		{name: "all seen", stored: []string{"a", "b"}, seen: map[string]bool{"a": true, "b": true, "c": true}, want: nil},
+		{name: "reversed hold", stored: []string{"a", "held", "b"}, seen: map[string]bool{"a": true, "b": true}, want: []string{"held"}},
+		{name: "nothing seen", stored: []string{"a", "b"}, seen: map[string]bool{}, want: []string{"a", "b"}},
+		{name: "nothing stored", stored: nil, seen: map[string]bool{"a": true}, want: nil},
+	}
+	for _, tt := range tests {
+		if got := missingTransactionIDs(tt.stored, tt.seen); !reflect.DeepEqual(got, tt.want) {
+			t.Fatalf("%s: missingTransactionIDs() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
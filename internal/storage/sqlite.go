@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/lachiem1/giddyUp/internal/auth"
 )
@@ -20,13 +21,26 @@ const (
 	ModeSecure Mode = "secure"
 )
 
-const schemaVersion = 8
+const schemaVersion = 14
 
 type Config struct {
 	Mode Mode
 	Path string
 }
 
+// SchemaTooNewError reports a database written by a newer build. Older
+// binaries cannot safely read it, so callers should offer to back it up and
+// start fresh rather than retrying.
+type SchemaTooNewError struct {
+	Path      string
+	Found     int
+	Supported int
+}
+
+func (e *SchemaTooNewError) Error() string {
+	return fmt.Sprintf("database schema version %d is newer than supported version %d", e.Found, e.Supported)
+}
+
 func Open(ctx context.Context) (*sql.DB, Config, error) {
 	cfg, err := configFromEnv()
 	if err != nil {
@@ -66,6 +80,10 @@ func Open(ctx context.Context) (*sql.DB, Config, error) {
 	}
 	if err := runMigrations(ctx, db); err != nil {
 		db.Close()
+		var tooNew *SchemaTooNewError
+		if errors.As(err, &tooNew) {
+			tooNew.Path = cfg.Path
+		}
 		return nil, Config{}, err
 	}
 
@@ -84,6 +102,21 @@ func Wipe() (Config, error) {
 	return cfg, nil
 }
 
+// BackupAndWipe moves the local database files aside under a timestamped
+// name and returns that backup path. The db key is left in the keychain so
+// the backup can still be opened by a build that understands it.
+func BackupAndWipe() (Config, string, error) {
+	cfg, err := configFromEnv()
+	if err != nil {
+		return Config{}, "", err
+	}
+	backup, err := backupLocalDBFiles(cfg.Path, time.Now().Format("20060102-150405"))
+	if err != nil {
+		return Config{}, "", fmt.Errorf("back up local db files: %w", err)
+	}
+	return cfg, backup, nil
+}
+
 func configFromEnv() (Config, error) {
 	if dbPath := strings.TrimSpace(os.Getenv("GIDDYUP_DB_PATH")); dbPath != "" {
 		return Config{
@@ -128,6 +161,12 @@ func generateRandomKey() (string, error) {
 	return base64.RawStdEncoding.EncodeToString(buf), nil
 }
 
+// Migrate brings db up to the current schema. Open already does this; it is
+// exported for tests that open a plain SQLite database of their own.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	return runMigrations(ctx, db)
+}
+
 func runMigrations(ctx context.Context, db *sql.DB) error {
 	const bootstrapSchema = `
 CREATE TABLE IF NOT EXISTS schema_migrations (
@@ -188,9 +227,45 @@ INSERT OR IGNORE INTO schema_migrations (id, version) VALUES (1, 1);
 		}
 		currentVersion = 8
 	}
+	if currentVersion < 9 {
+		if err := applyV9Migrations(ctx, db); err != nil {
+			return err
+		}
+		currentVersion = 9
+	}
+	if currentVersion < 10 {
+		if err := applyV10Migrations(ctx, db); err != nil {
+			return err
+		}
+		currentVersion = 10
+	}
+	if currentVersion < 11 {
+		if err := applyV11Migrations(ctx, db); err != nil {
+			return err
+		}
+		currentVersion = 11
+	}
+	if currentVersion < 12 {
+		if err := applyV12Migrations(ctx, db); err != nil {
+			return err
+		}
+		currentVersion = 12
+	}
+	if currentVersion < 13 {
+		if err := applyV13Migrations(ctx, db); err != nil {
+			return err
+		}
+		currentVersion = 13
+	}
+	if currentVersion < 14 {
+		if err := applyV14Migrations(ctx, db); err != nil {
+			return err
+		}
+		currentVersion = 14
+	}
 
 	if currentVersion > schemaVersion {
-		return fmt.Errorf("database schema version %d is newer than supported version %d", currentVersion, schemaVersion)
+		return &SchemaTooNewError{Found: currentVersion, Supported: schemaVersion}
 	}
 
 	return nil
@@ -579,6 +654,198 @@ func applyV8Migrations(ctx context.Context, db *sql.DB) error {
 	return nil
 }
 
+// applyV9Migrations adds an index matching the default table ordering so
+// unfiltered date-sorted pages and counts avoid a full sort of the table.
+func applyV9Migrations(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin sqlite migration v9 transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(
+		ctx,
+		"CREATE INDEX IF NOT EXISTS idx_transactions_active_created_at ON transactions(is_active, created_at, id)",
+	); err != nil {
+		return fmt.Errorf("create transactions active created_at index: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE schema_migrations SET version = 9 WHERE id = 1"); err != nil {
+		return fmt.Errorf("update sqlite schema version to 9: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit sqlite v9 migrations: %w", err)
+	}
+	return nil
+}
+
+// applyV10Migrations adds a local per-account opt-out from automatic
+// refreshes. Flagged accounts are only fetched by manual syncs.
+func applyV10Migrations(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin sqlite migration v10 transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	hasSkipAutoSync, err := tableHasColumn(ctx, tx, "accounts", "skip_auto_sync")
+	if err != nil {
+		return err
+	}
+	if !hasSkipAutoSync {
+		if _, err = tx.ExecContext(
+			ctx,
+			"ALTER TABLE accounts ADD COLUMN skip_auto_sync INTEGER NOT NULL DEFAULT 0 CHECK (skip_auto_sync IN (0,1))",
+		); err != nil {
+			return fmt.Errorf("add accounts.skip_auto_sync column: %w", err)
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE schema_migrations SET version = 10 WHERE id = 1"); err != nil {
+		return fmt.Errorf("update sqlite schema version to 10: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit sqlite v10 migrations: %w", err)
+	}
+	return nil
+}
+
+// applyV11Migrations adds a local display name that takes the place of the
+// synced one. Syncs never write it, so a rename survives them.
+func applyV11Migrations(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin sqlite migration v11 transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	hasOverride, err := tableHasColumn(ctx, tx, "accounts", "display_name_override")
+	if err != nil {
+		return err
+	}
+	if !hasOverride {
+		if _, err = tx.ExecContext(ctx, "ALTER TABLE accounts ADD COLUMN display_name_override TEXT"); err != nil {
+			return fmt.Errorf("add accounts.display_name_override column: %w", err)
+		}
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE schema_migrations SET version = 11 WHERE id = 1"); err != nil {
+		return fmt.Errorf("update sqlite schema version to 11: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit sqlite v11 migrations: %w", err)
+	}
+	return nil
+}
+
+// applyV12Migrations adds local transaction notes. They live in their own
+// table, without a foreign key, so sync upserts never touch them.
+func applyV12Migrations(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin sqlite migration v12 transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS transaction_notes (
+  transaction_id TEXT PRIMARY KEY,
+  note TEXT NOT NULL,
+  updated_at TEXT NOT NULL
+);
+`); err != nil {
+		return fmt.Errorf("create transaction_notes table: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE schema_migrations SET version = 12 WHERE id = 1"); err != nil {
+		return fmt.Errorf("update sqlite schema version to 12: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit sqlite v12 migrations: %w", err)
+	}
+	return nil
+}
+
+// applyV13Migrations adds merchant aliases: friendly names shown in place
+// of matching merchant_norm values. Patterns are stored upper case.
+func applyV13Migrations(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin sqlite migration v13 transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS merchant_aliases (
+  pattern TEXT PRIMARY KEY,
+  alias TEXT NOT NULL,
+  updated_at TEXT NOT NULL
+);
+`); err != nil {
+		return fmt.Errorf("create merchant_aliases table: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE schema_migrations SET version = 13 WHERE id = 1"); err != nil {
+		return fmt.Errorf("update sqlite schema version to 13: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit sqlite v13 migrations: %w", err)
+	}
+	return nil
+}
+
+// applyV14Migrations adds category aliases: display names shown in place of
+// Up's category IDs. Search and budgets keep using the ID.
+func applyV14Migrations(ctx context.Context, db *sql.DB) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin sqlite migration v14 transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS category_aliases (
+  category_id TEXT PRIMARY KEY,
+  alias TEXT NOT NULL,
+  updated_at TEXT NOT NULL
+);
+`); err != nil {
+		return fmt.Errorf("create category_aliases table: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, "UPDATE schema_migrations SET version = 14 WHERE id = 1"); err != nil {
+		return fmt.Errorf("update sqlite schema version to 14: %w", err)
+	}
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit sqlite v14 migrations: %w", err)
+	}
+	return nil
+}
+
 func backfillTransactionsNormalizedText(ctx context.Context, tx *sql.Tx) error {
 	type txRow struct {
 		id             string
@@ -686,6 +953,19 @@ func resetLocalDBFiles(path string) error {
 	return nil
 }
 
+// backupLocalDBFiles renames the db and its -wal/-shm companions to
+// path.bak-<stamp>, keeping the suffixes so SQLite pairs them on open.
+func backupLocalDBFiles(path string, stamp string) (string, error) {
+	backup := path + ".bak-" + stamp
+	for _, suffix := range []string{"", "-wal", "-shm"} {
+		err := os.Rename(path+suffix, backup+suffix)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+	return backup, nil
+}
+
 func hasLocalDBFiles(path string) (bool, error) {
 	paths := []string{
 		path,
@@ -52,3 +52,66 @@ func TestHasLocalDBFilesDetectsWalOrShm(t *testing.T) {
 		t.Fatal("hasLocalDBFiles() = false, want true")
 	}
 }
+
+func TestBackupLocalDBFilesMovesDBAndWal(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "giddyup.db")
+	for _, p := range []string{path, path + "-wal"} {
+		if err := os.WriteFile(p, []byte("db"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", p, err)
+		}
+	}
+
+	backup, err := backupLocalDBFiles(path, "20260101-000000")
+	if err != nil {
+		t.Fatalf("backupLocalDBFiles() unexpected error: %v", err)
+	}
+	if want := path + ".bak-20260101-000000"; backup != want {
+		t.Fatalf("backupLocalDBFiles() = %q, want %q", backup, want)
+	}
+	exists, err := hasLocalDBFiles(path)
+	if err != nil {
+		t.Fatalf("hasLocalDBFiles() unexpected error: %v", err)
+	}
+	if exists {
+		t.Fatal("hasLocalDBFiles() after backup = true, want false")
+	}
+	for _, p := range []string{backup, backup + "-wal"} {
+		if _, err := os.Stat(p); err != nil {
+			t.Fatalf("backup file %s missing: %v", p, err)
+		}
+	}
+}
+
+func TestLocalDBFilesSizeSumsCompanions(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "giddyup.db")
+	if err := os.WriteFile(path, []byte("db"), 0o600); err != nil {
+		t.Fatalf("write db file: %v", err)
+	}
+	if err := os.WriteFile(path+"-wal", []byte("wal"), 0o600); err != nil {
+		t.Fatalf("write wal file: %v", err)
+	}
+
+	size, err := localDBFilesSize(path)
+	if err != nil {
+		t.Fatalf("localDBFilesSize() unexpected error: %v", err)
+	}
+	if size != 5 {
+		t.Fatalf("localDBFilesSize() = %d, want 5", size)
+	}
+}
+
+func TestOptimizeResultString(t *testing.T) {
+	t.Parallel()
+
+	r := OptimizeResult{BeforeBytes: 3 << 20, AfterBytes: 1536}
+	if got, want := r.String(), "3.0 MiB -> 1.5 KiB"; got != want {
+		t.Fatalf("OptimizeResult.String() = %q, want %q", got, want)
+	}
+	if got, want := formatByteSize(512), "512 B"; got != want {
+		t.Fatalf("formatByteSize(512) = %q, want %q", got, want)
+	}
+}
@@ -239,7 +239,8 @@ CREATE TABLE IF NOT EXISTS accounts (
   balance_value_in_base_units INTEGER NOT NULL,
   created_at TEXT NOT NULL,
   last_fetched_at TEXT NOT NULL,
-  is_active INTEGER NOT NULL DEFAULT 1 CHECK (is_active IN (0,1))
+  is_active INTEGER NOT NULL DEFAULT 1 CHECK (is_active IN (0,1)),
+  skip_auto_sync INTEGER NOT NULL DEFAULT 0 CHECK (skip_auto_sync IN (0,1))
 );
 `
 	if _, err := db.Exec(schema); err != nil {
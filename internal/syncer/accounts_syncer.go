@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/lachiem1/giddyUp/internal/storage"
@@ -18,6 +19,9 @@ type AccountsSyncer struct {
 	accounts  *storage.AccountsRepo
 	syncState *storage.SyncStateRepo
 	workers   int
+
+	mu           sync.Mutex
+	everyAccount bool
 }
 
 func NewAccountsSyncer(
@@ -56,18 +60,44 @@ func (s *AccountsSyncer) LastSuccessAt(ctx context.Context) (time.Time, bool, er
 	return state.LastSuccess.UTC(), true, nil
 }
 
+// SetEveryAccount makes later syncs fetch accounts flagged to skip
+// auto-sync as well. Automatic refreshes leave it off so flagged accounts
+// keep their stored snapshot; manual refreshes turn it on.
+func (s *AccountsSyncer) SetEveryAccount(every bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.everyAccount = every
+}
+
 func (s *AccountsSyncer) Sync(ctx context.Context) error {
+	s.mu.Lock()
+	everyAccount := s.everyAccount
+	s.mu.Unlock()
+
 	return runSyncAttempt(ctx, s.syncState, s.Collection(), func(runCtx context.Context) (time.Time, error) {
 		list, err := s.client.ListAccounts(runCtx)
 		if err != nil {
 			return time.Time{}, err
 		}
 
+		skipped := map[string]bool{}
+		if !everyAccount {
+			skipped, err = s.accounts.SkipAutoSyncIDs(runCtx)
+			if err != nil {
+				return time.Time{}, err
+			}
+		}
+
 		ids := make([]string, 0, len(list.Data))
+		keepIDs := make([]string, 0, len(skipped))
 		for _, res := range list.Data {
 			if res.ID == "" {
 				continue
 			}
+			if skipped[res.ID] {
+				keepIDs = append(keepIDs, res.ID)
+				continue
+			}
 			ids = append(ids, res.ID)
 		}
 
@@ -77,7 +107,7 @@ func (s *AccountsSyncer) Sync(ctx context.Context) error {
 		}
 
 		fetchedAt := time.Now().UTC()
-		if err := s.accounts.ReplaceSnapshot(runCtx, accounts, fetchedAt); err != nil {
+		if err := s.accounts.ReplaceSnapshotKeeping(runCtx, accounts, keepIDs, fetchedAt); err != nil {
 			return time.Time{}, err
 		}
 		return fetchedAt, nil
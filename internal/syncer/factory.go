@@ -8,14 +8,21 @@ import (
 	"github.com/lachiem1/giddyUp/internal/upapi"
 )
 
-func NewAccountsService(db *sql.DB, client *upapi.Client) (*Service, error) {
+// DefaultMinSyncInterval is the shortest time allowed between syncs of the
+// same collection when no interval has been configured.
+const DefaultMinSyncInterval = 30 * time.Second
+
+// NewAccountsService builds an accounts sync service. Cached data younger
+// than minInterval is treated as fresh; a non-positive value uses
+// DefaultMinSyncInterval.
+func NewAccountsService(db *sql.DB, client *upapi.Client, minInterval time.Duration) (*Service, error) {
 	accountsRepo := storage.NewAccountsRepo(db)
 	syncStateRepo := storage.NewSyncStateRepo(db)
 	accountsSyncer := NewAccountsSyncer(client, accountsRepo, syncStateRepo, defaultAccountWorkers)
 
 	engine, err := New(
 		Config{
-			StaleTTL:     30 * time.Second,
+			StaleTTL:     minSyncIntervalOrDefault(minInterval),
 			PollInterval: 2 * time.Minute,
 			Backoff:      []time.Duration{2 * time.Second, 5 * time.Second, 15 * time.Second, 60 * time.Second},
 		},
@@ -25,17 +32,27 @@ func NewAccountsService(db *sql.DB, client *upapi.Client) (*Service, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewService(engine), nil
+	service := NewService(engine)
+	service.accounts = accountsSyncer
+	return service, nil
 }
 
-func NewTransactionsService(db *sql.DB, client *upapi.Client) (*Service, error) {
+// NewTransactionsService builds a transactions sync service. Cached data
+// younger than minInterval is treated as fresh; a non-positive value uses
+// DefaultMinSyncInterval. A positive maxDays stops syncs fetching
+// transactions created more than that many days ago and deactivates any
+// already stored. readAhead bounds how
+// many pages are fetched ahead of the one being stored; a non-positive value
+// uses DefaultTransactionsReadAhead.
+func NewTransactionsService(db *sql.DB, client *upapi.Client, minInterval time.Duration, maxDays int, readAhead int) (*Service, error) {
 	txRepo := storage.NewTransactionsRepo(db)
 	syncStateRepo := storage.NewSyncStateRepo(db)
-	txSyncer := NewTransactionsSyncer(client, txRepo, syncStateRepo, defaultTransactionsMaxPages)
+	txSyncer := NewTransactionsSyncer(client, txRepo, syncStateRepo, defaultTransactionsMaxPages, readAhead)
+	txSyncer.maxDays = maxDays
 
 	engine, err := New(
 		Config{
-			StaleTTL:     30 * time.Second,
+			StaleTTL:     minSyncIntervalOrDefault(minInterval),
 			PollInterval: 2 * time.Minute,
 			Backoff:      []time.Duration{2 * time.Second, 5 * time.Second, 15 * time.Second, 60 * time.Second},
 		},
@@ -45,5 +62,14 @@ func NewTransactionsService(db *sql.DB, client *upapi.Client) (*Service, error)
 	if err != nil {
 		return nil, err
 	}
-	return NewService(engine), nil
+	service := NewService(engine)
+	service.transactions = txSyncer
+	return service, nil
+}
+
+func minSyncIntervalOrDefault(minInterval time.Duration) time.Duration {
+	if minInterval <= 0 {
+		return DefaultMinSyncInterval
+	}
+	return minInterval
 }
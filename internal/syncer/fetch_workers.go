@@ -84,3 +84,68 @@ func fetchAllByID[T any](
 	}
 	return out, nil
 }
+
+// walkPagesAhead follows a cursor-paginated listing, letting up to ahead
+// pages be fetched before handle has consumed them. Cursor links mean pages
+// can't be requested out of order, so the concurrency comes from fetching
+// later pages while handle writes earlier ones. fetch receives "" for the
+// first page and the previous page's cursor after that; next returns "" on
+// the last page. handle runs on the caller's goroutine in page order and
+// returns false to stop the walk. No fetch is still running on return.
+func walkPagesAhead[T any](
+	ctx context.Context,
+	ahead int,
+	fetch func(context.Context, string) (T, error),
+	next func(T) string,
+	handle func(T) (bool, error),
+) error {
+	if ahead <= 0 {
+		ahead = 1
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	type fetched struct {
+		page T
+		err  error
+	}
+	// The fetcher holds one page while blocked on send, so the buffer is one
+	// short of ahead.
+	pages := make(chan fetched, ahead-1)
+	done := make(chan struct{})
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	go func() {
+		defer close(done)
+		defer close(pages)
+		cursor := ""
+		for {
+			page, err := fetch(ctx, cursor)
+			select {
+			case <-ctx.Done():
+				return
+			case pages <- fetched{page: page, err: err}:
+			}
+			if err != nil {
+				return
+			}
+			cursor = next(page)
+			if cursor == "" {
+				return
+			}
+		}
+	}()
+
+	for p := range pages {
+		if p.err != nil {
+			return p.err
+		}
+		more, err := handle(p.page)
+		if err != nil || !more {
+			return err
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,133 @@
+package syncer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/lachiem1/giddyUp/internal/upapi"
+)
+
+// newPagedTransactionsServer serves pages transactions pages of two rows
+// each, linked by page[after] cursors.
+func newPagedTransactionsServer(t *testing.T, pages int, hits *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		page := 0
+		if after := r.URL.Query().Get("page[after]"); after != "" {
+			n, err := strconv.Atoi(after)
+			if err != nil {
+				http.NotFound(w, r)
+				return
+			}
+			page = n
+		}
+		var next any
+		if page < pages-1 {
+			next = fmt.Sprintf("/transactions?page[after]=%d", page+1)
+		}
+		body := map[string]any{
+			"data": []map[string]any{
+				{"type": "transactions", "id": fmt.Sprintf("tx-%d-a", page)},
+				{"type": "transactions", "id": fmt.Sprintf("tx-%d-b", page)},
+			},
+			"links": map[string]any{"prev": nil, "next": next},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(body); err != nil {
+			t.Errorf("encode json response: %v", err)
+		}
+	}))
+}
+
+func pagedTransactionsWalk(client *upapi.Client) (func(context.Context, string) (*upapi.ListResponse, error), func(*upapi.ListResponse) string) {
+	fetch := func(ctx context.Context, next string) (*upapi.ListResponse, error) {
+		if next == "" {
+			return client.ListTransactionsPage(ctx, upapi.TransactionListOptions{})
+		}
+		return client.ListTransactionsPageByURL(ctx, next)
+	}
+	nextLink := func(page *upapi.ListResponse) string {
+		if page.Links.Next == nil {
+			return ""
+		}
+		return *page.Links.Next
+	}
+	return fetch, nextLink
+}
+
+func TestWalkPagesAheadHandlesEveryPageInOrder(t *testing.T) {
+	var hits atomic.Int32
+	server := newPagedTransactionsServer(t, 6, &hits)
+	defer server.Close()
+
+	fetch, nextLink := pagedTransactionsWalk(upapi.NewWithBaseURL("test-token", server.URL))
+	var stored []string
+	err := walkPagesAhead(context.Background(), 3, fetch, nextLink, func(page *upapi.ListResponse) (bool, error) {
+		for _, res := range page.Data {
+			stored = append(stored, res.ID)
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("walkPagesAhead() unexpected error: %v", err)
+	}
+	if got := hits.Load(); got != 6 {
+		t.Fatalf("page requests = %d, want 6", got)
+	}
+	if len(stored) != 12 {
+		t.Fatalf("stored %d transactions, want 12", len(stored))
+	}
+	for i, id := range stored {
+		want := fmt.Sprintf("tx-%d-%c", i/2, "ab"[i%2])
+		if id != want {
+			t.Fatalf("stored[%d] = %q, want %q", i, id, want)
+		}
+	}
+}
+
+func TestWalkPagesAheadStopsWithinReadAhead(t *testing.T) {
+	var hits atomic.Int32
+	server := newPagedTransactionsServer(t, 20, &hits)
+	defer server.Close()
+
+	const ahead = 2
+	fetch, nextLink := pagedTransactionsWalk(upapi.NewWithBaseURL("test-token", server.URL))
+	handled := 0
+	err := walkPagesAhead(context.Background(), ahead, fetch, nextLink, func(page *upapi.ListResponse) (bool, error) {
+		handled++
+		return handled < 2, nil
+	})
+	if err != nil {
+		t.Fatalf("walkPagesAhead() unexpected error: %v", err)
+	}
+	if handled != 2 {
+		t.Fatalf("handled %d pages, want 2", handled)
+	}
+	if got := hits.Load(); got > 2+ahead {
+		t.Fatalf("page requests = %d, want at most %d", got, 2+ahead)
+	}
+}
+
+func TestWalkPagesAheadReturnsFetchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	fetch, nextLink := pagedTransactionsWalk(upapi.NewWithBaseURL("test-token", server.URL))
+	err := walkPagesAhead(context.Background(), 2, fetch, nextLink, func(*upapi.ListResponse) (bool, error) {
+		t.Fatal("handle called for a failed fetch")
+		return false, nil
+	})
+	if err == nil {
+		t.Fatal("walkPagesAhead() error = nil, want the fetch error")
+	}
+}
@@ -1,9 +1,15 @@
 package syncer
 
-import "context"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 type Service struct {
-	engine *Engine
+	engine       *Engine
+	accounts     *AccountsSyncer
+	transactions *TransactionsSyncer
 }
 
 func NewService(engine *Engine) *Service {
@@ -22,6 +28,16 @@ func (s *Service) RefreshAccounts() error {
 	return s.engine.ManualRefresh(CollectionAccounts)
 }
 
+// SetAccountsEveryAccount controls whether later accounts syncs of this
+// service also fetch accounts flagged to skip auto-sync.
+func (s *Service) SetAccountsEveryAccount(every bool) error {
+	if s.accounts == nil {
+		return errors.New("service has no accounts syncer")
+	}
+	s.accounts.SetEveryAccount(every)
+	return nil
+}
+
 func (s *Service) EnterTransactionsView(ctx context.Context) error {
 	return s.engine.EnterView(ctx, CollectionTransactions)
 }
@@ -29,3 +45,32 @@ func (s *Service) EnterTransactionsView(ctx context.Context) error {
 func (s *Service) RefreshTransactions() error {
 	return s.engine.ManualRefresh(CollectionTransactions)
 }
+
+// SetTransactionsSince scopes every later transactions sync of this service
+// to transactions created at or after since. Call it before
+// EnterTransactionsView so the sync on entry is scoped too.
+func (s *Service) SetTransactionsSince(since time.Time) error {
+	if s.transactions == nil {
+		return errors.New("service has no transactions syncer")
+	}
+	s.transactions.SetSince(since)
+	return nil
+}
+
+// RefreshTransactionsSince asks for an immediate sync of transactions created
+// at or after since.
+func (s *Service) RefreshTransactionsSince(since time.Time) error {
+	if err := s.SetTransactionsSince(since); err != nil {
+		return err
+	}
+	return s.RefreshTransactions()
+}
+
+// TransactionsFetched reports how many transactions the running or most
+// recent transactions sync of this service has stored so far.
+func (s *Service) TransactionsFetched() int {
+	if s.transactions == nil {
+		return 0
+	}
+	return s.transactions.Fetched()
+}
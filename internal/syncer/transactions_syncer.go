@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/lachiem1/giddyUp/internal/storage"
@@ -12,11 +14,25 @@ import (
 
 const defaultTransactionsMaxPages = 20
 
+// DefaultTransactionsReadAhead keeps at most two pages in flight beyond the
+// one being stored. An incremental sync that stops at stored transactions
+// wastes those requests, so this stays small to respect Up's rate limits.
+const DefaultTransactionsReadAhead = 2
+
 type TransactionsSyncer struct {
 	client    *upapi.Client
 	txRepo    *storage.TransactionsRepo
 	syncState *storage.SyncStateRepo
 	maxPages  int
+	readAhead int
+	// maxDays caps how far back a sync reaches and what it keeps active; 0 is
+	// unlimited.
+	maxDays int
+
+	mu    sync.Mutex
+	since time.Time
+
+	fetched atomic.Int64
 }
 
 func NewTransactionsSyncer(
@@ -24,15 +40,20 @@ func NewTransactionsSyncer(
 	txRepo *storage.TransactionsRepo,
 	syncState *storage.SyncStateRepo,
 	maxPages int,
+	readAhead int,
 ) *TransactionsSyncer {
 	if maxPages <= 0 {
 		maxPages = defaultTransactionsMaxPages
 	}
+	if readAhead <= 0 {
+		readAhead = DefaultTransactionsReadAhead
+	}
 	return &TransactionsSyncer{
 		client:    client,
 		txRepo:    txRepo,
 		syncState: syncState,
 		maxPages:  maxPages,
+		readAhead: readAhead,
 	}
 }
 
@@ -55,44 +76,94 @@ func (s *TransactionsSyncer) LastSuccessAt(ctx context.Context) (time.Time, bool
 	return state.LastSuccess.UTC(), true, nil
 }
 
+// SetSince limits later syncs to transactions created at or after since,
+// fetched with the API's filter[since]. Every page in that window is read,
+// so edits to recent transactions are picked up too. A zero time restores
+// the default newest-first walk that stops at already stored transactions.
+func (s *TransactionsSyncer) SetSince(since time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.since = since
+}
+
+// Fetched reports how many transactions the running or most recent sync has
+// stored so far. It is safe to call while a sync is in progress.
+func (s *TransactionsSyncer) Fetched() int {
+	return int(s.fetched.Load())
+}
+
 func (s *TransactionsSyncer) Sync(ctx context.Context) error {
 	hasCached, err := s.txRepo.HasAny(ctx)
 	if err != nil {
 		return err
 	}
+	s.mu.Lock()
+	since := s.since
+	s.mu.Unlock()
+	opts := upapi.TransactionListOptions{}
+	if !since.IsZero() {
+		// The window already bounds the fetch; walk all of it.
+		hasCached = false
+	} else {
+		// The cap only bounds the walk, which still stops at stored
+		// transactions, so routine syncs stay as quick as uncapped ones.
+		since = transactionsSyncFloor(s.maxDays, time.Now())
+	}
+	if !since.IsZero() {
+		opts.SinceRFC = since.Format(time.RFC3339)
+	}
 
 	return runSyncAttempt(ctx, s.syncState, s.Collection(), func(runCtx context.Context) (time.Time, error) {
+		s.fetched.Store(0)
 		pageCount := 0
 		knownSeen := 0
-		next := ""
-		fetchedAt := time.Now().UTC()
+		startedAt := time.Now().UTC()
+		fetchedAt := startedAt
+		seen := map[string]bool{}
+		// reconcile runs once every page has been walked, so any stored
+		// transaction in the window that the API didn't return is gone.
+		reconcile := func() (time.Time, error) {
+			if _, err := s.txRepo.DeactivateMissing(runCtx, since, startedAt, seen); err != nil {
+				return time.Time{}, err
+			}
+			return fetchedAt, nil
+		}
 
-		for {
-			var page *upapi.ListResponse
+		// complete records that the walk reached the last page rather than
+		// stopping at stored transactions or the page cap.
+		complete := false
+		fetchPage := func(ctx context.Context, next string) (*upapi.ListResponse, error) {
 			if next == "" {
-				page, err = s.client.ListTransactionsPage(runCtx, upapi.TransactionListOptions{})
-			} else {
-				page, err = s.client.ListTransactionsPageByURL(runCtx, next)
+				return s.client.ListTransactionsPage(ctx, opts)
 			}
-			if err != nil {
-				return time.Time{}, err
+			return s.client.ListTransactionsPageByURL(ctx, next)
+		}
+		nextLink := func(page *upapi.ListResponse) string {
+			if len(page.Data) == 0 || page.Links.Next == nil {
+				return ""
 			}
+			return *page.Links.Next
+		}
+		handlePage := func(page *upapi.ListResponse) (bool, error) {
 			pageCount++
 			if len(page.Data) == 0 {
-				return fetchedAt, nil
+				complete = true
+				return false, nil
 			}
 
 			ids := make([]string, 0, len(page.Data))
 			for _, res := range page.Data {
 				if res.ID != "" {
 					ids = append(ids, res.ID)
+					seen[res.ID] = true
 				}
 			}
 			known := map[string]bool{}
 			if hasCached && len(ids) > 0 {
+				var err error
 				known, err = s.txRepo.KnownIDs(runCtx, ids)
 				if err != nil {
-					return time.Time{}, err
+					return false, err
 				}
 			}
 
@@ -113,7 +184,7 @@ func (s *TransactionsSyncer) Sync(ctx context.Context) error {
 				knownSeen = 0
 				rec, mapErr := mapTransactionRecord(res)
 				if mapErr != nil {
-					return time.Time{}, mapErr
+					return false, mapErr
 				}
 				batch = append(batch, rec)
 			}
@@ -121,27 +192,50 @@ func (s *TransactionsSyncer) Sync(ctx context.Context) error {
 			fetchedAt = time.Now().UTC()
 			if len(batch) > 0 {
 				if err := s.txRepo.UpsertBatch(runCtx, batch, fetchedAt); err != nil {
-					return time.Time{}, err
+					return false, err
 				}
+				s.fetched.Add(int64(len(batch)))
 			}
 
+			// Stopping early or at the page cap leaves older pages unread,
+			// so only a walk to the last page reconciles.
 			if shouldStop {
-				return fetchedAt, nil
+				return false, nil
 			}
-
-			if page.Links.Next == nil || *page.Links.Next == "" {
-				return fetchedAt, nil
+			if nextLink(page) == "" {
+				complete = true
+				return false, nil
 			}
-			next = *page.Links.Next
-
 			// On incremental runs, cap page traversal.
-			if hasCached && pageCount >= s.maxPages {
-				return fetchedAt, nil
+			return !hasCached || pageCount < s.maxPages, nil
+		}
+
+		if err := walkPagesAhead(runCtx, s.readAhead, fetchPage, nextLink, handlePage); err != nil {
+			return time.Time{}, err
+		}
+		// The cap also ages out what earlier, wider syncs stored, so views
+		// only show the window the config asks for.
+		if floor := transactionsSyncFloor(s.maxDays, startedAt); !floor.IsZero() {
+			if _, err := s.txRepo.DeactivateBefore(runCtx, floor); err != nil {
+				return time.Time{}, err
 			}
 		}
+		if !complete {
+			return fetchedAt, nil
+		}
+		return reconcile()
 	})
 }
 
+// transactionsSyncFloor is the earliest creation time a sync capped at
+// maxDays fetches, or zero when maxDays leaves it unlimited.
+func transactionsSyncFloor(maxDays int, now time.Time) time.Time {
+	if maxDays <= 0 {
+		return time.Time{}
+	}
+	return now.AddDate(0, 0, -maxDays)
+}
+
 func mapTransactionRecord(res upapi.Resource) (storage.TransactionRecord, error) {
 	if stringsTrim(res.ID) == "" {
 		return storage.TransactionRecord{}, fmt.Errorf("transaction id is empty")
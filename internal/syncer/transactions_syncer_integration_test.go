@@ -0,0 +1,276 @@
+//go:build integration
+// +build integration
+
+package syncer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/lachiem1/giddyUp/internal/storage"
+	"github.com/lachiem1/giddyUp/internal/upapi"
+)
+
+func TestTransactionsSyncerUpsertsEveryPage(t *testing.T) {
+	server := newTransactionsStubServer(t, 4, 3, 0)
+	defer server.Close()
+
+	db := openMigratedTestDB(t)
+	defer db.Close()
+
+	txSyncer := newTestTransactionsSyncer(db, server.URL(), 2)
+	if err := txSyncer.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+
+	for page := 1; page <= 4; page++ {
+		server.AssertPageHits(t, page, 1)
+		for i := 1; i <= 3; i++ {
+			id := stubTransactionID(page, i)
+			var active int
+			var baseUnits int64
+			err := db.QueryRow(
+				`SELECT is_active, amount_value_in_base_units FROM transactions WHERE id = ?`,
+				id,
+			).Scan(&active, &baseUnits)
+			if err != nil {
+				t.Fatalf("transaction %s not stored: %v", id, err)
+			}
+			if active != 1 || baseUnits != stubTransactionCents(page, i) {
+				t.Fatalf("transaction %s is_active = %d, cents = %d, want 1, %d", id, active, baseUnits, stubTransactionCents(page, i))
+			}
+		}
+	}
+	if got := countTransactions(t, db); got != 12 {
+		t.Fatalf("stored transactions = %d, want 12", got)
+	}
+	if got := txSyncer.Fetched(); got != 12 {
+		t.Fatalf("Fetched() = %d, want 12", got)
+	}
+
+	state, found, err := storage.NewSyncStateRepo(db).Get(context.Background(), CollectionTransactions)
+	if err != nil || !found || state.LastSuccess == nil {
+		t.Fatalf("sync state = %+v, found %v, err %v, want a recorded success", state, found, err)
+	}
+}
+
+func TestTransactionsSyncerStopsAtFailedPage(t *testing.T) {
+	server := newTransactionsStubServer(t, 4, 3, 3)
+	defer server.Close()
+
+	db := openMigratedTestDB(t)
+	defer db.Close()
+
+	txSyncer := newTestTransactionsSyncer(db, server.URL(), 2)
+	if err := txSyncer.Sync(context.Background()); err == nil {
+		t.Fatal("Sync() error = nil, want the failed page's error")
+	}
+
+	// Pages before the failure are stored in order; nothing from the failed
+	// page or after it is written.
+	for page := 1; page <= 4; page++ {
+		for i := 1; i <= 3; i++ {
+			id := stubTransactionID(page, i)
+			var n int
+			if err := db.QueryRow(`SELECT COUNT(*) FROM transactions WHERE id = ?`, id).Scan(&n); err != nil {
+				t.Fatalf("count transaction %s: %v", id, err)
+			}
+			want := 0
+			if page < 3 {
+				want = 1
+			}
+			if n != want {
+				t.Fatalf("transaction %s stored %d times, want %d", id, n, want)
+			}
+		}
+	}
+	server.AssertPageHits(t, 4, 0)
+
+	state, found, err := storage.NewSyncStateRepo(db).Get(context.Background(), CollectionTransactions)
+	if err != nil || !found {
+		t.Fatalf("sync state found %v, err %v, want a recorded attempt", found, err)
+	}
+	if state.LastSuccess != nil || state.LastErrorMsg == "" {
+		t.Fatalf("sync state = %+v, want an error and no success", state)
+	}
+}
+
+func TestTransactionsSyncerDeactivatesRowsBeforeMaxDays(t *testing.T) {
+	server := newTransactionsStubServer(t, 2, 3, 0)
+	defer server.Close()
+
+	db := openMigratedTestDB(t)
+	defer db.Close()
+
+	// Stored by an earlier, uncapped sync and far older than the cap.
+	_, err := db.Exec(
+		`INSERT INTO transactions (
+			id, account_id, status, description, amount_currency_code, amount_value,
+			amount_value_in_base_units, created_at, last_fetched_at
+		) VALUES ('tx-old', 'acc-1', 'SETTLED', 'Old merchant', 'AUD', '-1.00', -100,
+			'2020-01-01T09:00:00+11:00', '2020-01-01T09:00:00+11:00')`,
+	)
+	if err != nil {
+		t.Fatalf("insert old transaction: %v", err)
+	}
+
+	txSyncer := newTestTransactionsSyncer(db, server.URL(), 2)
+	// Reach back just past the stub's February 2026 transactions.
+	txSyncer.maxDays = int(time.Since(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)).Hours()/24) + 1
+	if err := txSyncer.Sync(context.Background()); err != nil {
+		t.Fatalf("Sync() unexpected error: %v", err)
+	}
+
+	var active int
+	if err := db.QueryRow(`SELECT is_active FROM transactions WHERE id = 'tx-old'`).Scan(&active); err != nil {
+		t.Fatalf("query old transaction: %v", err)
+	}
+	if active != 0 {
+		t.Fatalf("old transaction is_active = %d, want 0 once it falls outside sync.max_days", active)
+	}
+	var activeCount int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM transactions WHERE is_active = 1`).Scan(&activeCount); err != nil {
+		t.Fatalf("count active transactions: %v", err)
+	}
+	if activeCount != 6 {
+		t.Fatalf("active transactions = %d, want the 6 synced ones", activeCount)
+	}
+}
+
+func newTestTransactionsSyncer(db *sql.DB, baseURL string, readAhead int) *TransactionsSyncer {
+	client := upapi.NewWithBaseURL("test-token", baseURL)
+	return NewTransactionsSyncer(
+		client,
+		storage.NewTransactionsRepo(db),
+		storage.NewSyncStateRepo(db),
+		defaultTransactionsMaxPages,
+		readAhead,
+	)
+}
+
+func openMigratedTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db := openTestDB(t)
+	if err := storage.Migrate(context.Background(), db); err != nil {
+		db.Close()
+		t.Fatalf("storage.Migrate() unexpected error: %v", err)
+	}
+	return db
+}
+
+func countTransactions(t *testing.T, db *sql.DB) int {
+	t.Helper()
+
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM transactions`).Scan(&n); err != nil {
+		t.Fatalf("count transactions: %v", err)
+	}
+	return n
+}
+
+func stubTransactionID(page, i int) string {
+	return fmt.Sprintf("tx-%d-%d", page, i)
+}
+
+func stubTransactionCents(page, i int) int64 {
+	return -int64(page*100 + i)
+}
+
+// transactionsStubServer serves pages of perPage transactions, newest first,
+// linked by page[after] cursors. failPage, when positive, answers that page
+// with a server error.
+type transactionsStubServer struct {
+	server *httptest.Server
+
+	mu   sync.Mutex
+	hits map[int]int
+}
+
+func newTransactionsStubServer(t *testing.T, pages, perPage, failPage int) *transactionsStubServer {
+	t.Helper()
+
+	s := &transactionsStubServer{hits: make(map[int]int)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/transactions", func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if after := r.URL.Query().Get("page[after]"); after != "" {
+			n, err := strconv.Atoi(after)
+			if err != nil || n < 1 || n >= pages {
+				http.NotFound(w, r)
+				return
+			}
+			page = n + 1
+		}
+		s.mu.Lock()
+		s.hits[page]++
+		s.mu.Unlock()
+		if page == failPage {
+			http.Error(w, `{"errors":[{"status":"500","title":"Internal Server Error"}]}`, http.StatusInternalServerError)
+			return
+		}
+
+		data := make([]map[string]any, 0, perPage)
+		for i := 1; i <= perPage; i++ {
+			cents := stubTransactionCents(page, i)
+			data = append(data, map[string]any{
+				"type": "transactions",
+				"id":   stubTransactionID(page, i),
+				"attributes": map[string]any{
+					"status":          "SETTLED",
+					"description":     fmt.Sprintf("Merchant %d-%d", page, i),
+					"isCategorizable": true,
+					"amount": map[string]any{
+						"currencyCode":     "AUD",
+						"value":            fmt.Sprintf("%.2f", float64(cents)/100),
+						"valueInBaseUnits": cents,
+					},
+					"createdAt": fmt.Sprintf("2026-02-%02dT09:%02d:00+11:00", 28-page, 60-i),
+				},
+				"relationships": map[string]any{
+					"account": map[string]any{
+						"data": map[string]any{"type": "accounts", "id": "acc-1"},
+					},
+				},
+			})
+		}
+		var next any
+		if page < pages {
+			next = fmt.Sprintf("/transactions?page[after]=%d", page)
+		}
+		writeJSON(t, w, map[string]any{
+			"data":  data,
+			"links": map[string]any{"prev": nil, "next": next},
+		})
+	})
+
+	s.server = httptest.NewServer(mux)
+	return s
+}
+
+func (s *transactionsStubServer) Close() {
+	s.server.Close()
+}
+
+func (s *transactionsStubServer) URL() string {
+	return s.server.URL
+}
+
+func (s *transactionsStubServer) AssertPageHits(t *testing.T, page, want int) {
+	t.Helper()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if got := s.hits[page]; got != want {
+		t.Fatalf("page %d hits = %d, want %d", page, got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+// accountColorKeyPrefix keys an account's pinned palette colour by account
+// ID. An empty value means the colour follows the account's rank.
+const accountColorKeyPrefix = "accounts.color."
+
+type saveAccountColorMsg struct {
+	color string
+	err   error
+}
+
+func accountColorKey(accountID string) string {
+	return accountColorKeyPrefix + strings.TrimSpace(accountID)
+}
+
+// loadAccountColors maps account IDs to their pinned colours, skipping
+// cleared values and colours no longer in the palette.
+func loadAccountColors(ctx context.Context, db *sql.DB) (map[string]string, error) {
+	values, err := storage.NewAppConfigRepo(db).ListByPrefix(ctx, accountColorKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string]string, len(values))
+	for key, value := range values {
+		if color, ok := parseAccountColor(value); ok {
+			out[strings.TrimPrefix(key, accountColorKeyPrefix)] = color
+		}
+	}
+	return out, nil
+}
+
+func parseAccountColor(raw string) (string, bool) {
+	raw = strings.ToUpper(strings.TrimSpace(raw))
+	for _, color := range transactionsCategoryPalette() {
+		if raw == string(color) {
+			return raw, true
+		}
+	}
+	return "", false
+}
+
+// nextAccountColor steps through the palette and then back to unpinned.
+func nextAccountColor(current string) string {
+	palette := transactionsCategoryPalette()
+	if current == "" {
+		return string(palette[0])
+	}
+	for i, color := range palette {
+		if current == string(color) {
+			if i == len(palette)-1 {
+				return ""
+			}
+			return string(palette[i+1])
+		}
+	}
+	return ""
+}
+
+// accountDisplayColor is the pinned colour when there is one, otherwise the
+// palette colour for rank.
+func accountDisplayColor(pinned string, rank int) lipgloss.Color {
+	if pinned != "" {
+		return lipgloss.Color(pinned)
+	}
+	return transactionsCategoryColor(rank)
+}
+
+func (m model) saveAccountColorCmd(accountID, color string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveAccountColorMsg{err: errors.New("database is not initialized")}
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			accountColorKey(accountID): color,
+		})
+		return saveAccountColorMsg{color: color, err: err}
+	}
+}
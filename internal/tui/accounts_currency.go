@@ -0,0 +1,78 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// baseCurrencyCode is the currency shown as a bare "$". Up accounts are
+// held in it unless the API reports otherwise.
+const baseCurrencyCode = "AUD"
+
+// currencySymbols prefixes amounts in currencies with a well-known symbol
+// that uses two decimal places. Others are prefixed with their code.
+var currencySymbols = map[string]string{
+	baseCurrencyCode: "$",
+	"USD":            "US$",
+	"NZD":            "NZ$",
+	"CAD":            "CA$",
+	"GBP":            "£",
+	"EUR":            "€",
+}
+
+// normalizeCurrencyCode upper-cases a currency code, treating a blank one
+// as the base currency.
+func normalizeCurrencyCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return baseCurrencyCode
+	}
+	return code
+}
+
+// formatCurrencyCents formats an amount in cents with its currency's symbol,
+// e.g. "$12.50", "£3" or "SGD 40.10".
+func formatCurrencyCents(code string, cents int64) string {
+	code = normalizeCurrencyCode(code)
+	prefix, ok := currencySymbols[code]
+	if !ok {
+		prefix = code + " "
+	}
+	return prefix + formatMoneyDisplay(fmt.Sprintf("%.2f", float64(cents)/100.0))
+}
+
+// formatAccountMoney formats a stored decimal balance in the account's
+// currency. Unparsable values are shown as they are.
+func formatAccountMoney(code, raw string) string {
+	cents, ok := shareParseCents(raw)
+	if !ok {
+		return formatMoneyDisplay(raw)
+	}
+	return formatCurrencyCents(code, cents)
+}
+
+// totalBalancesByCurrency sums balances per currency, since amounts in
+// different currencies can't be added. The base currency comes first, then
+// the others by code.
+func totalBalancesByCurrency(rows []accountPreviewRow) ([]string, map[string]int64) {
+	totals := make(map[string]int64)
+	for _, row := range rows {
+		cents, ok := shareParseCents(row.balanceValue)
+		if !ok {
+			continue
+		}
+		totals[normalizeCurrencyCode(row.balanceCurrency)] += cents
+	}
+	codes := make([]string, 0, len(totals))
+	for code := range totals {
+		codes = append(codes, code)
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		if (codes[i] == baseCurrencyCode) != (codes[j] == baseCurrencyCode) {
+			return codes[i] == baseCurrencyCode
+		}
+		return codes[i] < codes[j]
+	})
+	return codes, totals
+}
@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+const accountsTypeFilterKey = "accounts.type_filter"
+
+// accountsTypeFilters is the order t cycles through; "" shows every account.
+var accountsTypeFilters = []string{"", "SAVER", "TRANSACTIONAL"}
+
+// parseAccountsTypeFilter reads a stored filter, falling back to every
+// account for anything unrecognised.
+func parseAccountsTypeFilter(raw string) string {
+	raw = strings.ToUpper(strings.TrimSpace(raw))
+	for _, filter := range accountsTypeFilters {
+		if raw == filter {
+			return filter
+		}
+	}
+	return ""
+}
+
+func nextAccountsTypeFilter(current string) string {
+	for i, filter := range accountsTypeFilters {
+		if filter == current {
+			return accountsTypeFilters[(i+1)%len(accountsTypeFilters)]
+		}
+	}
+	return accountsTypeFilters[0]
+}
+
+// accountsTypeFilterNoun names the filtered accounts in the status line.
+func accountsTypeFilterNoun(filter string) string {
+	switch filter {
+	case "SAVER":
+		return "savers"
+	case "TRANSACTIONAL":
+		return "spending accounts"
+	default:
+		return ""
+	}
+}
+
+func loadAccountsTypeFilter(ctx context.Context, db *sql.DB) (string, error) {
+	raw, _, err := storage.NewAppConfigRepo(db).Get(ctx, accountsTypeFilterKey)
+	if err != nil {
+		return "", err
+	}
+	return parseAccountsTypeFilter(raw), nil
+}
+
+// accountsPreview is what the accounts screen loads: the rows under the
+// saved type filter and sort, and the filter and sort themselves.
+type accountsPreview struct {
+	rows       []accountPreviewRow
+	fetchedAt  *time.Time
+	typeFilter string
+	sortKey    string
+}
+
+func (v accountsPreview) loadMsg() loadAccountsPreviewMsg {
+	return loadAccountsPreviewMsg{rows: v.rows, lastFetchedAt: v.fetchedAt, typeFilter: v.typeFilter, sortKey: v.sortKey}
+}
+
+// queryFilteredAccountsPreview lists the accounts the accounts screen shows
+// under the saved type filter and sort.
+func queryFilteredAccountsPreview(db *sql.DB) (accountsPreview, error) {
+	filter, err := loadAccountsTypeFilter(context.Background(), db)
+	if err != nil {
+		return accountsPreview{}, err
+	}
+	sortKey, err := loadAccountsSort(context.Background(), db)
+	if err != nil {
+		return accountsPreview{}, err
+	}
+	rows, fetchedAt, err := queryAccountsPreviewOfType(db, filter, sortKey)
+	if err != nil {
+		return accountsPreview{}, err
+	}
+	colors, err := loadAccountColors(context.Background(), db)
+	if err != nil {
+		return accountsPreview{}, err
+	}
+	for i := range rows {
+		rows[i].color = colors[rows[i].id]
+	}
+	return accountsPreview{rows: rows, fetchedAt: fetchedAt, typeFilter: filter, sortKey: sortKey}, nil
+}
+
+// saveAccountsTypeFilterCmd saves the filter before reloading so the reload
+// reads it back.
+func (m model) saveAccountsTypeFilterCmd(filter string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadAccountsPreviewMsg{err: errors.New("database is not initialized")}
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			accountsTypeFilterKey: filter,
+		})
+		if err != nil {
+			return loadAccountsPreviewMsg{err: err}
+		}
+		view, err := queryFilteredAccountsPreview(m.db)
+		if err != nil {
+			return loadAccountsPreviewMsg{err: err}
+		}
+		return view.loadMsg()
+	}
+}
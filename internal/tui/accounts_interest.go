@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// accountInterest is the interest a saver earned over the trailing month,
+// quarter and year, in cents.
+type accountInterest struct {
+	monthCents   int64
+	quarterCents int64
+	yearCents    int64
+}
+
+type loadAccountInterestMsg struct {
+	interest map[string]accountInterest
+	err      error
+}
+
+// accountInterestSQL matches interest payments. Rows synced before
+// transaction_type was stored fall back to Up's "Interest" description.
+const accountInterestSQL = `(LOWER(TRIM(COALESCE(t.transaction_type, ''))) = 'interest'
+	OR (COALESCE(t.transaction_type, '') = '' AND LOWER(TRIM(t.description)) = 'interest'))`
+
+// accountInterestWindows returns the first day of the trailing month,
+// quarter and year ending today, as YYYY-MM-DD.
+func accountInterestWindows(now time.Time) (string, string, string) {
+	now = now.In(time.Local)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return today.AddDate(0, -1, 0).Format("2006-01-02"),
+		today.AddDate(0, -3, 0).Format("2006-01-02"),
+		today.AddDate(-1, 0, 0).Format("2006-01-02")
+}
+
+func (m model) loadAccountInterestCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadAccountInterestMsg{err: errors.New("database is not initialized")}
+		}
+		interest, err := queryAccountInterest(context.Background(), m.db, time.Now())
+		return loadAccountInterestMsg{interest: interest, err: err}
+	}
+}
+
+// queryAccountInterest sums interest credits per saver account over each
+// trailing period.
+func queryAccountInterest(ctx context.Context, db *sql.DB, now time.Time) (map[string]accountInterest, error) {
+	monthStart, quarterStart, yearStart := accountInterestWindows(now)
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT
+			t.account_id,
+			COALESCE(SUM(CASE WHEN date(t.created_at) >= date(?) THEN t.amount_value_in_base_units ELSE 0 END), 0),
+			COALESCE(SUM(CASE WHEN date(t.created_at) >= date(?) THEN t.amount_value_in_base_units ELSE 0 END), 0),
+			COALESCE(SUM(t.amount_value_in_base_units), 0)
+		 FROM transactions t
+		 JOIN accounts a ON a.id = t.account_id
+		 WHERE t.is_active = 1
+		   AND UPPER(a.account_type) = 'SAVER'
+		   AND t.amount_value_in_base_units > 0
+		   AND date(t.created_at) >= date(?)
+		   AND `+accountInterestSQL+`
+		 GROUP BY t.account_id`,
+		monthStart,
+		quarterStart,
+		yearStart,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := map[string]accountInterest{}
+	for rows.Next() {
+		var accountID string
+		var interest accountInterest
+		if err := rows.Scan(&accountID, &interest.monthCents, &interest.quarterCents, &interest.yearCents); err != nil {
+			return nil, err
+		}
+		out[accountID] = interest
+	}
+	return out, rows.Err()
+}
+
+func formatAccountInterest(cents int64) string {
+	return "$" + formatMoneyDisplay(fmt.Sprintf("%.2f", float64(cents)/100.0))
+}
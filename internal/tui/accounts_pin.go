@@ -0,0 +1,192 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+// homePinnedAccountsKey holds the account IDs shown on the home screen's
+// pinned panel, comma separated in pin order.
+const homePinnedAccountsKey = "home.pinned_accounts"
+
+// maxPinnedAccounts is how many accounts fit in the home pinned panel.
+const maxPinnedAccounts = 2
+
+type loadPinnedAccountsMsg struct {
+	ids  []string
+	rows []accountPreviewRow
+	err  error
+}
+
+type togglePinnedAccountMsg struct {
+	pinned bool
+	ids    []string
+	err    error
+}
+
+// parsePinnedAccounts reads the stored pin list, dropping blanks, repeats and
+// anything past maxPinnedAccounts.
+func parsePinnedAccounts(raw string) []string {
+	out := make([]string, 0, maxPinnedAccounts)
+	for _, part := range strings.Split(raw, ",") {
+		id := strings.TrimSpace(part)
+		if id == "" || slices.Contains(out, id) {
+			continue
+		}
+		out = append(out, id)
+		if len(out) == maxPinnedAccounts {
+			break
+		}
+	}
+	return out
+}
+
+// togglePinnedAccount unpins id when it is pinned and pins it otherwise. It
+// refuses to pin past maxPinnedAccounts rather than silently dropping one.
+func togglePinnedAccount(pinned []string, id string) ([]string, bool, error) {
+	id = strings.TrimSpace(id)
+	out := make([]string, 0, len(pinned)+1)
+	removed := false
+	for _, existing := range pinned {
+		if existing == id {
+			removed = true
+			continue
+		}
+		out = append(out, existing)
+	}
+	if removed {
+		return out, false, nil
+	}
+	if len(out) >= maxPinnedAccounts {
+		return pinned, false, fmt.Errorf("only %d accounts can be pinned; unpin one first", maxPinnedAccounts)
+	}
+	return append(out, id), true, nil
+}
+
+func loadPinnedAccountIDs(ctx context.Context, db *sql.DB) ([]string, error) {
+	raw, _, err := storage.NewAppConfigRepo(db).Get(ctx, homePinnedAccountsKey)
+	if err != nil {
+		return nil, err
+	}
+	return parsePinnedAccounts(raw), nil
+}
+
+// loadPinnedAccountsCmd reads the pinned accounts from every active account,
+// not the accounts screen's filtered list, so a type filter can't hide them.
+func (m model) loadPinnedAccountsCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadPinnedAccountsMsg{err: errors.New("database is not initialized")}
+		}
+		ids, err := loadPinnedAccountIDs(context.Background(), m.db)
+		if err != nil || len(ids) == 0 {
+			return loadPinnedAccountsMsg{ids: ids, err: err}
+		}
+		all, _, err := queryAccountsPreview(m.db)
+		if err != nil {
+			return loadPinnedAccountsMsg{ids: ids, err: err}
+		}
+		colors, err := loadAccountColors(context.Background(), m.db)
+		if err != nil {
+			return loadPinnedAccountsMsg{ids: ids, err: err}
+		}
+		rows := make([]accountPreviewRow, 0, len(ids))
+		for _, id := range ids {
+			for _, row := range all {
+				if row.id == id {
+					row.color = colors[row.id]
+					rows = append(rows, row)
+					break
+				}
+			}
+		}
+		return loadPinnedAccountsMsg{ids: ids, rows: rows}
+	}
+}
+
+func (m model) togglePinnedAccountCmd(accountID string) tea.Cmd {
+	current := append([]string{}, m.homePinnedAccounts...)
+	return func() tea.Msg {
+		if m.db == nil {
+			return togglePinnedAccountMsg{err: errors.New("database is not initialized")}
+		}
+		next, pinned, err := togglePinnedAccount(current, accountID)
+		if err != nil {
+			return togglePinnedAccountMsg{err: err}
+		}
+		err = storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			homePinnedAccountsKey: strings.Join(next, ","),
+		})
+		if err != nil {
+			return togglePinnedAccountMsg{err: err}
+		}
+		return togglePinnedAccountMsg{pinned: pinned, ids: next}
+	}
+}
+
+// formatAccountGoalBar shows one account's balance as a share of its goal,
+// e.g. "45% █████░░░░░". It reports false when the account has no goal.
+func formatAccountGoalBar(row accountPreviewRow, width int) (string, bool) {
+	goal, err := strconv.ParseFloat(strings.TrimSpace(row.goalBalance), 64)
+	if err != nil || goal <= 0 {
+		return "", false
+	}
+	balance, err := strconv.ParseFloat(strings.TrimSpace(row.balanceValue), 64)
+	if err != nil {
+		return "", false
+	}
+	pct := math.Max(0, balance/goal*100)
+	width = max(1, width)
+	filled := min(width, int(math.Round(pct/100*float64(width))))
+	return fmt.Sprintf("%.0f%% %s", pct, strings.Repeat("█", filled)+strings.Repeat("░", width-filled)), true
+}
+
+// renderPinnedAccountsPanel is the body of the home screen's pinned panel:
+// each pinned account's name, balance and goal progress.
+func renderPinnedAccountsPanel(rows []accountPreviewRow, width int) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD54A")).Bold(true)
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	width = max(8, width)
+
+	lines := []string{pinIconOrFallback() + " " + titleStyle.Render("pinned accounts"), ""}
+	if len(rows) == 0 {
+		hint := "pin up to 2 accounts from the accounts actions pane"
+		lines = append(lines, mutedStyle.Width(width).Render(hint))
+		return strings.Join(lines, "\n")
+	}
+	for i, row := range rows {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		nameColor := lipgloss.Color("#87CEEB")
+		if row.color != "" {
+			nameColor = lipgloss.Color(row.color)
+		}
+		nameStyle := lipgloss.NewStyle().Foreground(nameColor).Bold(true)
+		lines = append(lines,
+			nameStyle.Render(truncateDisplayWidth(row.displayName, width)),
+			"  "+valueStyle.Render(formatAccountMoney(row.balanceCurrency, row.balanceValue)),
+		)
+		if bar, ok := formatAccountGoalBar(row, max(4, width-16)); ok {
+			goal := "goal " + formatAccountMoney(row.balanceCurrency, row.goalBalance)
+			lines = append(lines,
+				"  "+mutedStyle.Render(truncateDisplayWidth(goal, width-2)),
+				"  "+mutedStyle.Render(bar),
+			)
+		} else {
+			lines = append(lines, "  "+mutedStyle.Render("no goal set"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// accountNameMaxLen caps a local account name; cards truncate long names
+// anyway.
+const accountNameMaxLen = 40
+
+type saveAccountNameMsg struct {
+	cleared bool
+	err     error
+}
+
+// saveAccountNameOverride stores a local display name for an account. An
+// empty name clears it, so the synced name shows again.
+func saveAccountNameOverride(ctx context.Context, db *sql.DB, accountID, name string) error {
+	var value any
+	if name = strings.TrimSpace(name); name != "" {
+		value = name
+	}
+	res, err := db.ExecContext(
+		ctx,
+		"UPDATE accounts SET display_name_override = ? WHERE id = ?",
+		value,
+		accountID,
+	)
+	if err != nil {
+		return err
+	}
+	changed, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if changed == 0 {
+		return errors.New("account not found")
+	}
+	return nil
+}
+
+func (m model) saveAccountNameCmd(accountID, name string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveAccountNameMsg{err: errors.New("database is not initialized")}
+		}
+		if err := saveAccountNameOverride(context.Background(), m.db, accountID, name); err != nil {
+			return saveAccountNameMsg{err: err}
+		}
+		return saveAccountNameMsg{cleared: strings.TrimSpace(name) == ""}
+	}
+}
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// filterAccountRows keeps the accounts whose display name contains query,
+// ignoring case. An empty query keeps every row.
+func filterAccountRows(rows []accountPreviewRow, query string) []accountPreviewRow {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return rows
+	}
+	out := make([]accountPreviewRow, 0, len(rows))
+	for _, row := range rows {
+		if strings.Contains(strings.ToLower(row.displayName), query) {
+			out = append(out, row)
+		}
+	}
+	return out
+}
+
+func (m model) accountsSearchQuery() string {
+	return strings.TrimSpace(m.accountsSearchInput.Value())
+}
+
+// applyAccountsSearch refilters the loaded accounts, keeping the cursor on
+// the same account when it still matches.
+func (m *model) applyAccountsSearch() {
+	selectedID := ""
+	if m.accountsCursor >= 0 && m.accountsCursor < len(m.accountsRows) {
+		selectedID = m.accountsRows[m.accountsCursor].id
+	}
+	m.accountsRows = filterAccountRows(m.accountsAllRows, m.accountsSearchQuery())
+	m.accountsCursor = 0
+	for i, row := range m.accountsRows {
+		if row.id == selectedID {
+			m.accountsCursor = i
+			break
+		}
+	}
+	m.clampAccountsAction()
+	m.ensureAccountsScrollWindow()
+}
+
+// clearAccountsSearch drops the search and shows every loaded account again.
+func (m *model) clearAccountsSearch() {
+	m.accountsSearchActive = false
+	m.accountsSearchInput.Blur()
+	m.accountsSearchInput.SetValue("")
+	m.applyAccountsSearch()
+}
+
+// renderAccountsSearchBox is empty unless a search is being typed or applied.
+func (m model) renderAccountsSearchBox(width int) string {
+	if !m.accountsSearchActive && m.accountsSearchQuery() == "" {
+		return ""
+	}
+	input := m.accountsSearchInput
+	input.Width = max(6, width-lipgloss.Width(input.Prompt)-3)
+	border := lipgloss.Color("#6CBFE6")
+	if m.accountsSearchActive {
+		border = lipgloss.Color("#FFD54A")
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		Padding(0, 1).
+		Width(width).
+		Render(input.View())
+}
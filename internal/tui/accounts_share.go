@@ -0,0 +1,183 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+const shareSummaryDetailKey = "share.summary_detail"
+
+// Share summary detail levels, from least to most revealing. Every level
+// leaves out transactions entirely.
+const (
+	shareDetailTotals   = "totals"
+	shareDetailGoals    = "goals"
+	shareDetailAccounts = "accounts"
+)
+
+const defaultShareDetail = shareDetailGoals
+
+type shareSummaryMsg struct {
+	detail string
+	err    error
+}
+
+func shareDetailOptions() []string {
+	return []string{shareDetailTotals, shareDetailGoals, shareDetailAccounts}
+}
+
+func parseShareDetail(raw string) (string, error) {
+	value := strings.ToLower(strings.TrimSpace(raw))
+	for _, opt := range shareDetailOptions() {
+		if value == opt {
+			return opt, nil
+		}
+	}
+	return "", fmt.Errorf("detail must be one of %s", strings.Join(shareDetailOptions(), ", "))
+}
+
+// copyShareSummaryCmd copies the summary to the clipboard. An empty detail
+// uses the saved level; any other value is validated and saved for next time.
+func (m model) copyShareSummaryCmd(detail string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return shareSummaryMsg{err: errors.New("database is not initialized")}
+		}
+		ctx := context.Background()
+		repo := storage.NewAppConfigRepo(m.db)
+		if detail == "" {
+			saved, found, err := repo.Get(ctx, shareSummaryDetailKey)
+			if err != nil {
+				return shareSummaryMsg{err: err}
+			}
+			detail = defaultShareDetail
+			if found {
+				if parsed, err := parseShareDetail(saved); err == nil {
+					detail = parsed
+				}
+			}
+		} else {
+			parsed, err := parseShareDetail(detail)
+			if err != nil {
+				return shareSummaryMsg{err: err}
+			}
+			detail = parsed
+			if err := repo.UpsertMany(ctx, map[string]string{shareSummaryDetailKey: detail}); err != nil {
+				return shareSummaryMsg{err: err}
+			}
+		}
+
+		rows, _, err := queryAccountsPreview(m.db)
+		if err != nil {
+			return shareSummaryMsg{err: err}
+		}
+		if len(rows) == 0 {
+			return shareSummaryMsg{err: errors.New("no accounts synced yet")}
+		}
+		if err := clipboard.WriteAll(renderShareSummary(rows, detail, time.Now())); err != nil {
+			return shareSummaryMsg{err: err}
+		}
+		return shareSummaryMsg{detail: detail}
+	}
+}
+
+// renderShareSummary is a plain-text status meant for sharing rather than
+// analysis. "totals" gives balances by account type, the overall total and
+// combined goal progress; "goals" adds each goal's progress as a percentage;
+// "accounts" adds each account's balance.
+func renderShareSummary(rows []accountPreviewRow, detail string, asOf time.Time) string {
+	lines := []string{"giddyUp summary — " + asOf.Format("2 Jan 2006"), ""}
+
+	typeTotals := map[string]int64{}
+	typeOrder := make([]string, 0, 4)
+	var totalCents int64
+	for _, row := range rows {
+		cents, ok := shareParseCents(row.balanceValue)
+		if !ok {
+			continue
+		}
+		label := shareAccountTypeLabel(row.accountType)
+		if _, seen := typeTotals[label]; !seen {
+			typeOrder = append(typeOrder, label)
+		}
+		typeTotals[label] += cents
+		totalCents += cents
+	}
+	for _, label := range typeOrder {
+		lines = append(lines, label+": "+shareDollars(typeTotals[label]))
+	}
+	lines = append(lines, "total: "+shareDollars(totalCents))
+
+	var savedCents, goalCents int64
+	goalLines := []string{}
+	for _, row := range rows {
+		if !strings.EqualFold(strings.TrimSpace(row.accountType), "SAVER") {
+			continue
+		}
+		goal, ok := shareParseCents(row.goalBalance)
+		if !ok || goal <= 0 {
+			continue
+		}
+		saved, ok := shareParseCents(row.balanceValue)
+		if !ok {
+			continue
+		}
+		savedCents += saved
+		goalCents += goal
+		goalLines = append(goalLines, "  "+row.displayName+": "+sharePercent(saved, goal))
+	}
+	if goalCents > 0 {
+		lines = append(lines, "", "savings goals: "+sharePercent(savedCents, goalCents)+" reached")
+		if detail == shareDetailGoals || detail == shareDetailAccounts {
+			lines = append(lines, goalLines...)
+		}
+	}
+
+	if detail == shareDetailAccounts {
+		lines = append(lines, "", "accounts:")
+		for _, row := range rows {
+			lines = append(lines, "  "+row.displayName+": $"+formatMoneyDisplay(row.balanceValue))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func shareAccountTypeLabel(accountType string) string {
+	switch strings.ToUpper(strings.TrimSpace(accountType)) {
+	case "TRANSACTIONAL":
+		return "spending"
+	case "SAVER":
+		return "savings"
+	default:
+		return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(accountType), "_", " "))
+	}
+}
+
+func shareParseCents(raw string) (int64, bool) {
+	v := strings.TrimSpace(raw)
+	if v == "" {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, false
+	}
+	return int64(math.Round(n * 100)), true
+}
+
+func shareDollars(cents int64) string {
+	return "$" + formatMoneyDisplay(fmt.Sprintf("%.2f", float64(cents)/100.0))
+}
+
+func sharePercent(part, whole int64) string {
+	return fmt.Sprintf("%.0f%%", float64(part)/float64(whole)*100.0)
+}
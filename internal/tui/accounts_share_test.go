@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderShareSummary(t *testing.T) {
+	t.Parallel()
+
+	rows := []accountPreviewRow{
+		{displayName: "Spending", accountType: "TRANSACTIONAL", balanceValue: "812.40"},
+		{displayName: "Holiday", accountType: "SAVER", balanceValue: "1500.00", goalBalance: "2000"},
+		{displayName: "Rainy day", accountType: "SAVER", balanceValue: "500.00", goalBalance: "2000"},
+	}
+	asOf := time.Date(2026, time.October, 16, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		detail string
+		want   string
+	}{
+		{
+			detail: shareDetailTotals,
+			want: "giddyUp summary — 16 Oct 2026\n\n" +
+				"spending: $812.40\nsavings: $2000\ntotal: $2812.40\n\n" +
+				"savings goals: 50% reached\n",
+		},
+		{
+			detail: shareDetailGoals,
+			want: "giddyUp summary — 16 Oct 2026\n\n" +
+				"spending: $812.40\nsavings: $2000\ntotal: $2812.40\n\n" +
+				"savings goals: 50% reached\n  Holiday: 75%\n  Rainy day: 25%\n",
+		},
+		{
+			detail: shareDetailAccounts,
+			want: "giddyUp summary — 16 Oct 2026\n\n" +
+				"spending: $812.40\nsavings: $2000\ntotal: $2812.40\n\n" +
+				"savings goals: 50% reached\n  Holiday: 75%\n  Rainy day: 25%\n\n" +
+				"accounts:\n  Spending: $812.40\n  Holiday: $1500\n  Rainy day: $500\n",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := renderShareSummary(rows, tt.detail, asOf); got != tt.want {
+			t.Fatalf("renderShareSummary(%q) = %q, want %q", tt.detail, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+const accountsSortKey = "accounts.sort"
+
+// accountsSortManual is the shift+arrow order kept in display_order, and
+// the only mode accounts can be reordered in.
+const accountsSortManual = "manual"
+
+// accountsSortOption is one allowed accounts ORDER BY. Queries only ever
+// take orderBy from this list, never from stored or typed text.
+type accountsSortOption struct {
+	key     string
+	label   string
+	orderBy string
+}
+
+// accountsSortOptions is the order o cycles through.
+func accountsSortOptions() []accountsSortOption {
+	return []accountsSortOption{
+		{key: accountsSortManual, label: "manual order", orderBy: "display_order ASC, display_name ASC, id ASC"},
+		{key: "balance", label: "balance", orderBy: "balance_value_in_base_units DESC, display_name ASC, id ASC"},
+		{key: "name", label: "name", orderBy: "LOWER(COALESCE(NULLIF(TRIM(display_name_override), ''), display_name)) ASC, id ASC"},
+		{key: "type", label: "type", orderBy: "account_type ASC, display_order ASC, display_name ASC, id ASC"},
+	}
+}
+
+// accountsSortFor resolves a sort key, falling back to manual order for
+// anything unrecognised.
+func accountsSortFor(key string) accountsSortOption {
+	key = strings.ToLower(strings.TrimSpace(key))
+	for _, opt := range accountsSortOptions() {
+		if opt.key == key {
+			return opt
+		}
+	}
+	return accountsSortOptions()[0]
+}
+
+func nextAccountsSort(current string) string {
+	options := accountsSortOptions()
+	for i, opt := range options {
+		if opt.key == current {
+			return options[(i+1)%len(options)].key
+		}
+	}
+	return options[0].key
+}
+
+func loadAccountsSort(ctx context.Context, db *sql.DB) (string, error) {
+	raw, _, err := storage.NewAppConfigRepo(db).Get(ctx, accountsSortKey)
+	if err != nil {
+		return "", err
+	}
+	return accountsSortFor(raw).key, nil
+}
+
+// saveAccountsSortCmd saves the sort before reloading so the reload reads
+// it back.
+func (m model) saveAccountsSortCmd(sortKey string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadAccountsPreviewMsg{err: errors.New("database is not initialized")}
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			accountsSortKey: sortKey,
+		})
+		if err != nil {
+			return loadAccountsPreviewMsg{err: err}
+		}
+		view, err := queryFilteredAccountsPreview(m.db)
+		if err != nil {
+			return loadAccountsPreviewMsg{err: err}
+		}
+		return view.loadMsg()
+	}
+}
+
+// runAccountsSortCommand handles /accounts sort [mode]: a named mode is
+// applied, and no mode steps to the next one like o does.
+func (m model) runAccountsSortCommand(args []string) (tea.Model, tea.Cmd) {
+	keys := make([]string, 0, len(accountsSortOptions()))
+	for _, opt := range accountsSortOptions() {
+		keys = append(keys, opt.key)
+	}
+	if len(args) > 1 {
+		return m.withCommandFeedback("usage: /accounts sort [" + strings.Join(keys, "|") + "]")
+	}
+	sortKey := nextAccountsSort(m.accountsSort)
+	if len(args) == 1 {
+		sortKey = strings.ToLower(args[0])
+		if accountsSortFor(sortKey).key != sortKey {
+			return m.withCommandFeedback("sort must be one of " + strings.Join(keys, ", "))
+		}
+	}
+	m.accountsSort = sortKey
+	m.accountsCursor = 0
+	m.accountsOffset = 0
+	m.accountsAction = 0
+	next, cmd := m.withCommandFeedback("accounts sorted by " + accountsSortFor(sortKey).label)
+	return next, tea.Batch(cmd, m.saveAccountsSortCmd(sortKey))
+}
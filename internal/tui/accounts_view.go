@@ -18,6 +18,10 @@ import (
 	"github.com/lachiem1/giddyUp/internal/upapi"
 )
 
+// accountsManualSyncMarker follows the name of an account that automatic
+// refreshes skip.
+const accountsManualSyncMarker = " · manual sync"
+
 func renderAccountsTitle() string {
 	raw := []string{
 		"▄▀█ █▀▀ █▀▀ █▀█ █ █ █▄ █ ▀█▀ █▀",
@@ -49,10 +53,23 @@ func (m model) renderAccountsScreen(layoutWidth int) string {
 		body := m.renderAccountsSkeletonCards(layoutWidth)
 		return strings.Join([]string{title, "", body}, "\n")
 	}
+	if len(m.accountsRows) == 0 && m.accountsSearchQuery() != "" {
+		search := m.renderAccountsSearchBox(max(30, min(layoutWidth-20, 56)))
+		body := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#B9B4D0")).
+			Render("no accounts match  (esc: clear search)")
+		body = lipgloss.JoinVertical(lipgloss.Center, search, "", body)
+		body = lipgloss.PlaceHorizontal(layoutWidth, lipgloss.Center, body)
+		return strings.Join([]string{title, "", body}, "\n")
+	}
 	if len(m.accountsRows) == 0 {
+		empty := "no accounts found"
+		if noun := accountsTypeFilterNoun(m.accountsTypeFilter); noun != "" {
+			empty = "no " + noun + " found  (t: account type)"
+		}
 		body := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#B9B4D0")).
-			Render("no accounts found")
+			Render(empty)
 		body = lipgloss.PlaceHorizontal(layoutWidth, lipgloss.Center, body)
 		return strings.Join([]string{title, "", body}, "\n")
 	}
@@ -83,10 +100,10 @@ func (m model) renderAccountsScreen(layoutWidth int) string {
 		row := m.accountsRows[i]
 
 		display := row.displayName
-		balance := formatMoneyDisplay(row.balanceValue)
+		balance := formatAccountMoney(row.balanceCurrency, row.balanceValue)
 		goalSuffix := ""
 		if strings.TrimSpace(row.goalBalance) != "" {
-			goalSuffix = " / " + formatMoneyDisplay(row.goalBalance)
+			goalSuffix = " / " + formatAccountMoney(row.balanceCurrency, row.goalBalance)
 		}
 
 		rightWhite := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true).Render(balance)
@@ -103,6 +120,11 @@ func (m model) renderAccountsScreen(layoutWidth int) string {
 			Foreground(lipgloss.Color("#FFFFFF")).
 			Bold(true).
 			Render(display)
+		if row.skipAutoSync && lipgloss.Width(display)+len(accountsManualSyncMarker) <= leftWidth {
+			marker := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(accountsManualSyncMarker)
+			name := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true).Render(display)
+			left = lipgloss.NewStyle().Width(leftWidth).Render(name + marker)
+		}
 		content := lipgloss.NewStyle().
 			Width(innerWidth).
 			Render(left + " " + right)
@@ -114,6 +136,9 @@ func (m model) renderAccountsScreen(layoutWidth int) string {
 	}
 
 	body := strings.Join(cards, "\n")
+	if search := m.renderAccountsSearchBox(cardWidth); search != "" {
+		body = search + "\n" + body
+	}
 
 	shownFrom := 0
 	shownTo := 0
@@ -129,14 +154,29 @@ func (m model) renderAccountsScreen(layoutWidth int) string {
 	if end < len(m.accountsRows) {
 		downArrow = lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true).Render("↓")
 	}
+	shown := fmt.Sprintf("showing %d-%d/%d", shownFrom, shownTo, len(m.accountsRows))
+	if noun := accountsTypeFilterNoun(m.accountsTypeFilter); noun != "" {
+		shown += " " + noun
+	}
+	if m.accountsSort != accountsSortManual {
+		shown += " by " + accountsSortFor(m.accountsSort).label
+	}
 	statusLine := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#9CA3AF")).
-		Render(fmt.Sprintf("showing %d-%d/%d   %s/%s to scroll", shownFrom, shownTo, len(m.accountsRows), upArrow, downArrow))
+		Render(fmt.Sprintf("%s   %s/%s to scroll", shown, upArrow, downArrow))
 
 	totalLine := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#87CEEB")).
 		Bold(true).
 		Render("total " + formatTotalBalance(m.accountsRows))
+	if goals, ok := formatGoalsProgress(m.accountsRows); ok {
+		totalLine += "\n" + lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render(goals)
+	}
+	totalLine += "\n" + lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#9CA3AF")).
+		Render(formatGoalsPercentBar(m.accountsRows, goalsProgressBarWidth))
 
 	footer := ""
 	if m.accountsFetched != nil {
@@ -151,7 +191,7 @@ func (m model) renderAccountsScreen(layoutWidth int) string {
 
 	hints := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#9CA3AF")).
-		Render("enter: open actions  tab: switch focus  esc: close/back")
+		Render(accountsHelpText)
 
 	leftParts := []string{body, "", statusLine, "", totalLine, "", hints}
 	if footer != "" {
@@ -191,18 +231,21 @@ func (m model) renderAccountsScreen(layoutWidth int) string {
 	}
 
 	paneBody := ""
-	if m.accountsGoalEditing {
-		input := m.accountsGoalInput
+	if m.accountsGoalEditing || m.accountsRenameEditing {
+		input, hintText, errText := m.accountsGoalInput, accountsGoalHelpText, m.accountsGoalErr
+		if m.accountsRenameEditing {
+			input, hintText, errText = m.accountsRenameInput, accountsRenameHelpText, m.accountsRenameErr
+		}
 		input.Width = max(12, paneWidth-10)
 		inputView := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Render(input.View())
 		hint := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#9CA3AF")).
-			Render("digits + '.' (2dp max)  enter save  esc cancel")
+			Render(hintText)
 		errLine := ""
-		if strings.TrimSpace(m.accountsGoalErr) != "" {
+		if strings.TrimSpace(errText) != "" {
 			errLine = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#F15B5B")).
-				Render(m.accountsGoalErr)
+				Render(errText)
 		}
 		parts := []string{paneHeader, "", inputView, "", hint}
 		if errLine != "" {
@@ -212,7 +255,7 @@ func (m model) renderAccountsScreen(layoutWidth int) string {
 	} else {
 		paneHints := lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#9CA3AF")).
-			Render("↑/↓ pick  enter run  tab cards  esc close")
+			Render(accountsActionsHelpText)
 		infoRows := []string{}
 		if len(m.accountsRows) > 0 && m.accountsCursor >= 0 && m.accountsCursor < len(m.accountsRows) {
 			row := m.accountsRows[m.accountsCursor]
@@ -223,6 +266,19 @@ func (m model) renderAccountsScreen(layoutWidth int) string {
 			infoRows = append(infoRows, label.Render("currency")+": "+value.Render(row.balanceCurrency))
 			infoRows = append(infoRows, label.Render("created")+": "+value.Render(formatAccountCreatedAt(row.createdAt)))
 			infoRows = append(infoRows, label.Render("active")+": "+value.Render(formatBoolYesNo(row.isActive)))
+			infoRows = append(infoRows, label.Render("auto-sync")+": "+value.Render(formatBoolYesNo(!row.skipAutoSync)))
+			colour := value.Render("auto")
+			if row.color != "" {
+				colour = lipgloss.NewStyle().Foreground(lipgloss.Color(row.color)).Render("●") + " " + value.Render(row.color)
+			}
+			infoRows = append(infoRows, label.Render("colour")+": "+colour)
+			if strings.EqualFold(strings.TrimSpace(row.accountType), "SAVER") {
+				interest := m.accountsInterest[row.id]
+				infoRows = append(infoRows, "")
+				infoRows = append(infoRows, label.Render("interest 1m")+": "+value.Render(formatAccountInterest(interest.monthCents)))
+				infoRows = append(infoRows, label.Render("interest 3m")+": "+value.Render(formatAccountInterest(interest.quarterCents)))
+				infoRows = append(infoRows, label.Render("interest 1y")+": "+value.Render(formatAccountInterest(interest.yearCents)))
+			}
 		}
 
 		paneBody = strings.Join([]string{
@@ -253,37 +309,66 @@ func (m model) loadAccountsPreviewCmd() tea.Cmd {
 		if m.db == nil {
 			return loadAccountsPreviewMsg{err: errors.New("database is not initialized")}
 		}
-		rows, fetchedAt, err := queryAccountsPreview(m.db)
+		view, err := queryFilteredAccountsPreview(m.db)
 		if err != nil {
 			return loadAccountsPreviewMsg{err: err}
 		}
-		return loadAccountsPreviewMsg{rows: rows, lastFetchedAt: fetchedAt}
+		return view.loadMsg()
 	}
 }
 
 func (m model) syncAndReloadAccountsPreviewCmd(force bool) tea.Cmd {
+	return m.syncAndReloadAccountsCmd(force, false)
+}
+
+// refreshEveryAccountCmd is the manual refresh: it also fetches accounts
+// flagged to skip auto-sync.
+func (m model) refreshEveryAccountCmd() tea.Cmd {
+	return m.syncAndReloadAccountsCmd(true, true)
+}
+
+// syncAndReloadAccountsCmd only reloads the local rows while offline.
+func (m model) syncAndReloadAccountsCmd(force, everyAccount bool) tea.Cmd {
+	offline := m.offline
 	return func() tea.Msg {
 		if m.db == nil {
 			return syncAccountsPreviewMsg{err: errors.New("database is not initialized")}
 		}
-		syncErr := syncAccountsIntoDB(m.db, force)
-		rows, fetchedAt, queryErr := queryAccountsPreview(m.db)
+		var syncErr error
+		if !offline {
+			syncErr = ignoreSyncSkipped(syncAccountsIntoDB(m.db, force, everyAccount))
+		}
+		view, queryErr := queryFilteredAccountsPreview(m.db)
 		if queryErr != nil {
 			return syncAccountsPreviewMsg{err: queryErr}
 		}
-		if syncErr != nil && len(rows) == 0 {
+		if syncErr != nil && len(view.rows) == 0 {
 			return syncAccountsPreviewMsg{err: syncErr}
 		}
-		return syncAccountsPreviewMsg{rows: rows, lastFetchedAt: fetchedAt}
+		return syncAccountsPreviewMsg{
+			rows:          view.rows,
+			lastFetchedAt: view.fetchedAt,
+			typeFilter:    view.typeFilter,
+			sortKey:       view.sortKey,
+			syncErr:       syncErr,
+		}
 	}
 }
 
 func queryAccountsPreview(db *sql.DB) ([]accountPreviewRow, *time.Time, error) {
+	return queryAccountsPreviewOfType(db, "", accountsSortManual)
+}
+
+// queryAccountsPreviewOfType lists active accounts of one account type, or
+// every active account when accountType is empty, in the order of the
+// allow-listed sort sortKey names.
+func queryAccountsPreviewOfType(db *sql.DB, accountType, sortKey string) ([]accountPreviewRow, *time.Time, error) {
 	rows, err := db.QueryContext(
 		context.Background(),
 		`SELECT
 			id,
-			display_name,
+			COALESCE(NULLIF(TRIM(display_name_override), ''), display_name),
+			NULLIF(TRIM(display_name_override), '') IS NOT NULL,
 			account_type,
 			ownership_type,
 			balance_currency_code,
@@ -291,10 +376,14 @@ func queryAccountsPreview(db *sql.DB) ([]accountPreviewRow, *time.Time, error) {
 			is_active,
 			balance_value,
 			goal_balance,
-			last_fetched_at
+			last_fetched_at,
+			skip_auto_sync
 		 FROM accounts
 		 WHERE is_active = 1
-		 ORDER BY display_order ASC, display_name ASC, id ASC`,
+		   AND (? = '' OR UPPER(account_type) = ?)
+		 ORDER BY `+accountsSortFor(sortKey).orderBy,
+		accountType,
+		accountType,
 	)
 	if err != nil {
 		return nil, nil, err
@@ -308,9 +397,11 @@ func queryAccountsPreview(db *sql.DB) ([]accountPreviewRow, *time.Time, error) {
 		var isActive int
 		var goalBalance sql.NullString
 		var fetchedAtRaw string
+		var skipAutoSync int
 		if err := rows.Scan(
 			&row.id,
 			&row.displayName,
+			&row.nameOverridden,
 			&row.accountType,
 			&row.ownershipType,
 			&row.balanceCurrency,
@@ -319,10 +410,12 @@ func queryAccountsPreview(db *sql.DB) ([]accountPreviewRow, *time.Time, error) {
 			&row.balanceValue,
 			&goalBalance,
 			&fetchedAtRaw,
+			&skipAutoSync,
 		); err != nil {
 			return nil, nil, err
 		}
 		row.isActive = isActive == 1
+		row.skipAutoSync = skipAutoSync == 1
 		if goalBalance.Valid {
 			row.goalBalance = goalBalance.String
 		}
@@ -340,61 +433,120 @@ func queryAccountsPreview(db *sql.DB) ([]accountPreviewRow, *time.Time, error) {
 	return out, newest, nil
 }
 
-func syncAccountsIntoDB(sqlDB *sql.DB, force bool) error {
-	pat, err := auth.LoadPAT()
-	if err != nil {
-		return err
-	}
+// AccountSummary is a cached account as listed by the accounts view.
+type AccountSummary struct {
+	ID              string `json:"id"`
+	DisplayName     string `json:"displayName"`
+	AccountType     string `json:"accountType"`
+	OwnershipType   string `json:"ownershipType"`
+	BalanceCurrency string `json:"balanceCurrency"`
+	CreatedAt       string `json:"createdAt"`
+	IsActive        bool   `json:"isActive"`
+	BalanceValue    string `json:"balanceValue"`
+	GoalBalance     string `json:"goalBalance"`
+	SkipAutoSync    bool   `json:"skipAutoSync"`
+}
 
-	client := upapi.New(pat)
-	service, err := syncer.NewAccountsService(sqlDB, client)
+// ListAccounts returns the locally cached active accounts in display order
+// without contacting the Up API.
+func ListAccounts(db *sql.DB) ([]AccountSummary, error) {
+	rows, _, err := queryAccountsPreview(db)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	out := make([]AccountSummary, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, AccountSummary{
+			ID:              row.id,
+			DisplayName:     row.displayName,
+			AccountType:     row.accountType,
+			OwnershipType:   row.ownershipType,
+			BalanceCurrency: row.balanceCurrency,
+			CreatedAt:       row.createdAt,
+			IsActive:        row.isActive,
+			BalanceValue:    row.balanceValue,
+			GoalBalance:     row.goalBalance,
+			SkipAutoSync:    row.skipAutoSync,
+		})
+	}
+	return out, nil
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
-	defer service.LeaveView()
+// SyncAccounts forces an accounts sync and waits for it to finish. It is the
+// headless counterpart of refreshing the accounts screen, so accounts that
+// skip auto-sync are fetched too. It returns a *SyncSkippedError when the
+// minimum sync interval has not passed.
+func SyncAccounts(db *sql.DB) error {
+	return syncAccountsIntoDB(db, true, true)
+}
 
-	repo := storage.NewSyncStateRepo(sqlDB)
-	accountsRepo := storage.NewAccountsRepo(sqlDB)
+// syncAccountsIntoDB leaves accounts flagged to skip auto-sync untouched
+// unless everyAccount is set.
+func syncAccountsIntoDB(sqlDB *sql.DB, force, everyAccount bool) error {
+	params := fmt.Sprintf("force=%t every=%t", force, everyAccount)
+	return runExclusiveSync(syncer.CollectionAccounts, params, func() error {
+		pat, err := auth.LoadPAT()
+		if err != nil {
+			return err
+		}
+		minInterval, err := loadMinSyncInterval(context.Background(), sqlDB)
+		if err != nil {
+			return err
+		}
 
-	hasCachedRows, err := accountsRepo.HasActiveAccounts(ctx)
-	if err != nil {
-		return err
-	}
+		client := upapi.New(pat)
+		service, err := syncer.NewAccountsService(sqlDB, client, minInterval)
+		if err != nil {
+			return err
+		}
+		if err := service.SetAccountsEveryAccount(everyAccount); err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		defer service.LeaveView()
+
+		repo := storage.NewSyncStateRepo(sqlDB)
+		accountsRepo := storage.NewAccountsRepo(sqlDB)
 
-	var prevAttempt *time.Time
-	var prevSuccess *time.Time
-	if state, found, err := repo.Get(ctx, syncer.CollectionAccounts); err == nil && found {
-		if state.LastAttempt != nil {
-			t := state.LastAttempt.UTC()
-			prevAttempt = &t
+		hasCachedRows, err := accountsRepo.HasActiveAccounts(ctx)
+		if err != nil {
+			return err
 		}
-		if state.LastSuccess != nil {
-			t := state.LastSuccess.UTC()
-			prevSuccess = &t
+
+		var prevAttempt *time.Time
+		var prevSuccess *time.Time
+		var prevErr string
+		if state, found, err := repo.Get(ctx, syncer.CollectionAccounts); err == nil && found {
+			if state.LastAttempt != nil {
+				t := state.LastAttempt.UTC()
+				prevAttempt = &t
+			}
+			if state.LastSuccess != nil {
+				t := state.LastSuccess.UTC()
+				prevSuccess = &t
+			}
+			prevErr = strings.TrimSpace(state.LastErrorMsg)
 		}
-	}
 
-	if err := service.EnterAccountsView(ctx); err != nil {
-		return err
-	}
+		// Never hit the API more often than the configured minimum, even when forced.
+		if skip, err := syncIntervalGuard(syncer.CollectionAccounts, prevAttempt, prevErr, hasCachedRows, minInterval, time.Now()); skip {
+			return err
+		}
+		isStale := prevSuccess == nil || time.Since(prevSuccess.UTC()) > minInterval
+		if !force && hasCachedRows && !isStale {
+			return nil
+		}
 
-	if force {
-		if err := service.RefreshAccounts(); err != nil {
+		// Past the interval guard the cache is always stale, so entering the
+		// view runs the sync.
+		if err := service.EnterAccountsView(ctx); err != nil {
 			return err
 		}
 		waitForRows := !hasCachedRows
 		return waitForAccountsSyncResult(ctx, repo, accountsRepo, prevAttempt, prevSuccess, waitForRows)
-	}
-
-	isStale := prevSuccess == nil || time.Since(prevSuccess.UTC()) > 30*time.Second
-	if hasCachedRows && !isStale {
-		return nil
-	}
-	waitForRows := !hasCachedRows
-	return waitForAccountsSyncResult(ctx, repo, accountsRepo, prevAttempt, prevSuccess, waitForRows)
+	})
 }
 
 func (m model) renderAccountsSkeletonCards(layoutWidth int) string {
@@ -450,7 +602,7 @@ func waitForAccountsSyncResult(
 			}
 
 			if attemptChanged && strings.TrimSpace(state.LastErrorMsg) != "" {
-				return errors.New(state.LastErrorMsg)
+				return syncStateError("accounts", state.LastErrorMsg)
 			}
 			if successChanged {
 				return nil
@@ -521,26 +673,84 @@ func segmentRuns(line string) [][2]int {
 	return segments
 }
 
+// formatTotalBalance totals the balances, one total per currency, e.g.
+// "$1234.50 + US$20".
 func formatTotalBalance(rows []accountPreviewRow) string {
-	if len(rows) == 0 {
-		return "$0"
+	codes, totals := totalBalancesByCurrency(rows)
+	if len(codes) == 0 {
+		return formatCurrencyCents(baseCurrencyCode, 0)
+	}
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, formatCurrencyCents(code, totals[code]))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// formatGoalsProgress sums saver accounts that have a goal and reports how
+// far their combined balance is from the combined goal. A saver ahead of its
+// goal offsets one that is behind. It reports false when no saver has a goal.
+func formatGoalsProgress(rows []accountPreviewRow) (string, bool) {
+	savedCents, goalCents, found := sumGoals(rows)
+	if !found {
+		return "", false
+	}
+	dollars := func(cents int64) string {
+		return "$" + formatMoneyDisplay(fmt.Sprintf("%.2f", float64(cents)/100.0))
+	}
+	line := "saved " + dollars(savedCents) + " of " + dollars(goalCents) + " in goals"
+	switch gap := goalCents - savedCents; {
+	case gap > 0:
+		return line + " — " + dollars(gap) + " to go", true
+	case gap < 0:
+		return line + " — " + dollars(-gap) + " ahead", true
+	default:
+		return line + " — all goals met", true
+	}
+}
+
+// goalsProgressBarWidth is the cell width of the goals bar under the total.
+const goalsProgressBarWidth = 20
+
+// formatGoalsPercentBar shows the combined saver balance as a share of the
+// combined goal, e.g. "goals 45% █████████░░░░░░░░░░░". Savers without a
+// goal stay out of both sides.
+func formatGoalsPercentBar(rows []accountPreviewRow, width int) string {
+	savedCents, goalCents, found := sumGoals(rows)
+	if !found {
+		return "no goals set"
+	}
+	pct := 100.0
+	if goalCents > 0 {
+		pct = math.Max(0, float64(savedCents)/float64(goalCents)*100)
 	}
-	total := 0.0
+	filled := min(width, int(math.Round(pct/100*float64(width))))
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", width-filled)
+	return fmt.Sprintf("goals %.0f%% %s", pct, bar)
+}
+
+// sumGoals totals the balances and goals of savers that have a goal. It
+// reports false when no saver has one.
+func sumGoals(rows []accountPreviewRow) (int64, int64, bool) {
+	var savedCents, goalCents int64
+	found := false
 	for _, row := range rows {
-		v := strings.TrimSpace(row.balanceValue)
-		if v == "" {
+		if !strings.EqualFold(strings.TrimSpace(row.accountType), "SAVER") || strings.TrimSpace(row.goalBalance) == "" {
 			continue
 		}
-		n, err := strconv.ParseFloat(v, 64)
+		goal, err := strconv.ParseFloat(strings.TrimSpace(row.goalBalance), 64)
 		if err != nil {
 			continue
 		}
-		total += n
-	}
-	if math.Abs(total) < 0.0000001 {
-		total = 0
+		balance, err := strconv.ParseFloat(strings.TrimSpace(row.balanceValue), 64)
+		if err != nil {
+			continue
+		}
+		found = true
+		goalCents += int64(math.Round(goal * 100))
+		savedCents += int64(math.Round(balance * 100))
 	}
-	return "$" + formatMoneyDisplay(fmt.Sprintf("%.2f", total))
+	return savedCents, goalCents, found
 }
 
 func formatMoneyDisplay(raw string) string {
@@ -689,6 +899,30 @@ func moveAccountDisplayOrder(ctx context.Context, db *sql.DB, accountID string,
 	return nil
 }
 
+func saveAccountSkipAutoSync(ctx context.Context, db *sql.DB, accountID string, skip bool) error {
+	value := 0
+	if skip {
+		value = 1
+	}
+	res, err := db.ExecContext(
+		ctx,
+		"UPDATE accounts SET skip_auto_sync = ? WHERE id = ?",
+		value,
+		accountID,
+	)
+	if err != nil {
+		return err
+	}
+	changed, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if changed == 0 {
+		return errors.New("account not found")
+	}
+	return nil
+}
+
 func saveAccountGoalBalance(ctx context.Context, db *sql.DB, accountID, goalBalance string) error {
 	res, err := db.ExecContext(
 		ctx,
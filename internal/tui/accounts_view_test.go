@@ -0,0 +1,314 @@
+package tui
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lachiem1/giddyUp/internal/upapi"
+)
+
+func TestFormatGoalsProgress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rows []accountPreviewRow
+		want string
+		ok   bool
+	}{
+		{
+			name: "shortfall across savers",
+			rows: []accountPreviewRow{
+				{accountType: "SAVER", balanceValue: "10000.00", goalBalance: "15000"},
+				{accountType: "SAVER", balanceValue: "2340.00", goalBalance: "5000"},
+				{accountType: "TRANSACTIONAL", balanceValue: "800.00", goalBalance: "100"},
+				{accountType: "SAVER", balanceValue: "50.00"},
+			},
+			want: "saved $12340 of $20000 in goals — $7660 to go",
+			ok:   true,
+		},
+		{
+			name: "surplus",
+			rows: []accountPreviewRow{{accountType: "SAVER", balanceValue: "120.50", goalBalance: "100"}},
+			want: "saved $120.50 of $100 in goals — $20.50 ahead",
+			ok:   true,
+		},
+		{
+			name: "no goals",
+			rows: []accountPreviewRow{{accountType: "SAVER", balanceValue: "120.50"}},
+		},
+	}
+	for _, tt := range tests {
+		got, ok := formatGoalsProgress(tt.rows)
+		if got != tt.want || ok != tt.ok {
+			t.Fatalf("%s: formatGoalsProgress() = (%q, %v), want (%q, %v)", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatGoalsPercentBar(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rows []accountPreviewRow
+		want string
+	}{
+		{
+			name: "half way",
+			rows: []accountPreviewRow{
+				{accountType: "SAVER", balanceValue: "250.00", goalBalance: "1000"},
+				{accountType: "SAVER", balanceValue: "250.00", goalBalance: "0"},
+				{accountType: "SAVER", balanceValue: "9000.00"},
+			},
+			want: "goals 50% █████░░░░░",
+		},
+		{
+			name: "past the goal",
+			rows: []accountPreviewRow{{accountType: "SAVER", balanceValue: "150.00", goalBalance: "100"}},
+			want: "goals 150% ██████████",
+		},
+		{
+			name: "no goals",
+			rows: []accountPreviewRow{{accountType: "SAVER", balanceValue: "120.50"}},
+			want: "no goals set",
+		},
+	}
+	for _, tt := range tests {
+		if got := formatGoalsPercentBar(tt.rows, 10); got != tt.want {
+			t.Fatalf("%s: formatGoalsPercentBar() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCurrentAccountActionItems(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		row    accountPreviewRow
+		pinned []string
+		want   string
+	}{
+		{
+			name: "saver",
+			row:  accountPreviewRow{accountType: "SAVER"},
+			want: "enter goal balance, burndown chart, refresh now, skip auto-sync, rename account, cycle colour, pin to home",
+		},
+		{
+			name: "transactional skipping auto-sync",
+			row:  accountPreviewRow{accountType: "TRANSACTIONAL", skipAutoSync: true},
+			want: "burndown chart, refresh now, resume auto-sync, rename account, cycle colour, pin to home",
+		},
+		{
+			name: "renamed locally",
+			row:  accountPreviewRow{accountType: "TRANSACTIONAL", nameOverridden: true},
+			want: "burndown chart, refresh now, skip auto-sync, rename account, clear name override, cycle colour, pin to home",
+		},
+		{
+			name:   "pinned to home",
+			row:    accountPreviewRow{id: "acc-1", accountType: "TRANSACTIONAL"},
+			pinned: []string{"acc-1"},
+			want:   "burndown chart, refresh now, skip auto-sync, rename account, cycle colour, unpin from home",
+		},
+	}
+
+	for _, tt := range tests {
+		m := model{accountsRows: []accountPreviewRow{tt.row}, homePinnedAccounts: tt.pinned}
+		got := strings.Join(m.currentAccountActionItems(), ", ")
+		if got != tt.want {
+			t.Fatalf("%s: currentAccountActionItems() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestAccountInterestWindows(t *testing.T) {
+	t.Parallel()
+
+	month, quarter, year := accountInterestWindows(time.Date(2026, 5, 15, 18, 30, 0, 0, time.Local))
+	if month != "2026-04-15" || quarter != "2026-02-15" || year != "2025-05-15" {
+		t.Fatalf("accountInterestWindows() = %q, %q, %q, want 2026-04-15, 2026-02-15, 2025-05-15", month, quarter, year)
+	}
+	if got := formatAccountInterest(123456); got != "$"+formatMoneyDisplay("1234.56") {
+		t.Fatalf("formatAccountInterest(123456) = %q", got)
+	}
+}
+
+func TestSyncStateError(t *testing.T) {
+	t.Parallel()
+
+	unauthorized := syncStateError("accounts", "list accounts: GET /accounts failed with status 401: "+upapi.ErrUnauthorized.Error())
+	if !errors.Is(unauthorized, upapi.ErrUnauthorized) {
+		t.Fatalf("syncStateError(401) = %v, want ErrUnauthorized", unauthorized)
+	}
+	limited := syncStateError("transactions", "GET /transactions: "+upapi.ErrRateLimited.Error()+" after 4 attempts")
+	if !errors.Is(limited, upapi.ErrRateLimited) {
+		t.Fatalf("syncStateError(429) = %v, want ErrRateLimited", limited)
+	}
+	if got := syncStateError("accounts", "connection refused"); got.Error() != "connection refused" {
+		t.Fatalf("syncStateError(other) = %v, want the stored message", got)
+	}
+}
+
+func TestAccountsTypeFilterCycle(t *testing.T) {
+	t.Parallel()
+
+	filter := parseAccountsTypeFilter(" saver ")
+	if filter != "SAVER" {
+		t.Fatalf("parseAccountsTypeFilter(%q) = %q, want %q", " saver ", filter, "SAVER")
+	}
+	if got := parseAccountsTypeFilter("HOME_LOAN"); got != "" {
+		t.Fatalf("parseAccountsTypeFilter(%q) = %q, want every account", "HOME_LOAN", got)
+	}
+	for _, want := range []string{"TRANSACTIONAL", "", "SAVER"} {
+		filter = nextAccountsTypeFilter(filter)
+		if filter != want {
+			t.Fatalf("nextAccountsTypeFilter cycled to %q, want %q", filter, want)
+		}
+	}
+	if got := accountsTypeFilterNoun("SAVER"); got != "savers" {
+		t.Fatalf("accountsTypeFilterNoun(SAVER) = %q, want %q", got, "savers")
+	}
+}
+
+func TestFilterAccountRows(t *testing.T) {
+	t.Parallel()
+
+	rows := []accountPreviewRow{
+		{id: "a", displayName: "Spending"},
+		{id: "b", displayName: "🏖️ Holiday Saver"},
+		{id: "c", displayName: "Rainy Day Saver"},
+	}
+	for _, tc := range []struct {
+		query string
+		want  []string
+	}{
+		{query: "", want: []string{"a", "b", "c"}},
+		{query: " saver ", want: []string{"b", "c"}},
+		{query: "HOLI", want: []string{"b"}},
+		{query: "car", want: []string{}},
+	} {
+		got := []string{}
+		for _, row := range filterAccountRows(rows, tc.query) {
+			got = append(got, row.id)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("filterAccountRows(%q) = %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestNextAccountColor(t *testing.T) {
+	t.Parallel()
+
+	palette := transactionsCategoryPalette()
+	if got := nextAccountColor(""); got != string(palette[0]) {
+		t.Fatalf("nextAccountColor(\"\") = %q, want %q", got, palette[0])
+	}
+	if got := nextAccountColor(string(palette[0])); got != string(palette[1]) {
+		t.Fatalf("nextAccountColor(%q) = %q, want %q", palette[0], got, palette[1])
+	}
+	if got := nextAccountColor(string(palette[len(palette)-1])); got != "" {
+		t.Fatalf("nextAccountColor(last) = %q, want unpinned", got)
+	}
+	if got, ok := parseAccountColor(" #e53935 "); !ok || got != "#E53935" {
+		t.Fatalf("parseAccountColor(%q) = (%q, %v), want (%q, true)", " #e53935 ", got, ok, "#E53935")
+	}
+	if got := accountDisplayColor("", 1); got != palette[1] {
+		t.Fatalf("accountDisplayColor(unpinned, 1) = %q, want %q", got, palette[1])
+	}
+}
+
+func TestAccountsSortFor(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		raw  string
+		want string
+	}{
+		{raw: " Balance ", want: "balance"},
+		{raw: "type", want: "type"},
+		{raw: "", want: accountsSortManual},
+		{raw: "balance; DROP TABLE accounts", want: accountsSortManual},
+	} {
+		if got := accountsSortFor(tc.raw).key; got != tc.want {
+			t.Fatalf("accountsSortFor(%q) = %q, want %q", tc.raw, got, tc.want)
+		}
+	}
+	key := accountsSortManual
+	for range accountsSortOptions() {
+		key = nextAccountsSort(key)
+	}
+	if key != accountsSortManual {
+		t.Fatalf("nextAccountsSort cycled to %q, want back to %q", key, accountsSortManual)
+	}
+}
+
+func TestFormatCurrencyCents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code  string
+		cents int64
+		want  string
+	}{
+		{code: "AUD", cents: 123450, want: "$1234.50"},
+		{code: "", cents: 500, want: "$5"},
+		{code: "usd", cents: 2000, want: "US$20"},
+		{code: "GBP", cents: -150, want: "£-1.50"},
+		{code: "SGD", cents: 4010, want: "SGD 40.10"},
+	}
+	for _, tt := range tests {
+		if got := formatCurrencyCents(tt.code, tt.cents); got != tt.want {
+			t.Fatalf("formatCurrencyCents(%q, %d) = %q, want %q", tt.code, tt.cents, got, tt.want)
+		}
+	}
+}
+
+func TestFormatTotalBalanceGroupsCurrencies(t *testing.T) {
+	t.Parallel()
+
+	rows := []accountPreviewRow{
+		{balanceCurrency: "USD", balanceValue: "20.00"},
+		{balanceCurrency: "AUD", balanceValue: "1000.25"},
+		{balanceCurrency: "EUR", balanceValue: "5.00"},
+		{balanceCurrency: "AUD", balanceValue: "234.25"},
+		{balanceCurrency: "USD", balanceValue: "not a number"},
+	}
+	if got, want := formatTotalBalance(rows), "$1234.50 + €5 + US$20"; got != want {
+		t.Fatalf("formatTotalBalance() = %q, want %q", got, want)
+	}
+	if got, want := formatTotalBalance(nil), "$0"; got != want {
+		t.Fatalf("formatTotalBalance(nil) = %q, want %q", got, want)
+	}
+}
+
+func TestTogglePinnedAccount(t *testing.T) {
+	t.Parallel()
+
+	got, pinned, err := togglePinnedAccount(parsePinnedAccounts(" acc-1 ,acc-1,,"), "acc-2")
+	if err != nil || !pinned || !reflect.DeepEqual(got, []string{"acc-1", "acc-2"}) {
+		t.Fatalf("togglePinnedAccount(pin) = %v, %v, %v, want [acc-1 acc-2], true, nil", got, pinned, err)
+	}
+	if _, _, err := togglePinnedAccount(got, "acc-3"); err == nil {
+		t.Fatal("togglePinnedAccount(third pin) error = nil, want the pin limit")
+	}
+	got, pinned, err = togglePinnedAccount(got, "acc-1")
+	if err != nil || pinned || !reflect.DeepEqual(got, []string{"acc-2"}) {
+		t.Fatalf("togglePinnedAccount(unpin) = %v, %v, %v, want [acc-2], false, nil", got, pinned, err)
+	}
+}
+
+func TestFormatAccountGoalBar(t *testing.T) {
+	t.Parallel()
+
+	if got, ok := formatAccountGoalBar(accountPreviewRow{balanceValue: "250.00", goalBalance: "1000.00"}, 4); !ok || got != "25% █░░░" {
+		t.Fatalf("formatAccountGoalBar() = %q, %v, want %q, true", got, ok, "25% █░░░")
+	}
+	if _, ok := formatAccountGoalBar(accountPreviewRow{balanceValue: "250.00"}, 4); ok {
+		t.Fatal("formatAccountGoalBar(no goal) ok = true, want false")
+	}
+}
@@ -2,7 +2,9 @@ package tui
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -10,8 +12,31 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lachiem1/giddyUp/internal/storage"
+	"github.com/lachiem1/giddyUp/internal/syncer"
 )
 
+const (
+	configFocusNextPayDate = iota
+	configFocusFrequency
+	configFocusLargeThreshold
+	configFocusSyncInterval
+	configFocusSyncMaxDays
+	configFocusSyncReadAhead
+	configFocusDefaultRange
+	configFieldCount
+)
+
+const syncMinIntervalKey = "sync.min_interval_seconds"
+
+// syncMaxDaysKey caps how many days back transactions syncs fetch and keep;
+// older stored transactions are hidden after the next sync. Unset or 0 is
+// unlimited.
+const syncMaxDaysKey = "sync.max_days"
+
+// syncReadAheadKey sets how many transaction pages a sync fetches ahead of
+// the one being stored.
+const syncReadAheadKey = "sync.read_ahead"
+
 func renderConfigTitle() string {
 	raw := []string{
 		"█▀▀ █▀█ █▄ █ █▀▀ █ █▀▀",
@@ -32,8 +57,13 @@ func (m model) enterConfigView() (tea.Model, tea.Cmd) {
 	m.selected = 0
 	m.screen = screenConfig
 	m.configErr = ""
-	m.configFocus = 0
+	m.configFocus = configFocusNextPayDate
 	m.configNextPayDigits = ""
+	m.configLargeThreshold = ""
+	m.configSyncIntervalIndex = syncIntervalIndexFromDuration(m.syncMinInterval)
+	m.configSyncMaxDaysIndex = 0
+	m.configSyncReadAheadIndex = syncReadAheadIndexFromPages(syncer.DefaultTransactionsReadAhead)
+	m.configDefaultRangeIndex = transactionsDefaultQuickIdx
 	m.configDateDirty = false
 	m.cmd.Blur()
 	return m, m.loadConfigCmd()
@@ -55,24 +85,45 @@ func (m model) loadConfigCmd() tea.Cmd {
 		if err != nil {
 			return loadConfigMsg{err: err}
 		}
+		largeThreshold, _, err := repo.Get(ctx, txLargeThresholdKey)
+		if err != nil {
+			return loadConfigMsg{err: err}
+		}
+		syncInterval, err := loadMinSyncInterval(ctx, m.db)
+		if err != nil {
+			return loadConfigMsg{err: err}
+		}
+		syncMaxDays, err := loadSyncMaxDays(ctx, m.db)
+		if err != nil {
+			return loadConfigMsg{err: err}
+		}
+		syncReadAhead, err := loadSyncReadAhead(ctx, m.db)
+		if err != nil {
+			return loadConfigMsg{err: err}
+		}
+		defaultRange, err := loadTransactionsDefaultRange(ctx, repo)
+		if err != nil {
+			return loadConfigMsg{err: err}
+		}
 		return loadConfigMsg{
-			nextPayDate: nextDate,
-			frequency:   freq,
+			nextPayDate:    nextDate,
+			frequency:      freq,
+			largeThreshold: largeThreshold,
+			syncInterval:   syncInterval,
+			syncMaxDays:    syncMaxDays,
+			syncReadAhead:  syncReadAhead,
+			defaultRange:   defaultRange,
 		}
 	}
 }
 
-func (m model) saveConfigCmd(nextDate, frequency string) tea.Cmd {
+func (m model) saveConfigCmd(values map[string]string) tea.Cmd {
 	return func() tea.Msg {
 		if m.db == nil {
 			return saveConfigMsg{err: fmt.Errorf("database is not initialized"), silent: false}
 		}
 		repo := storage.NewAppConfigRepo(m.db)
-		err := repo.UpsertMany(context.Background(), map[string]string{
-			"pay_cycle.next_date": nextDate,
-			"pay_cycle.frequency": frequency,
-		})
-		if err != nil {
+		if err := repo.UpsertMany(context.Background(), values); err != nil {
 			return saveConfigMsg{err: err, silent: false}
 		}
 		return saveConfigMsg{silent: false}
@@ -95,6 +146,55 @@ func (m model) saveConfigDateCmd(nextDate string) tea.Cmd {
 	}
 }
 
+// configSettingsValues returns the validated settings that are saved
+// independently of the pay cycle fields.
+func (m model) configSettingsValues() (map[string]string, error) {
+	threshold, err := formatLargeThreshold(m.configLargeThreshold)
+	if err != nil {
+		return nil, err
+	}
+	opts := configSyncIntervalOptions()
+	idx := m.configSyncIntervalIndex
+	if idx < 0 || idx >= len(opts) {
+		idx = syncIntervalIndexFromDuration(syncer.DefaultMinSyncInterval)
+	}
+	maxDaysOpts := configSyncMaxDaysOptions()
+	maxDaysIdx := m.configSyncMaxDaysIndex
+	if maxDaysIdx < 0 || maxDaysIdx >= len(maxDaysOpts) {
+		maxDaysIdx = 0
+	}
+	readAheadOpts := configSyncReadAheadOptions()
+	readAheadIdx := m.configSyncReadAheadIndex
+	if readAheadIdx < 0 || readAheadIdx >= len(readAheadOpts) {
+		readAheadIdx = syncReadAheadIndexFromPages(syncer.DefaultTransactionsReadAhead)
+	}
+	values := map[string]string{
+		txLargeThresholdKey: threshold,
+		syncMinIntervalKey:  strconv.Itoa(int(opts[idx] / time.Second)),
+		syncMaxDaysKey:      strconv.Itoa(maxDaysOpts[maxDaysIdx]),
+		syncReadAheadKey:    strconv.Itoa(readAheadOpts[readAheadIdx]),
+	}
+	// A custom default window is set from the filters screen; leave it be
+	// until a quick range is picked here.
+	if m.configDefaultRangeIndex >= 0 {
+		rangeIdx := m.configDefaultRangeIndex
+		if rangeIdx >= len(transactionsQuickRanges()) {
+			rangeIdx = transactionsDefaultQuickIdx
+		}
+		values[txDefaultRangeKey] = strconv.Itoa(rangeIdx)
+	}
+	return values, nil
+}
+
+// defaultRangeIndexFromValue maps the stored first-entry quick range to its
+// index, falling back to the built-in default.
+func defaultRangeIndexFromValue(raw string) int {
+	if idx, ok := transactionsQuickRangeIndex(raw); ok {
+		return idx
+	}
+	return transactionsDefaultQuickIdx
+}
+
 func configFrequencyOptions() []string {
 	return []string{"weekly", "fortnightly", "monthly", "quarterly"}
 }
@@ -110,6 +210,166 @@ func frequencyIndexFromValue(raw string) int {
 	return 0
 }
 
+func configSyncIntervalOptions() []time.Duration {
+	return []time.Duration{
+		15 * time.Second,
+		30 * time.Second,
+		time.Minute,
+		2 * time.Minute,
+		5 * time.Minute,
+		10 * time.Minute,
+	}
+}
+
+func syncIntervalIndexFromDuration(d time.Duration) int {
+	opts := configSyncIntervalOptions()
+	for i, v := range opts {
+		if v == d {
+			return i
+		}
+	}
+	for i, v := range opts {
+		if v == syncer.DefaultMinSyncInterval {
+			return i
+		}
+	}
+	return 0
+}
+
+func formatSyncInterval(d time.Duration) string {
+	if d >= time.Minute && d%time.Minute == 0 {
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+	return fmt.Sprintf("%ds", int(d/time.Second))
+}
+
+// loadMinSyncInterval reads the configured minimum time between syncs,
+// falling back to the syncer default when unset or invalid. Values below the
+// smallest offered option are raised to it so the API is never polled faster.
+func loadMinSyncInterval(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	raw, found, err := storage.NewAppConfigRepo(db).Get(ctx, syncMinIntervalKey)
+	if err != nil {
+		return 0, err
+	}
+	if !found {
+		return syncer.DefaultMinSyncInterval, nil
+	}
+	seconds, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || seconds <= 0 {
+		return syncer.DefaultMinSyncInterval, nil
+	}
+	interval := time.Duration(seconds) * time.Second
+	if floor := configSyncIntervalOptions()[0]; interval < floor {
+		interval = floor
+	}
+	return interval, nil
+}
+
+// configSyncMaxDaysOptions are the offered sync window caps in days, with
+// 0 for unlimited first.
+func configSyncMaxDaysOptions() []int {
+	return []int{0, 90, 180, 365, 730, 1825}
+}
+
+func syncMaxDaysIndexFromDays(days int) int {
+	for i, v := range configSyncMaxDaysOptions() {
+		if v == days {
+			return i
+		}
+	}
+	return 0
+}
+
+func formatSyncMaxDays(days int) string {
+	switch {
+	case days <= 0:
+		return "all"
+	case days%365 == 0:
+		return fmt.Sprintf("%dy", days/365)
+	default:
+		return fmt.Sprintf("%dd", days)
+	}
+}
+
+// loadSyncMaxDays reads how many days back transactions syncs reach, or 0
+// for unlimited when unset or invalid.
+func loadSyncMaxDays(ctx context.Context, db *sql.DB) (int, error) {
+	raw, _, err := storage.NewAppConfigRepo(db).Get(ctx, syncMaxDaysKey)
+	if err != nil {
+		return 0, err
+	}
+	days, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || days < 0 {
+		return 0, nil
+	}
+	return days, nil
+}
+
+// configSyncReadAheadOptions are the offered read-ahead page counts. The
+// largest stays low so a sync never bursts many requests at Up.
+func configSyncReadAheadOptions() []int {
+	return []int{1, 2, 3, 4}
+}
+
+func syncReadAheadIndexFromPages(pages int) int {
+	for i, v := range configSyncReadAheadOptions() {
+		if v == pages {
+			return i
+		}
+	}
+	for i, v := range configSyncReadAheadOptions() {
+		if v == syncer.DefaultTransactionsReadAhead {
+			return i
+		}
+	}
+	return 0
+}
+
+// loadSyncReadAhead reads how many pages a transactions sync fetches ahead,
+// falling back to the syncer default when unset or invalid and capping it at
+// the largest offered option.
+func loadSyncReadAhead(ctx context.Context, db *sql.DB) (int, error) {
+	raw, _, err := storage.NewAppConfigRepo(db).Get(ctx, syncReadAheadKey)
+	if err != nil {
+		return 0, err
+	}
+	return parseSyncReadAhead(raw), nil
+}
+
+func parseSyncReadAhead(raw string) int {
+	pages, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || pages <= 0 {
+		return syncer.DefaultTransactionsReadAhead
+	}
+	opts := configSyncReadAheadOptions()
+	return min(pages, opts[len(opts)-1])
+}
+
+// parseLargeThresholdCents parses the large transaction alert threshold.
+// An empty value disables the alert and returns 0.
+func parseLargeThresholdCents(raw string) (int64, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("alert threshold is invalid")
+	}
+	return int64(math.Round(n * 100)), nil
+}
+
+func formatLargeThreshold(raw string) (string, error) {
+	cents, err := parseLargeThresholdCents(raw)
+	if err != nil {
+		return "", err
+	}
+	if cents == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("%.2f", float64(cents)/100.0), nil
+}
+
 func dateToDigits(raw string) string {
 	v := strings.TrimSpace(raw)
 	if len(v) != 10 {
@@ -186,15 +446,31 @@ func (m model) renderConfigScreen(layoutWidth int) string {
 
 	nextLabelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
 	freqLabelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
-	if m.configFocus == 0 {
+	thresholdLabelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	syncLabelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	maxDaysLabelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	readAheadLabelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	rangeLabelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF"))
+	switch m.configFocus {
+	case configFocusNextPayDate:
 		nextLabelStyle = nextLabelStyle.Bold(true)
-	} else {
+	case configFocusFrequency:
 		freqLabelStyle = freqLabelStyle.Bold(true)
+	case configFocusLargeThreshold:
+		thresholdLabelStyle = thresholdLabelStyle.Bold(true)
+	case configFocusSyncInterval:
+		syncLabelStyle = syncLabelStyle.Bold(true)
+	case configFocusSyncMaxDays:
+		maxDaysLabelStyle = maxDaysLabelStyle.Bold(true)
+	case configFocusSyncReadAhead:
+		readAheadLabelStyle = readAheadLabelStyle.Bold(true)
+	case configFocusDefaultRange:
+		rangeLabelStyle = rangeLabelStyle.Bold(true)
 	}
 
 	nextFieldBorder := lipgloss.Color("#FFFFFF")
 	dateWarning := ""
-	if m.configFocus == 0 {
+	if m.configFocus == configFocusNextPayDate {
 		nextFieldBorder = lipgloss.Color("#FFD54A")
 	}
 	if len(m.configNextPayDigits) == 8 {
@@ -226,7 +502,7 @@ func (m model) renderConfigScreen(layoutWidth int) string {
 	}
 	frequencyLine := strings.Join(frequencyParts, "  ")
 	freqBorder := lipgloss.Color("#FFFFFF")
-	if m.configFocus == 1 {
+	if m.configFocus == configFocusFrequency {
 		freqBorder = lipgloss.Color("#FFD54A")
 	}
 	frequencyField := lipgloss.NewStyle().
@@ -235,36 +511,136 @@ func (m model) renderConfigScreen(layoutWidth int) string {
 		Padding(0, 1).
 		Render(frequencyLine)
 
-	row1 := nextLabelStyle.Render("next pay date")
-	row2 := nextField
-	row3 := freqLabelStyle.Render("frequency")
-	row4 := frequencyField
-	row5 := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("tab/up/down switch field  left/right frequency")
-	row6 := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("enter save all  esc back")
-
-	contentWidth := max(
-		lipgloss.Width(row1),
-		max(
-			lipgloss.Width(row2),
-			max(
-				lipgloss.Width(row3),
-				max(lipgloss.Width(row4), max(lipgloss.Width(row5), lipgloss.Width(row6))),
-			),
-		),
-	)
-	center := func(s string) string {
-		return lipgloss.PlaceHorizontal(contentWidth, lipgloss.Center, s)
+	thresholdBorder := lipgloss.Color("#FFFFFF")
+	if m.configFocus == configFocusLargeThreshold {
+		thresholdBorder = lipgloss.Color("#FFD54A")
+	}
+	thresholdValue := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true).Render("$ " + m.configLargeThreshold)
+	if strings.TrimSpace(m.configLargeThreshold) == "" {
+		thresholdValue = lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280")).Render("off")
+	}
+	thresholdField := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(thresholdBorder).
+		Padding(0, 1).
+		Render(thresholdValue)
+
+	syncOpts := configSyncIntervalOptions()
+	syncParts := make([]string, 0, len(syncOpts))
+	for i, opt := range syncOpts {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		if i == m.configSyncIntervalIndex {
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+		}
+		syncParts = append(syncParts, style.Render(formatSyncInterval(opt)))
+	}
+	syncBorder := lipgloss.Color("#FFFFFF")
+	if m.configFocus == configFocusSyncInterval {
+		syncBorder = lipgloss.Color("#FFD54A")
+	}
+	syncField := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(syncBorder).
+		Padding(0, 1).
+		Render(strings.Join(syncParts, "  "))
+
+	maxDaysOpts := configSyncMaxDaysOptions()
+	maxDaysParts := make([]string, 0, len(maxDaysOpts))
+	for i, opt := range maxDaysOpts {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		if i == m.configSyncMaxDaysIndex {
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+		}
+		maxDaysParts = append(maxDaysParts, style.Render(formatSyncMaxDays(opt)))
 	}
+	maxDaysBorder := lipgloss.Color("#FFFFFF")
+	if m.configFocus == configFocusSyncMaxDays {
+		maxDaysBorder = lipgloss.Color("#FFD54A")
+	}
+	maxDaysField := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(maxDaysBorder).
+		Padding(0, 1).
+		Render(strings.Join(maxDaysParts, "  "))
 
-	content := []string{
-		center(row1),
-		center(row2),
+	readAheadOpts := configSyncReadAheadOptions()
+	readAheadParts := make([]string, 0, len(readAheadOpts))
+	for i, opt := range readAheadOpts {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		if i == m.configSyncReadAheadIndex {
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+		}
+		readAheadParts = append(readAheadParts, style.Render(strconv.Itoa(opt)))
+	}
+	readAheadBorder := lipgloss.Color("#FFFFFF")
+	if m.configFocus == configFocusSyncReadAhead {
+		readAheadBorder = lipgloss.Color("#FFD54A")
+	}
+	readAheadField := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(readAheadBorder).
+		Padding(0, 1).
+		Render(strings.Join(readAheadParts, "  "))
+
+	ranges := transactionsQuickRanges()
+	rangeParts := make([]string, 0, len(ranges))
+	for i, r := range ranges {
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
+		if i == m.configDefaultRangeIndex {
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+		}
+		rangeParts = append(rangeParts, style.Render(r.label))
+	}
+	if m.configDefaultRangeIndex < 0 {
+		rangeParts = append(rangeParts, lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true).Render(m.configDefaultRangeCustom))
+	}
+	rangeBorder := lipgloss.Color("#FFFFFF")
+	if m.configFocus == configFocusDefaultRange {
+		rangeBorder = lipgloss.Color("#FFD54A")
+	}
+	rangeField := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(rangeBorder).
+		Padding(0, 1).
+		Render(strings.Join(rangeParts, "  "))
+
+	rows := []string{
+		nextLabelStyle.Render("next pay date"),
+		nextField,
+		"",
+		freqLabelStyle.Render("frequency"),
+		frequencyField,
+		"",
+		thresholdLabelStyle.Render("large transaction alert"),
+		thresholdField,
+		"",
+		syncLabelStyle.Render("minimum sync interval"),
+		syncField,
 		"",
-		center(row3),
-		center(row4),
+		maxDaysLabelStyle.Render("keep transactions from the last"),
+		maxDaysField,
 		"",
-		center(row5),
-		center(row6),
+		readAheadLabelStyle.Render("pages fetched ahead during sync"),
+		readAheadField,
+		"",
+		rangeLabelStyle.Render("default transactions range"),
+		rangeField,
+		"",
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(configFieldsHelpText),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(configSaveHelpText),
+	}
+
+	contentWidth := 0
+	for _, row := range rows {
+		contentWidth = max(contentWidth, lipgloss.Width(row))
+	}
+	center := func(s string) string {
+		return lipgloss.PlaceHorizontal(contentWidth, lipgloss.Center, s)
+	}
+
+	content := make([]string, 0, len(rows)+2)
+	for _, row := range rows {
+		content = append(content, center(row))
 	}
 	warningText := strings.TrimSpace(m.configErr)
 	if warningText == "" {
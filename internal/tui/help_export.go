@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Key hints rendered in screen footers. They live here so the exported
+// keybinding reference reads the exact text the screens show.
+const (
+	accountsHelpText            = "enter: open actions  tab: switch focus  / search  t: account type  o: sort  esc: close/back"
+	accountsActionsHelpText     = "↑/↓ pick  enter run  tab cards  esc close"
+	accountsGoalHelpText        = "digits + '.' (2dp max)  enter save  esc cancel"
+	accountsRenameHelpText      = "enter save (empty clears)  esc cancel"
+	payCycleHelpText            = "↑/↓ account  enter details  t today  g set goal  o compare goals  m monthly budget  esc back"
+	payCycleOverlayHelpText     = "↑/↓ account  space compare/uncompare  o single account  g set goal  esc back"
+	payCycleMonthlyHelpText     = "↑/↓ account  enter details  t today  g set budget  m pay cycle  esc back"
+	payCyclePaneHelpText        = "↑/↓ account  ←/→ transaction  t today  tab focus  g set goal  esc close"
+	payCyclePromptHelpText      = "enter save  esc back"
+	payCycleDatePromptHelpText  = "type date or c calendar  enter save  esc back"
+	configFieldsHelpText        = "tab/up/down switch field  left/right change option"
+	configSaveHelpText          = "enter save all  esc back"
+	transactionsFiltersHelpText = "tab switch field  ←/→ change value"
+	transactionsFiltersSaveText = "type date or c calendar  enter save/apply  d set as default  esc back"
+	transactionsCalendarHelp    = "←/→/↑/↓ move  enter select  esc close"
+	transactionsCalendarJump    = "shift+←/→ month  shift+↑/↓ year"
+	transactionsRawHelpText     = "↑/↓ scroll  pgup/pgdn page  q/Esc to close"
+	transactionsHourlyHelpText  = "uses current search and date filters  Esc to close"
+	transactionsBudgetHelpText  = "enter save monthly budget (empty clears)  esc cancel"
+	transactionsJumpHelpText    = "enter jump to page  esc cancel"
+	transactionsNoteHelpText    = "enter save note (empty clears)  esc cancel"
+	transactionsAliasHelpText   = "enter save alias (empty clears)  PATTERN* = name for a prefix  esc cancel"
+	categoryAliasHelpText       = "enter save category name (empty shows the ID)  esc cancel"
+)
+
+const keybindingsFileName = "giddyup-keybindings.md"
+
+type keybindingSection struct {
+	title string
+	hints []string
+}
+
+type exportKeybindingsMsg struct {
+	path string
+	err  error
+}
+
+func keybindingSections() []keybindingSection {
+	return []keybindingSection{
+		{title: "Accounts", hints: []string{accountsHelpText}},
+		{title: "Accounts actions pane", hints: []string{accountsActionsHelpText}},
+		{title: "Accounts goal entry", hints: []string{accountsGoalHelpText}},
+		{title: "Transactions: table [1]", hints: []string{chartFooterHelpText(transactionsViewModeTable), transactionsMoreKeysHelpText(transactionsViewModeTable)}},
+		{title: "Transactions: chart [2]", hints: []string{chartFooterHelpText(transactionsViewModeChart), transactionsMoreKeysHelpText(transactionsViewModeChart)}},
+		{title: "Transactions: time series [3]", hints: []string{chartFooterHelpText(transactionsViewModeTimeSeries), transactionsMoreKeysHelpText(transactionsViewModeTimeSeries)}},
+		{title: "Transactions: weekly [4]", hints: []string{chartFooterHelpText(transactionsViewModeWeekly), transactionsMoreKeysHelpText(transactionsViewModeWeekly)}},
+		{title: "Transactions: heatmap [5]", hints: []string{chartFooterHelpText(transactionsViewModeHeatmap), transactionsMoreKeysHelpText(transactionsViewModeHeatmap)}},
+		{title: "Transactions filters", hints: []string{transactionsFiltersHelpText, transactionsFiltersSaveText}},
+		{title: "Transactions date picker", hints: []string{transactionsCalendarHelp, transactionsCalendarJump}},
+		{title: "Transactions raw json", hints: []string{transactionsRawHelpText}},
+		{title: "Transactions spend by hour", hints: []string{transactionsHourlyHelpText}},
+		{title: "Transactions category budget", hints: []string{transactionsBudgetHelpText}},
+		{title: "Transactions jump to page", hints: []string{transactionsJumpHelpText}},
+		{title: "Transactions note entry", hints: []string{transactionsNoteHelpText}},
+		{title: "Transactions merchant alias", hints: []string{transactionsAliasHelpText}},
+		{title: "Transactions category alias", hints: []string{categoryAliasHelpText}},
+		{title: "Pay cycle burndown", hints: []string{payCycleHelpText}},
+		{title: "Pay cycle goals compared", hints: []string{payCycleOverlayHelpText}},
+		{title: "Monthly budget burndown", hints: []string{payCycleMonthlyHelpText}},
+		{title: "Pay cycle details pane", hints: []string{payCyclePaneHelpText}},
+		{title: "Pay cycle prompts", hints: []string{payCyclePromptHelpText, payCycleDatePromptHelpText}},
+		{title: "Config", hints: []string{configFieldsHelpText, configSaveHelpText}},
+	}
+}
+
+// renderKeybindingsMarkdown builds the reference from the command catalog,
+// the search examples and each screen's footer hints.
+func renderKeybindingsMarkdown() string {
+	var b strings.Builder
+	b.WriteString("# Giddy Up keybindings\n\n## Commands\n\n")
+	for _, cmd := range commandCatalog() {
+		fmt.Fprintf(&b, "- `%s` %s\n", cmd.name, cmd.description)
+	}
+	b.WriteString("\n## Transactions search\n\n")
+	for _, example := range transactionsSearchHelpExamples() {
+		fmt.Fprintf(&b, "- `%s`\n", example)
+	}
+	for _, section := range keybindingSections() {
+		fmt.Fprintf(&b, "\n## %s\n\n", section.title)
+		for _, hint := range section.hints {
+			// Footer hints separate each binding with two spaces.
+			for _, binding := range strings.Split(hint, "  ") {
+				if binding = strings.TrimSpace(binding); binding != "" {
+					fmt.Fprintf(&b, "- %s\n", binding)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+func exportKeybindingsCmd() tea.Msg {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return exportKeybindingsMsg{err: fmt.Errorf("resolve home directory: %w", err)}
+	}
+	path := filepath.Join(home, keybindingsFileName)
+	if err := os.WriteFile(path, []byte(renderKeybindingsMarkdown()), 0o644); err != nil {
+		return exportKeybindingsMsg{err: err}
+	}
+	return exportKeybindingsMsg{path: path}
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lachiem1/giddyUp/internal/storage"
+	"github.com/lachiem1/giddyUp/internal/syncer"
+)
+
+// homeSyncRefreshInterval is how often the home screen rereads sync state,
+// so syncs finished by another screen or the CLI show up without a key press.
+const homeSyncRefreshInterval = 10 * time.Second
+
+// homeSyncStatus is one collection's row in the home connection panel.
+type homeSyncStatus struct {
+	collection  string
+	lastSuccess *time.Time
+	// lastError is set only when the latest attempt failed after the last
+	// success, so a recovered error is not shown.
+	lastError string
+}
+
+type loadHomeSyncStateMsg struct {
+	states []homeSyncStatus
+	err    error
+}
+
+type homeSyncTickMsg struct{}
+
+func homeSyncTickCmd() tea.Cmd {
+	return tea.Tick(homeSyncRefreshInterval, func(time.Time) tea.Msg {
+		return homeSyncTickMsg{}
+	})
+}
+
+func (m model) loadHomeSyncStateCmd() tea.Cmd {
+	db := m.db
+	return func() tea.Msg {
+		if db == nil {
+			return loadHomeSyncStateMsg{}
+		}
+		repo := storage.NewSyncStateRepo(db)
+		states := make([]homeSyncStatus, 0, 2)
+		for _, collection := range []string{syncer.CollectionAccounts, syncer.CollectionTransactions} {
+			state, found, err := repo.Get(context.Background(), collection)
+			if err != nil {
+				return loadHomeSyncStateMsg{err: err}
+			}
+			status := homeSyncStatus{collection: collection}
+			if found {
+				status.lastSuccess = state.LastSuccess
+				if strings.TrimSpace(state.LastErrorMsg) != "" && state.LastAttempt != nil &&
+					(state.LastSuccess == nil || state.LastAttempt.After(*state.LastSuccess)) {
+					status.lastError = strings.TrimSpace(state.LastErrorMsg)
+				}
+			}
+			states = append(states, status)
+		}
+		return loadHomeSyncStateMsg{states: states}
+	}
+}
+
+// formatHomeSyncAge describes when a collection last synced, relative to now
+// for the last day and as a date after that.
+func formatHomeSyncAge(lastSuccess *time.Time, now time.Time) string {
+	if lastSuccess == nil {
+		return "never"
+	}
+	age := now.Sub(*lastSuccess)
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age/time.Minute))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age/time.Hour))
+	default:
+		return lastSuccess.In(time.Local).Format("02 Jan 15:04")
+	}
+}
+
+// renderHomeSyncPanel is the body of the home screen's connection details
+// panel: when each collection last synced, and the latest error if any.
+func renderHomeSyncPanel(states []homeSyncStatus, loadErr string, width int, now time.Time) string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD54A")).Bold(true)
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+	errorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F15B5B"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	width = max(8, width)
+
+	lines := []string{pinIconOrFallback() + " " + titleStyle.Render("connection details"), ""}
+	if loadErr != "" {
+		lines = append(lines, errorStyle.Render(truncateDisplayWidth("sync state: "+loadErr, width)))
+		return strings.Join(lines, "\n")
+	}
+	if len(states) == 0 {
+		lines = append(lines, mutedStyle.Render("loading..."))
+		return strings.Join(lines, "\n")
+	}
+
+	var errs []string
+	for _, state := range states {
+		lines = append(lines,
+			labelStyle.Render(state.collection),
+			"  "+valueStyle.Render(truncateDisplayWidth("synced "+formatHomeSyncAge(state.lastSuccess, now), width-2)),
+		)
+		if state.lastError != "" {
+			errs = append(errs, state.collection+": "+state.lastError)
+		}
+	}
+	lines = append(lines, "", labelStyle.Render("last error"))
+	if len(errs) == 0 {
+		lines = append(lines, "  "+mutedStyle.Render("none"))
+	}
+	for _, e := range errs {
+		lines = append(lines, "  "+errorStyle.Render(truncateDisplayWidth(e, width-2)))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -3,10 +3,13 @@ package tui
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -14,6 +17,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lachiem1/giddyUp/internal/auth"
 	"github.com/lachiem1/giddyUp/internal/storage"
+	"github.com/lachiem1/giddyUp/internal/syncer"
 	"github.com/lachiem1/giddyUp/internal/upapi"
 )
 
@@ -44,6 +48,11 @@ type wipeDBMsg struct {
 	err  error
 }
 
+type optimizeDBMsg struct {
+	result storage.OptimizeResult
+	err    error
+}
+
 type accountPreviewRow struct {
 	id              string
 	displayName     string
@@ -54,18 +63,29 @@ type accountPreviewRow struct {
 	isActive        bool
 	balanceValue    string
 	goalBalance     string
+	skipAutoSync    bool
+	// nameOverridden marks a displayName renamed locally.
+	nameOverridden bool
+	// color is a pinned palette colour, or empty to follow rank.
+	color string
 }
 
 type loadAccountsPreviewMsg struct {
 	rows          []accountPreviewRow
 	lastFetchedAt *time.Time
+	typeFilter    string
+	sortKey       string
 	err           error
 }
 
 type syncAccountsPreviewMsg struct {
 	rows          []accountPreviewRow
 	lastFetchedAt *time.Time
-	err           error
+	typeFilter    string
+	sortKey       string
+	// syncErr is a failed sync that still left cached rows to show.
+	syncErr error
+	err     error
 }
 
 type moveAccountMsg struct {
@@ -76,6 +96,11 @@ type saveAccountGoalMsg struct {
 	err error
 }
 
+type saveAccountSkipAutoSyncMsg struct {
+	skip bool
+	err  error
+}
+
 type accountsClockTickMsg struct {
 	sessionID int
 }
@@ -85,9 +110,14 @@ type accountsAutoRefreshTickMsg struct {
 }
 
 type loadConfigMsg struct {
-	nextPayDate string
-	frequency   string
-	err         error
+	nextPayDate    string
+	frequency      string
+	largeThreshold string
+	syncInterval   time.Duration
+	syncMaxDays    int
+	syncReadAhead  int
+	defaultRange   transactionsDefaultRange
+	err            error
 }
 
 type saveConfigMsg struct {
@@ -101,6 +131,7 @@ type payCycleAccountRow struct {
 	accountType  string
 	balanceCents int64
 	goalBalance  string
+	color        string
 }
 
 type payCycleTransactionRow struct {
@@ -128,10 +159,11 @@ type payCycleBurndownPoint struct {
 }
 
 type loadPayCycleStateMsg struct {
-	accounts    []payCycleAccountRow
-	nextPayDate string
-	frequency   string
-	err         error
+	accounts      []payCycleAccountRow
+	nextPayDate   string
+	frequency     string
+	monthlyBudget string
+	err           error
 }
 
 type loadPayCycleSeriesMsg struct {
@@ -160,18 +192,30 @@ type transactionPreviewRow struct {
 	rawText     string
 	description string
 	amountValue string
+	amountCents int64
 	status      string
 	message     string
 	categoryID  string
 	cardMethod  string
 	noteText    string
+	localNote   string
 	accountName string
+	tags        string
+
+	foreignAmount   string
+	foreignCurrency string
+	// hasAttachment marks a receipt attached in the Up app.
+	hasAttachment bool
 }
 
 type transactionsCategorySpend struct {
 	category       string
 	spendCents     int64
 	percentOfSpend float64
+	previousCents  int64
+	deltaCents     int64
+	// label, when set, is shown in place of category; lookups keep category.
+	label string
 }
 
 type transactionsTimeSeriesPoint struct {
@@ -188,17 +232,40 @@ type transactionsTimeSeriesPoint struct {
 	categoryID  string
 	cardMethod  string
 	noteText    string
+	localNote   string
 	accountName string
+	// members holds the underlying transactions when the point is a day or
+	// month bucket rather than a single transaction.
+	members []transactionsTimeSeriesPoint
+	// count is how many transactions a point summed in SQL, for monthly
+	// round-ups.
+	count int
+
+	hasAttachment bool
 }
 
 type loadTransactionsPreviewMsg struct {
-	rows          []transactionPreviewRow
-	categorySpend []transactionsCategorySpend
-	timeSeries    []transactionsTimeSeriesPoint
-	lastFetchedAt *time.Time
-	totalCount    int
-	page          int
-	err           error
+	rows           []transactionPreviewRow
+	categorySpend  []transactionsCategorySpend
+	timeSeries     []transactionsTimeSeriesPoint
+	lastFetchedAt  *time.Time
+	totalCount     int
+	page           int
+	largeThreshold int64
+	largeCount     int
+	cashbackCents  int64
+	net            transactionsNetSummary
+	hasComparison  bool
+	pageKey        transactionsPageKey
+	ignored        []string
+	hidden         []string
+	aliases        []merchantAlias
+	categoryNames  map[string]string
+	dailyCounts    []int64
+	weeklySpend    []transactionsWeeklySpend
+	heatmap        spendHeatmap
+	aggregates     transactionsAggregates
+	err            error
 }
 
 type categoryTransactionRow struct {
@@ -213,7 +280,10 @@ type categoryTransactionRow struct {
 	categoryID  string
 	cardMethod  string
 	noteText    string
+	localNote   string
 	accountName string
+
+	hasAttachment bool
 }
 
 type loadCategoryTransactionsMsg struct {
@@ -229,9 +299,19 @@ type loadTransactionsFiltersMsg struct {
 	mode            int
 	quickIdx        int
 	includeInternal bool
+	granularity     int
+	viewMode        int
+	chartGrouping   int
+	payCycle        payCycleSettings
+	searchHistory   []string
 	err             error
 }
 
+type loadTransactionsPageSizeMsg struct {
+	pageSize int
+	err      error
+}
+
 type saveTransactionsFiltersMsg struct {
 	err error
 }
@@ -262,10 +342,6 @@ type clearCommandTextMsg struct {
 	id int
 }
 
-type clearButtonFlashMsg struct {
-	id int
-}
-
 type commandSpec struct {
 	name        string
 	description string
@@ -300,6 +376,8 @@ const (
 	transactionsFocusToDate
 	transactionsFocusQuickRange
 	transactionsFocusIncludeInternal
+	transactionsFocusPageSize
+	transactionsFocusCount
 )
 
 const (
@@ -311,6 +389,13 @@ const (
 	transactionsViewModeTable = iota
 	transactionsViewModeChart
 	transactionsViewModeTimeSeries
+	transactionsViewModeWeekly
+	transactionsViewModeHeatmap
+)
+
+const (
+	transactionsChartSortSpend = iota
+	transactionsChartSortChange
 )
 
 const (
@@ -323,6 +408,7 @@ const (
 	payCyclePromptNextDate
 	payCyclePromptFrequency
 	payCyclePromptGoal
+	payCyclePromptMonthlyBudget
 )
 
 const (
@@ -343,13 +429,19 @@ type model struct {
 
 	viewItems []string
 	selected  int
-	clicked   int
-	clickedID int
 	cmd       textinput.Model
 	pat       textinput.Model
 
+	homeSyncStates []homeSyncStatus
+	homeSyncErr    string
+	// homePinnedAccounts are the pinned account IDs; homePinnedRows holds
+	// those still active, in pin order.
+	homePinnedAccounts []string
+	homePinnedRows     []accountPreviewRow
+
 	status                  connectionState
 	statusDetail            string
+	offline                 bool
 	commandText             string
 	commandTextID           int
 	commandSuggestions      []commandSpec
@@ -357,11 +449,18 @@ type model struct {
 	commandSuggestionOffset int
 
 	showHelpOverlay                  bool
+	helpOverlayViewKeys              bool
 	authDialog                       authDialogMode
 	screen                           screenMode
 	connectHint                      string
 	accountsRows                     []accountPreviewRow
+	accountsTypeFilter               string
+	accountsSort                     string
+	accountsAllRows                  []accountPreviewRow
+	accountsSearchInput              textinput.Model
+	accountsSearchActive             bool
 	accountsFetched                  *time.Time
+	accountsInterest                 map[string]accountInterest
 	accountsErr                      string
 	accountsLoading                  bool
 	accountsCursor                   int
@@ -373,16 +472,30 @@ type model struct {
 	accountsGoalEditing              bool
 	accountsGoalErr                  string
 	accountsGoalInput                textinput.Model
+	accountsRenameEditing            bool
+	accountsRenameErr                string
+	accountsRenameInput              textinput.Model
 	configNextPayDigits              string
 	configFrequencyIndex             int
 	configLastSavedDate              string
 	configDateDirty                  bool
 	configFocus                      int
+	configLargeThreshold             string
+	configSyncIntervalIndex          int
+	configSyncMaxDaysIndex           int
+	configSyncReadAheadIndex         int
+	configDefaultRangeIndex          int
+	configDefaultRangeCustom         string
+	syncMinInterval                  time.Duration
 	configErr                        string
 	transactionsRows                 []transactionPreviewRow
 	transactionsCategorySpend        []transactionsCategorySpend
 	transactionsTimeSeries           []transactionsTimeSeriesPoint
+	transactionsTimeSeriesRaw        []transactionsTimeSeriesPoint
+	transactionsTimeSeriesGrouping   int
 	transactionsTimeSeriesCategory   string
+	transactionsTimeSeriesRoundUps   bool
+	transactionsRoundUpsMonthly      bool
 	transactionsTimeSeriesZoomStart  int
 	transactionsTimeSeriesZoomWindow int
 	transactionsTimeSeriesSelection  int
@@ -391,15 +504,23 @@ type model struct {
 	transactionsErr                  string
 	transactionsFetched              *time.Time
 	transactionsSyncing              bool
+	transactionsSyncFetched          int
 	transactionsSession              int
 	transactionsLastSync             *time.Time
 	transactionsPage                 int
 	transactionsPageSize             int
+	transactionsPageKey              transactionsPageKey
 	transactionsTotal                int
+	transactionsLargeThreshold       int64
+	transactionsLargeCount           int
+	transactionsCashbackCents        int64
+	transactionsNet                  transactionsNetSummary
 	transactionsFromDate             string
 	transactionsToDate               string
 	transactionsQuickIdx             int
+	transactionsPayCycle             payCycleSettings
 	transactionsSortIdx              int
+	transactionsSortNewestFirst      bool
 	transactionsViewMode             int
 	transactionsFocus                int
 	transactionsDateErr              string
@@ -410,7 +531,59 @@ type model struct {
 	transactionsSearchApplied        string
 	transactionsSearchErr            string
 	transactionsSearchActive         bool
+	transactionsSearchHistory        []string
+	transactionsSearchRecall         int
+	transactionsSavedFilters         []string
+	transactionsPendingFilter        *savedTransactionsFilter
+	transactionsTagActive            bool
+	transactionsTagInput             textinput.Model
+	transactionsTagErr               string
+	transactionsNoteActive           bool
+	transactionsNoteInput            textinput.Model
+	transactionsNoteTxID             string
+	transactionsAliasActive          bool
+	transactionsAliasInput           textinput.Model
+	transactionsAliasMerchant        string
+	transactionsMerchantAliases      []merchantAlias
+	transactionsCategoryAliases      map[string]string
+	transactionsCategoryAliasActive  bool
+	transactionsCategoryAliasInput   textinput.Model
+	transactionsTagging              bool
+	transactionsTagName              string
+	transactionsTagIDs               []string
+	transactionsTagClient            *upapi.Client
+	transactionsTagDone              int
+	transactionsTagFailed            int
+	transactionsTagFirstErr          string
+	transactionsTagStatus            string
+	transactionsRawOpen              bool
+	transactionsRawTxID              string
+	transactionsRawLines             []string
+	transactionsRawOffset            int
+	transactionsRawErr               string
+	transactionsHourlyOpen           bool
+	transactionsHourlyLoading        bool
+	transactionsHourly               hourlySpend
+	transactionsHourlyErr            string
+	transactionsIgnoredMerchants     []string
+	transactionsHiddenCategories     []string
+	transactionsDailyCounts          []int64
+	transactionsAmountSign           int
+	transactionsShowBalance          bool
+	transactionsBalances             map[string]int64
+	transactionsWeeklySpend          []transactionsWeeklySpend
+	transactionsWeeklyScroll         int
+	transactionsHeatmap              spendHeatmap
+	transactionsTrendTxID            string
+	transactionsTrend                categoryTrend
+	transactionsTrendErr             string
 	transactionsChartCursor          int
+	transactionsChartSort            int
+	transactionsChartByMerchant      bool
+	transactionsChartByParent        bool
+	transactionsChartParentDrill     string
+	transactionsDetailsToggled       bool
+	transactionsChartCompare         bool
 	transactionsChartOffset          int
 	transactionsChartPaneOpen        bool
 	transactionsChartPaneRows        []categoryTransactionRow
@@ -421,6 +594,15 @@ type model struct {
 	transactionsChartPaneFocus       int
 	transactionsChartPaneMode        int
 	transactionsChartPaneDetailTxID  string
+	transactionsCategoryBudgets      map[string]int64
+	transactionsChartBudgetPct       bool
+	transactionsChartVsPrevious      bool
+	transactionsBudgetActive         bool
+	transactionsBudgetErr            string
+	transactionsBudgetInput          textinput.Model
+	transactionsJumpActive           bool
+	transactionsJumpErr              string
+	transactionsJumpInput            textinput.Model
 	transactionsCalendarOpen         bool
 	transactionsCalendarMonth        time.Time
 	transactionsCalendarCursor       time.Time
@@ -439,11 +621,20 @@ type model struct {
 	payCycleErr                      string
 	payCyclePromptMode               int
 	payCyclePromptErr                string
+	payCyclePromptAccountID          string
+	payCyclePromptAccountName        string
 	payCycleInput                    textinput.Model
 	payCyclePaneOpen                 bool
 	payCyclePaneFocus                int
 	payCycleConfigReturn             bool
 	payCyclePromptGoalAfterConfig    bool
+	payCycleCalendarOpen             bool
+	payCycleCalendarCursor           time.Time
+	payCycleMonthly                  bool
+	payCycleOverlay                  bool
+	payCycleOverlayIDs               map[string]bool
+	payCycleOverlaySeries            []payCycleBurndownSeries
+	payCycleMonthlyBudget            string
 	quitting                         bool
 }
 
@@ -465,11 +656,49 @@ func New(db *sql.DB) tea.Model {
 	goalInput.Placeholder = "0.00"
 	goalInput.Width = 20
 
+	renameInput := textinput.New()
+	renameInput.Prompt = "name: "
+	renameInput.Placeholder = "local account name"
+	renameInput.CharLimit = accountNameMaxLen
+
+	accountsSearchInput := textinput.New()
+	accountsSearchInput.Prompt = "/ "
+	accountsSearchInput.Placeholder = "account name"
+
 	transactionsSearchInput := textinput.New()
 	transactionsSearchInput.Prompt = ""
 	transactionsSearchInput.Placeholder = "e.g. /merchant: WOOL + amount: >60 + type: -ve"
 	transactionsSearchInput.Width = 72
 
+	transactionsTagInput := textinput.New()
+	transactionsTagInput.Prompt = "tag: "
+	transactionsTagInput.Placeholder = "e.g. Holiday"
+	transactionsTagInput.Width = 32
+
+	transactionsNoteInput := textinput.New()
+	transactionsNoteInput.Prompt = "my note: "
+	transactionsNoteInput.Placeholder = "only stored on this device"
+	transactionsNoteInput.Width = 48
+
+	transactionsAliasInput := textinput.New()
+	transactionsAliasInput.Prompt = "show as: "
+	transactionsAliasInput.Placeholder = "e.g. Coffee Club, or SQ *COFFEE* = Coffee"
+	transactionsAliasInput.Width = 48
+
+	transactionsCategoryAliasInput := textinput.New()
+	transactionsCategoryAliasInput.Prompt = "category name: "
+	transactionsCategoryAliasInput.Placeholder = "e.g. Groceries"
+	transactionsCategoryAliasInput.Width = 32
+
+	transactionsBudgetInput := textinput.New()
+	transactionsBudgetInput.Prompt = "budget $ "
+	transactionsBudgetInput.Placeholder = "per month"
+	transactionsBudgetInput.Width = 16
+	transactionsJumpInput := textinput.New()
+	transactionsJumpInput.Prompt = "page: "
+	transactionsJumpInput.Placeholder = "number"
+	transactionsJumpInput.Width = 8
+
 	payCycleInput := textinput.New()
 	payCycleInput.Prompt = "> "
 	payCycleInput.Placeholder = ""
@@ -483,31 +712,50 @@ func New(db *sql.DB) tea.Model {
 			"transactions",
 			"pay cycle burndown",
 		},
-		selected:                    0,
-		clicked:                     -1,
-		cmd:                         cmd,
-		pat:                         pat,
-		status:                      stateChecking,
-		statusDetail:                "not connected",
-		authDialog:                  authDialogNone,
-		screen:                      screenHome,
-		commandText:                 "",
-		accountsGoalInput:           goalInput,
-		configFrequencyIndex:        0,
-		transactionsPageSize:        8,
-		transactionsFilterMode:      transactionsFilterModeQuick,
-		transactionsIncludeInternal: true,
-		transactionsViewMode:        transactionsViewModeTable,
-		transactionsSearchInput:     transactionsSearchInput,
-		payCycleInput:               payCycleInput,
+		selected:                       0,
+		cmd:                            cmd,
+		pat:                            pat,
+		status:                         stateChecking,
+		statusDetail:                   "not connected",
+		offline:                        offlineFromEnv(),
+		authDialog:                     authDialogNone,
+		screen:                         screenHome,
+		commandText:                    "",
+		accountsGoalInput:              goalInput,
+		accountsSearchInput:            accountsSearchInput,
+		accountsRenameInput:            renameInput,
+		configFrequencyIndex:           0,
+		accountsSort:                   accountsSortManual,
+		transactionsPageSize:           transactionsDefaultPageSize,
+		transactionsFilterMode:         transactionsFilterModeQuick,
+		transactionsIncludeInternal:    true,
+		transactionsViewMode:           transactionsViewModeTable,
+		transactionsSearchInput:        transactionsSearchInput,
+		transactionsTagInput:           transactionsTagInput,
+		transactionsNoteInput:          transactionsNoteInput,
+		transactionsAliasInput:         transactionsAliasInput,
+		transactionsCategoryAliasInput: transactionsCategoryAliasInput,
+		transactionsBudgetInput:        transactionsBudgetInput,
+		transactionsJumpInput:          transactionsJumpInput,
+		payCycleInput:                  payCycleInput,
 	}
 }
 
 func (m model) Init() tea.Cmd {
+	connectionCmd := checkConnectionCmd
+	if m.offline {
+		connectionCmd = nil
+	}
 	return tea.Batch(
-		checkConnectionCmd,
+		connectionCmd,
 		m.loadAccountsPreviewCmd(),
 		m.transactionsPrewarmCheckCmd(),
+		m.loadConfigCmd(),
+		m.loadSavedFilterNamesCmd(),
+		m.loadHomeSyncStateCmd(),
+		homeSyncTickCmd(),
+		m.loadPinnedAccountsCmd(),
+		m.accountsAutoRefreshTickCmd(),
 	)
 }
 
@@ -522,6 +770,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case checkConnectionMsg:
+		if errors.Is(msg.err, upapi.ErrUnauthorized) {
+			return m.withPATInvalid()
+		}
 		if msg.connected {
 			m.status = stateConnected
 			m.statusDetail = "connected"
@@ -564,6 +815,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m.withCommandFeedback("local database wiped: " + msg.path)
 
+	case optimizeDBMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("db optimize failed: " + msg.err.Error())
+		}
+		return m.withCommandFeedback("local database optimized: " + msg.result.String())
+
 	case loadAccountsPreviewMsg:
 		if msg.err != nil {
 			if len(m.accountsRows) == 0 {
@@ -572,35 +829,59 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 		m.accountsErr = ""
-		m.accountsRows = msg.rows
+		m.accountsAllRows = msg.rows
+		m.accountsRows = filterAccountRows(msg.rows, m.accountsSearchQuery())
 		m.accountsFetched = msg.lastFetchedAt
+		m.accountsTypeFilter = msg.typeFilter
+		m.accountsSort = msg.sortKey
 		if m.accountsCursor >= len(m.accountsRows) {
 			m.accountsCursor = max(0, len(m.accountsRows)-1)
 		}
 		m.clampAccountsAction()
 		m.ensureAccountsScrollWindow()
+		return m, tea.Batch(m.loadAccountInterestCmd(), m.loadPinnedAccountsCmd())
+
+	case loadAccountInterestMsg:
+		if msg.err != nil {
+			m.accountsInterest = nil
+			return m, nil
+		}
+		m.accountsInterest = msg.interest
 		return m, nil
 
 	case syncAccountsPreviewMsg:
 		m.accountsLoading = false
+		if errors.Is(msg.err, upapi.ErrUnauthorized) {
+			m.accountsErr = patInvalidFeedback
+			return m.withPATInvalid()
+		}
 		if msg.err != nil {
 			if len(m.accountsRows) == 0 {
 				m.accountsErr = msg.err.Error()
 			}
 			return m, nil
 		}
+		var authCmd tea.Cmd
+		if errors.Is(msg.syncErr, upapi.ErrUnauthorized) {
+			var next tea.Model
+			next, authCmd = m.withPATInvalid()
+			m = next.(model)
+		}
 		m.accountsErr = ""
-		m.accountsRows = msg.rows
+		m.accountsAllRows = msg.rows
+		m.accountsRows = filterAccountRows(msg.rows, m.accountsSearchQuery())
 		m.accountsFetched = msg.lastFetchedAt
+		m.accountsTypeFilter = msg.typeFilter
+		m.accountsSort = msg.sortKey
 		if m.accountsCursor >= len(m.accountsRows) {
 			m.accountsCursor = max(0, len(m.accountsRows)-1)
 		}
 		m.clampAccountsAction()
 		m.ensureAccountsScrollWindow()
 		if m.screen == screenPayCycleBurndown {
-			return m, m.loadPayCycleStateCmd()
+			return m, tea.Batch(authCmd, m.loadPayCycleStateCmd(), m.loadPinnedAccountsCmd())
 		}
-		return m, nil
+		return m, tea.Batch(authCmd, m.loadAccountInterestCmd(), m.loadPinnedAccountsCmd())
 
 	case moveAccountMsg:
 		if msg.err != nil {
@@ -623,6 +904,44 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		next, cmd := m.withCommandFeedback("goal balance saved")
 		return next, tea.Batch(cmd, m.loadAccountsPreviewCmd())
 
+	case saveAccountNameMsg:
+		if msg.err != nil {
+			m.accountsRenameErr = msg.err.Error()
+			return m, nil
+		}
+		m.accountsRenameErr = ""
+		m.accountsRenameEditing = false
+		m.accountsRenameInput.Blur()
+		m.accountsRenameInput.SetValue("")
+		feedback := "account renamed"
+		if msg.cleared {
+			feedback = "account name override cleared"
+		}
+		next, cmd := m.withCommandFeedback(feedback)
+		return next, tea.Batch(cmd, m.loadAccountsPreviewCmd())
+
+	case saveAccountColorMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("colour not saved: " + msg.err.Error())
+		}
+		feedback := "colour pinned to " + msg.color
+		if msg.color == "" {
+			feedback = "colour follows account order again"
+		}
+		next, cmd := m.withCommandFeedback(feedback)
+		return next, tea.Batch(cmd, m.loadAccountsPreviewCmd())
+
+	case saveAccountSkipAutoSyncMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("auto-sync setting failed: " + msg.err.Error())
+		}
+		feedback := "auto-sync resumed for this account"
+		if msg.skip {
+			feedback = "auto-sync skipped for this account; use refresh now to update it"
+		}
+		next, cmd := m.withCommandFeedback(feedback)
+		return next, tea.Batch(cmd, m.loadAccountsPreviewCmd())
+
 	case loadConfigMsg:
 		if msg.err != nil {
 			m.configErr = msg.err.Error()
@@ -633,6 +952,16 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.configFrequencyIndex = frequencyIndexFromValue(msg.frequency)
 		m.configLastSavedDate = msg.nextPayDate
 		m.configDateDirty = false
+		m.configLargeThreshold = strings.TrimSpace(msg.largeThreshold)
+		m.syncMinInterval = msg.syncInterval
+		m.configSyncIntervalIndex = syncIntervalIndexFromDuration(msg.syncInterval)
+		m.configSyncMaxDaysIndex = syncMaxDaysIndexFromDays(msg.syncMaxDays)
+		m.configSyncReadAheadIndex = syncReadAheadIndexFromPages(msg.syncReadAhead)
+		m.configDefaultRangeIndex = msg.defaultRange.quickIdx
+		m.configDefaultRangeCustom = ""
+		if msg.defaultRange.isCustom() {
+			m.configDefaultRangeCustom = "custom " + msg.defaultRange.label()
+		}
 		return m, nil
 
 	case saveConfigMsg:
@@ -658,16 +987,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.payCycleAccounts = msg.accounts
 		m.payCycleNextDate = strings.TrimSpace(msg.nextPayDate)
 		m.payCycleFrequency = strings.TrimSpace(msg.frequency)
+		m.payCycleMonthlyBudget = msg.monthlyBudget
 		m.clampPayCycleCursor()
 		m.payCyclePromptErr = ""
 		m.refreshPayCyclePrompt()
 		if m.payCyclePromptGoalAfterConfig && m.payCyclePromptMode == payCyclePromptNone {
 			if account, ok := m.payCycleSelectedAccount(); ok {
-				m.payCyclePromptMode = payCyclePromptGoal
-				m.payCyclePromptErr = ""
-				m.payCycleInput.Placeholder = "0.00"
-				m.payCycleInput.SetValue(strings.TrimSpace(account.goalBalance))
-				m.payCycleInput.Focus()
+				m.openPayCycleGoalPrompt(account)
 			}
 			m.payCyclePromptGoalAfterConfig = false
 		}
@@ -723,6 +1049,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case loadPayCycleOverlayMsg:
+		if msg.err != nil {
+			m.payCycleErr = msg.err.Error()
+			return m, nil
+		}
+		if !m.payCycleOverlay {
+			return m, nil
+		}
+		m.payCycleErr = ""
+		m.payCycleOverlaySeries = msg.series
+		m.payCycleStartDate = msg.startDate
+		m.payCycleEndDate = msg.endDate
+		return m, nil
+
 	case savePayCycleGoalMsg:
 		if msg.err != nil {
 			m.payCyclePromptErr = msg.err.Error()
@@ -749,9 +1089,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		paneCategory := strings.TrimSpace(m.transactionsChartPaneTitle)
 		m.transactionsRows = msg.rows
 		m.transactionsCategorySpend = msg.categorySpend
-		m.transactionsTimeSeries = msg.timeSeries
+		m.transactionsTimeSeriesRaw = msg.timeSeries
+		m.transactionsTimeSeries = m.groupTransactionsTimeSeries(msg.timeSeries)
+		// Only a load that ran the spend aggregate can tell whether the
+		// selected category still has spend.
 		selectedSeriesCategory := strings.TrimSpace(m.transactionsTimeSeriesCategory)
-		if selectedSeriesCategory != "" {
+		if selectedSeriesCategory != "" && msg.aggregates.spend {
 			foundSeriesCategory := false
 			for i := range m.transactionsCategorySpend {
 				category := strings.TrimSpace(m.transactionsCategorySpend[i].category)
@@ -792,21 +1135,44 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.ensureTransactionsChartScrollWindow()
 		m.transactionsFetched = msg.lastFetchedAt
 		m.transactionsTotal = msg.totalCount
+		m.transactionsLargeThreshold = msg.largeThreshold
+		m.transactionsLargeCount = msg.largeCount
+		m.transactionsCashbackCents = msg.cashbackCents
+		m.transactionsNet = msg.net
+		m.transactionsChartCompare = msg.hasComparison
 		if msg.page >= 0 {
 			m.transactionsPage = msg.page
 		}
+		m.transactionsPageKey = msg.pageKey
+		m.transactionsIgnoredMerchants = msg.ignored
+		m.transactionsHiddenCategories = msg.hidden
+		m.transactionsMerchantAliases = msg.aliases
+		m.transactionsCategoryAliases = msg.categoryNames
+		m.transactionsDailyCounts = msg.dailyCounts
+		m.transactionsWeeklySpend = msg.weeklySpend
+		m.transactionsHeatmap = msg.heatmap
+		m.scrollTransactionsWeekly(0)
 		if m.transactionsCursor >= len(m.transactionsRows) {
 			m.transactionsCursor = max(0, len(m.transactionsRows)-1)
 		}
 		m.ensureTransactionsScrollWindow()
+		balancesCmd := m.loadRunningBalancesCmd()
 		if paneWasOpen {
 			if paneCategory == "" && len(m.transactionsCategorySpend) > 0 && m.transactionsChartCursor >= 0 && m.transactionsChartCursor < len(m.transactionsCategorySpend) {
 				paneCategory = m.transactionsCategorySpend[m.transactionsChartCursor].category
 			}
 			if strings.TrimSpace(paneCategory) != "" {
-				return m, m.loadCategoryTransactionsCmd(paneCategory, m.transactionsChartPaneSortIdx)
+				return m, tea.Batch(m.loadCategoryTransactionsCmd(paneCategory, m.transactionsChartPaneSortIdx), balancesCmd)
 			}
 		}
+		return m, tea.Batch(m.loadCategoryTrendCmd(), balancesCmd)
+
+	case loadRunningBalancesMsg:
+		if msg.err != nil {
+			m.transactionsBalances = nil
+			return m.withCommandFeedback("running balance failed: " + msg.err.Error())
+		}
+		m.transactionsBalances = msg.balances
 		return m, nil
 
 	case loadCategoryTransactionsMsg:
@@ -855,7 +1221,246 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.transactionsQuickIdx = msg.quickIdx
 			}
 			m.transactionsIncludeInternal = msg.includeInternal
+			m.transactionsTimeSeriesGrouping = msg.granularity
+			// Entering the view already reset panes, cursors and zoom, so
+			// the restored mode only needs setting before the reload.
+			m.transactionsViewMode = msg.viewMode
+			m.setTransactionsChartGrouping(msg.chartGrouping)
+			m.transactionsPayCycle = msg.payCycle
+			m.transactionsSearchHistory = msg.searchHistory
+		}
+		if m.transactionsPendingFilter != nil {
+			m.applySavedTransactionsFilter(*m.transactionsPendingFilter)
+			m.transactionsPendingFilter = nil
+			return m, tea.Batch(m.loadTransactionsPreviewCmd(), m.saveTransactionsFiltersCmd())
+		}
+		return m, m.loadTransactionsPreviewCmd()
+
+	case loadCategoryTrendMsg:
+		if msg.err != nil {
+			m.transactionsTrendErr = msg.err.Error()
+		} else {
+			m.transactionsTrendErr = ""
+		}
+		m.transactionsTrendTxID = msg.txID
+		m.transactionsTrend = msg.trend
+		return m, nil
+
+	case loadTransactionRawMsg:
+		if !m.transactionsRawOpen || msg.id != m.transactionsRawTxID {
+			return m, nil
+		}
+		if msg.err != nil {
+			m.transactionsRawErr = msg.err.Error()
+			return m, nil
+		}
+		m.transactionsRawLines = msg.lines
+		m.transactionsRawOffset = 0
+		return m, nil
+
+	case loadHourlySpendMsg:
+		if !m.transactionsHourlyOpen {
+			return m, nil
+		}
+		m.transactionsHourlyLoading = false
+		if msg.err != nil {
+			m.transactionsHourlyErr = msg.err.Error()
+			return m, nil
+		}
+		m.transactionsHourly = msg.spend
+		return m, nil
+
+	case toggleIgnoredMerchantMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("ignore merchant failed: " + msg.err.Error())
+		}
+		m.transactionsIgnoredMerchants = msg.list
+		text := fmt.Sprintf("%s excluded from spend analysis", msg.merchant)
+		if !msg.ignored {
+			text = fmt.Sprintf("%s included in spend analysis again", msg.merchant)
+		}
+		next, cmd := m.withCommandFeedback(text)
+		return next, tea.Batch(cmd, next.(model).loadTransactionsPreviewCmd())
+
+	case toggleHiddenCategoryMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("hide category failed: " + msg.err.Error())
+		}
+		m.transactionsHiddenCategories = msg.list
+		text := fmt.Sprintf("%s hidden from spend charts", msg.category)
+		if !msg.hidden {
+			text = fmt.Sprintf("%s shown in spend charts again", msg.category)
+		}
+		// The hidden category's bar is gone, so its pane has nothing to show.
+		if msg.hidden && m.transactionsChartPaneOpen && strings.EqualFold(strings.TrimSpace(m.transactionsChartPaneTitle), msg.category) {
+			m.transactionsChartPaneOpen = false
+			m.transactionsChartPaneRows = nil
+			m.transactionsChartPaneCursor = 0
+			m.transactionsChartPaneOffset = 0
+			m.transactionsChartPaneTitle = ""
+			m.transactionsChartPaneSortIdx = 0
+			m.transactionsChartPaneFocus = transactionsChartFocusMain
+			m.transactionsChartPaneMode = transactionsChartPaneModeList
+			m.transactionsChartPaneDetailTxID = ""
+		}
+		next, cmd := m.withCommandFeedback(text)
+		return next, tea.Batch(cmd, next.(model).loadTransactionsPreviewCmd())
+
+	case unhideCategoriesMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("show categories failed: " + msg.err.Error())
+		}
+		m.transactionsHiddenCategories = nil
+		noun := "categories"
+		if msg.count == 1 {
+			noun = "category"
+		}
+		next, cmd := m.withCommandFeedback(fmt.Sprintf("showing %d hidden %s again", msg.count, noun))
+		return next, tea.Batch(cmd, next.(model).loadTransactionsPreviewCmd())
+
+	case exportTransactionsMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("export failed: " + msg.err.Error())
+		}
+		return m.withCommandFeedback(fmt.Sprintf("exported %d transactions to %s", msg.count, msg.path))
+
+	case loadCategoryBudgetsMsg:
+		if msg.err != nil {
+			m.transactionsBudgetErr = "budgets unavailable: " + msg.err.Error()
+			return m, nil
+		}
+		m.transactionsCategoryBudgets = msg.budgets
+		return m, nil
+
+	case saveCategoryBudgetMsg:
+		if msg.err != nil {
+			m.transactionsBudgetErr = "budget save failed: " + msg.err.Error()
+			return m, nil
+		}
+		m.transactionsBudgetErr = ""
+		feedback := fmt.Sprintf("cleared budget for %s", msg.category)
+		if msg.cents > 0 {
+			feedback = fmt.Sprintf("budget for %s set to %s a month", msg.category, formatCategoryBudget(msg.cents))
+		}
+		next, cmd := m.withCommandFeedback(feedback)
+		return next, tea.Batch(cmd, m.loadCategoryBudgetsCmd())
+
+	case shareSummaryMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("share summary failed: " + msg.err.Error())
+		}
+		return m.withCommandFeedback(fmt.Sprintf("copied %s summary to clipboard", msg.detail))
+
+	case copyTransactionMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("copy failed: " + msg.err.Error())
+		}
+		return m.withCommandFeedback("copied to clipboard")
+
+	case saveTransactionNoteMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("note save failed: " + msg.err.Error())
+		}
+		text := "note saved"
+		if msg.cleared {
+			text = "note cleared"
 		}
+		next, cmd := m.withCommandFeedback(text)
+		return next, tea.Batch(cmd, next.(model).loadTransactionsPreviewCmd())
+
+	case saveMerchantAliasMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("merchant alias failed: " + msg.err.Error())
+		}
+		text := fmt.Sprintf("%s now shows as %s", msg.pattern, msg.name)
+		if msg.name == "" {
+			text = fmt.Sprintf("cleared alias for %s", msg.pattern)
+		}
+		next, cmd := m.withCommandFeedback(text)
+		return next, tea.Batch(cmd, next.(model).loadTransactionsPreviewCmd())
+
+	case saveCategoryAliasMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("category alias failed: " + msg.err.Error())
+		}
+		text := fmt.Sprintf("%s now shows as %s", msg.categoryID, msg.name)
+		if msg.name == "" {
+			text = fmt.Sprintf("cleared alias for %s", msg.categoryID)
+		}
+		next, cmd := m.withCommandFeedback(text)
+		return next, tea.Batch(cmd, next.(model).loadTransactionsPreviewCmd())
+
+	case saveTransactionsSearchHistoryMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("search history save failed: " + msg.err.Error())
+		}
+		return m, nil
+
+	case savedFilterNamesMsg:
+		if msg.err == nil {
+			m.transactionsSavedFilters = msg.names
+		}
+		return m, nil
+
+	case saveSavedFilterMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("save filter failed: " + msg.err.Error())
+		}
+		m.transactionsSavedFilters = addSavedFilterName(m.transactionsSavedFilters, msg.name)
+		return m.withCommandFeedback("saved filter " + msg.name)
+
+	case loadSavedFilterMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("load filter failed: " + msg.err.Error())
+		}
+		if !msg.found {
+			return m.withCommandFeedback("no saved filter named " + msg.name)
+		}
+		if m.screen != screenTransactions {
+			// Entering the view reloads the stored dates, so the filter is
+			// applied once they arrive.
+			m.transactionsPendingFilter = &msg.filter
+			next, cmd := m.enterTransactionsView()
+			shown, feedbackCmd := next.(model).withCommandFeedback("loaded filter " + msg.name)
+			return shown, tea.Batch(cmd, feedbackCmd)
+		}
+		m.applySavedTransactionsFilter(msg.filter)
+		next, cmd := m.withCommandFeedback("loaded filter " + msg.name)
+		return next, tea.Batch(cmd, m.loadTransactionsPreviewCmd(), m.saveTransactionsFiltersCmd())
+
+	case openTransactionMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("open in Up failed: " + msg.err.Error())
+		}
+		if msg.noLink {
+			return m.withCommandFeedback("this transaction has no Up app link")
+		}
+		return m.withCommandFeedback("opened in Up")
+
+	case exportKeybindingsMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("keybindings export failed: " + msg.err.Error())
+		}
+		return m.withCommandFeedback("wrote keybindings to " + msg.path)
+
+	case bulkTagTargetsMsg:
+		return m.handleBulkTagTargets(msg)
+
+	case bulkTagStepMsg:
+		return m.handleBulkTagStep(msg)
+
+	case loadTransactionsPageSizeMsg:
+		if msg.err != nil {
+			m.transactionsErr = msg.err.Error()
+			return m, nil
+		}
+		if msg.pageSize == m.transactionsPageSize {
+			return m, nil
+		}
+		m.transactionsPageSize = msg.pageSize
+		m.transactionsPage = 0
+		m.transactionsCursor = 0
+		m.transactionsOffset = 0
 		return m, m.loadTransactionsPreviewCmd()
 
 	case saveTransactionsFiltersMsg:
@@ -864,26 +1469,43 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case saveTransactionsDefaultRangeMsg:
+		if msg.err != nil {
+			m.transactionsDateErr = msg.err.Error()
+			return m, nil
+		}
+		m.transactionsDateErr = ""
+		return m.withCommandFeedback("default range set to " + msg.label)
+
 	case syncTransactionsDoneMsg:
 		if msg.sessionID != m.transactionsSession {
 			return m, nil
 		}
 		m.transactionsSyncing = false
+		m.transactionsSyncFetched = 0
 		now := time.Now().UTC()
 		m.transactionsLastSync = &now
+		var authCmd tea.Cmd
+		if errors.Is(msg.err, upapi.ErrUnauthorized) {
+			var next tea.Model
+			next, authCmd = m.withPATInvalid()
+			m = next.(model)
+		}
 		if m.screen == screenPayCycleBurndown {
 			return m, tea.Batch(
+				authCmd,
 				m.loadTransactionsPreviewCmd(),
 				m.loadPayCycleStateCmd(),
 				m.syncAndReloadAccountsPreviewCmd(false),
 			)
 		}
-		return m, m.loadTransactionsPreviewCmd()
+		return m, tea.Batch(authCmd, m.loadTransactionsPreviewCmd())
 
 	case transactionsReloadTickMsg:
 		if msg.sessionID != m.transactionsSession || (m.screen != screenTransactions && m.screen != screenTransactionsFilters && m.screen != screenPayCycleBurndown) || !m.transactionsSyncing {
 			return m, nil
 		}
+		m.transactionsSyncFetched = int(transactionsSyncProgress.Load())
 		if m.screen == screenPayCycleBurndown {
 			return m, tea.Batch(m.loadPayCycleStateCmd(), m.transactionsReloadTickCmd())
 		}
@@ -916,9 +1538,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, m.accountsClockTickCmd()
 
 	case accountsAutoRefreshTickMsg:
-		if msg.sessionID != m.accountsSession || m.screen != screenAccounts {
+		if msg.sessionID != m.accountsSession {
 			return m, nil
 		}
+		if !m.accountsAutoRefreshDue() {
+			return m, m.accountsAutoRefreshTickCmd()
+		}
 		return m, tea.Batch(
 			m.syncAndReloadAccountsPreviewCmd(true),
 			m.accountsAutoRefreshTickCmd(),
@@ -930,42 +1555,50 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
-	case clearButtonFlashMsg:
-		if msg.id == m.clickedID {
-			m.clicked = -1
+	case loadHomeSyncStateMsg:
+		m.homeSyncErr = ""
+		if msg.err != nil {
+			m.homeSyncErr = msg.err.Error()
+			return m, nil
 		}
+		m.homeSyncStates = msg.states
 		return m, nil
 
-	case tea.MouseMsg:
-		if m.showHelpOverlay || m.authDialog != authDialogNone {
-			return m, nil
-		}
+	case homeSyncTickMsg:
+		// The tick keeps running off the home screen so it needs no restart
+		// on return; it only reads sync state while home is showing.
 		if m.screen != screenHome {
-			return m, nil
+			return m, homeSyncTickCmd()
 		}
+		return m, tea.Batch(m.loadHomeSyncStateCmd(), m.loadPinnedAccountsCmd(), homeSyncTickCmd())
 
-		if msg.Action == tea.MouseActionPress && msg.Button == tea.MouseButtonLeft {
-			clicked := m.selectButtonAt(msg.X, msg.Y)
-			switch clicked {
-			case 0:
-				m.clicked = 0
-				m.clickedID++
-				m.selected = 1
-				return m, clearButtonFlashCmd(m.clickedID)
-			case 1:
-				m.clicked = 1
-				m.clickedID++
-				m.selected = 2
-				return m, clearButtonFlashCmd(m.clickedID)
-			}
+	case loadPinnedAccountsMsg:
+		if msg.err != nil {
+			return m, nil
 		}
+		m.homePinnedAccounts = msg.ids
+		m.homePinnedRows = msg.rows
 		return m, nil
 
+	case togglePinnedAccountMsg:
+		if msg.err != nil {
+			return m.withCommandFeedback("pin not saved: " + msg.err.Error())
+		}
+		m.homePinnedAccounts = msg.ids
+		m.clampAccountsAction()
+		feedback := "unpinned from the home screen"
+		if msg.pinned {
+			feedback = "pinned to the home screen"
+		}
+		next, cmd := m.withCommandFeedback(feedback)
+		return next, tea.Batch(cmd, m.loadPinnedAccountsCmd())
+
 	case tea.KeyMsg:
 		if m.showHelpOverlay {
 			switch msg.String() {
-			case "esc":
+			case "esc", "?":
 				m.showHelpOverlay = false
+				m.helpOverlayViewKeys = false
 				return m, nil
 			case "ctrl+c", "q":
 				m.quitting = true
@@ -1013,36 +1646,94 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.configErr = ""
 				m.cmd.Focus()
 				return m, nil
-			case "tab", "up", "down", "j", "k":
-				if m.configFocus == 0 {
-					m.configFocus = 1
-				} else {
-					m.configFocus = 0
-				}
+			case "tab", "down", "j":
+				m.configFocus = (m.configFocus + 1) % configFieldCount
+				return m, nil
+			case "shift+tab", "up", "k":
+				m.configFocus = (m.configFocus - 1 + configFieldCount) % configFieldCount
 				return m, nil
 			case "left", "h":
-				if m.configFocus == 1 {
+				if m.configFocus == configFocusFrequency {
 					opts := configFrequencyOptions()
 					m.configFrequencyIndex = (m.configFrequencyIndex - 1 + len(opts)) % len(opts)
 					return m, nil
 				}
+				if m.configFocus == configFocusSyncInterval {
+					opts := configSyncIntervalOptions()
+					m.configSyncIntervalIndex = (m.configSyncIntervalIndex - 1 + len(opts)) % len(opts)
+					return m, nil
+				}
+				if m.configFocus == configFocusSyncMaxDays {
+					opts := configSyncMaxDaysOptions()
+					m.configSyncMaxDaysIndex = (m.configSyncMaxDaysIndex - 1 + len(opts)) % len(opts)
+					return m, nil
+				}
+				if m.configFocus == configFocusSyncReadAhead {
+					opts := configSyncReadAheadOptions()
+					m.configSyncReadAheadIndex = (m.configSyncReadAheadIndex - 1 + len(opts)) % len(opts)
+					return m, nil
+				}
+				if m.configFocus == configFocusDefaultRange {
+					opts := transactionsQuickRanges()
+					m.configDefaultRangeIndex = (m.configDefaultRangeIndex - 1 + len(opts)) % len(opts)
+					return m, nil
+				}
 			case "right", "l":
-				if m.configFocus == 1 {
+				if m.configFocus == configFocusFrequency {
 					opts := configFrequencyOptions()
 					m.configFrequencyIndex = (m.configFrequencyIndex + 1) % len(opts)
 					return m, nil
 				}
+				if m.configFocus == configFocusSyncInterval {
+					opts := configSyncIntervalOptions()
+					m.configSyncIntervalIndex = (m.configSyncIntervalIndex + 1) % len(opts)
+					return m, nil
+				}
+				if m.configFocus == configFocusSyncMaxDays {
+					opts := configSyncMaxDaysOptions()
+					m.configSyncMaxDaysIndex = (m.configSyncMaxDaysIndex + 1) % len(opts)
+					return m, nil
+				}
+				if m.configFocus == configFocusSyncReadAhead {
+					opts := configSyncReadAheadOptions()
+					m.configSyncReadAheadIndex = (m.configSyncReadAheadIndex + 1) % len(opts)
+					return m, nil
+				}
+				if m.configFocus == configFocusDefaultRange {
+					opts := transactionsQuickRanges()
+					m.configDefaultRangeIndex = (m.configDefaultRangeIndex + 1) % len(opts)
+					return m, nil
+				}
 			case "enter":
+				values, err := m.configSettingsValues()
+				if err != nil {
+					m.configErr = err.Error()
+					return m, nil
+				}
+				if m.configFocus == configFocusLargeThreshold ||
+					m.configFocus == configFocusSyncInterval ||
+					m.configFocus == configFocusSyncMaxDays ||
+					m.configFocus == configFocusSyncReadAhead ||
+					m.configFocus == configFocusDefaultRange {
+					// These settings are independent of the pay cycle, so save them alone.
+					m.configErr = ""
+					m.configLargeThreshold = values[txLargeThresholdKey]
+					m.syncMinInterval = configSyncIntervalOptions()[m.configSyncIntervalIndex]
+					return m, m.saveConfigCmd(values)
+				}
 				date, err := validateAndFormatDateDigits(m.configNextPayDigits, m.configDateDirty)
 				if err != nil {
 					m.configErr = err.Error()
 					return m, nil
 				}
-				freq := configFrequencyOptions()[m.configFrequencyIndex]
+				values["pay_cycle.next_date"] = date
+				values["pay_cycle.frequency"] = configFrequencyOptions()[m.configFrequencyIndex]
 				m.configErr = ""
-				return m, m.saveConfigCmd(date, freq)
+				m.configLargeThreshold = values[txLargeThresholdKey]
+				m.syncMinInterval = configSyncIntervalOptions()[m.configSyncIntervalIndex]
+				return m, m.saveConfigCmd(values)
 			case "backspace", "delete":
-				if m.configFocus == 0 {
+				if m.configFocus == configFocusNextPayDate {
 					if len(m.configNextPayDigits) > 0 {
 						m.configNextPayDigits = m.configNextPayDigits[:len(m.configNextPayDigits)-1]
 						m.configDateDirty = true
@@ -1050,10 +1741,24 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.configErr = ""
 					return m, nil
 				}
+				if m.configFocus == configFocusLargeThreshold {
+					if len(m.configLargeThreshold) > 0 {
+						m.configLargeThreshold = m.configLargeThreshold[:len(m.configLargeThreshold)-1]
+					}
+					m.configErr = ""
+					return m, nil
+				}
 			}
 
 			var cmd tea.Cmd
-			if m.configFocus == 0 {
+			if m.configFocus == configFocusLargeThreshold {
+				if msg.Type == tea.KeyRunes {
+					m.configLargeThreshold = normalizeGoalInput(m.configLargeThreshold + string(msg.Runes))
+					m.configErr = ""
+				}
+				return m, nil
+			}
+			if m.configFocus == configFocusNextPayDate {
 				if msg.Type == tea.KeyRunes {
 					for _, ch := range msg.Runes {
 						if ch >= '0' && ch <= '9' && len(m.configNextPayDigits) < 8 {
@@ -1083,13 +1788,61 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, cmd
 		}
+		if m.screen == screenPayCycleBurndown && m.payCyclePromptMode == payCyclePromptNextDate && m.payCycleCalendarOpen {
+			if cursor, ok := moveCalendarCursor(m.payCycleCalendarCursor, msg.String()); ok {
+				m.payCycleCalendarCursor = cursor
+				return m, nil
+			}
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc":
+				m.payCycleCalendarOpen = false
+			case "enter":
+				formatted := m.payCycleCalendarCursor.Format("2006-01-02")
+				m.payCycleCalendarOpen = false
+				m.payCyclePromptErr = ""
+				m.payCycleInput.SetValue(dateToDigits(formatted))
+				m.payCycleNextDate = formatted
+				return m, m.savePayCycleConfigValueCmd(map[string]string{
+					"pay_cycle.next_date": formatted,
+				})
+			}
+			return m, nil
+		}
 		if m.screen == screenPayCycleBurndown && m.payCyclePromptMode != payCyclePromptNone {
+			if m.payCyclePromptMode == payCyclePromptNextDate &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'c' {
+				selected := time.Now().In(time.Local)
+				if t, ok := calendarAnchorFromPartial(m.payCycleInput.Value()); ok {
+					selected = t
+				}
+				m.payCycleCalendarCursor = time.Date(selected.Year(), selected.Month(), selected.Day(), 0, 0, 0, 0, time.Local)
+				m.payCycleCalendarOpen = true
+				m.payCyclePromptErr = ""
+				return m, nil
+			}
 			switch msg.String() {
 			case "ctrl+c", "q":
 				m.quitting = true
 				return m, tea.Quit
 			case "esc":
-				if m.payCyclePromptMode == payCyclePromptGoal {
+				if m.payCyclePromptMode == payCyclePromptMonthlyBudget {
+					if _, err := parseGoalBalanceCents(m.payCycleMonthlyBudget); err != nil {
+						// No budget to chart yet, so fall back to the pay cycle.
+						m.payCycleMonthly = false
+						m.payCycleCursor = 0
+						m.payCyclePromptMode = payCyclePromptNone
+						m.payCyclePromptErr = ""
+						m.payCycleInput.SetValue("")
+						m.payCycleInput.Blur()
+						return m, m.loadPayCycleStateCmd()
+					}
+				}
+				if m.payCyclePromptMode == payCyclePromptGoal || m.payCyclePromptMode == payCyclePromptMonthlyBudget {
 					m.payCyclePromptMode = payCyclePromptNone
 					m.payCyclePromptErr = ""
 					m.payCycleInput.SetValue("")
@@ -1140,8 +1893,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						"pay_cycle.frequency": freq,
 					})
 				case payCyclePromptGoal:
-					account, ok := m.payCycleSelectedAccount()
-					if !ok {
+					account, err := m.payCycleGoalTarget()
+					if err != nil {
+						m.payCyclePromptErr = err.Error()
 						return m, nil
 					}
 					raw := normalizeGoalInput(m.payCycleInput.Value())
@@ -1152,15 +1906,30 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					m.payCyclePromptErr = ""
 					return m, m.savePayCycleGoalCmd(account.id, fmt.Sprintf("%.2f", float64(goalCents)/100.0))
-				}
-			}
-			var cmd tea.Cmd
-			m.payCycleInput, cmd = m.payCycleInput.Update(msg)
-			m.payCyclePromptErr = ""
-			if m.payCyclePromptMode == payCyclePromptNextDate {
-				m.payCycleInput.SetValue(limitDigits(digitsOnly(m.payCycleInput.Value()), 8))
-			}
-			if m.payCyclePromptMode == payCyclePromptGoal {
+				case payCyclePromptMonthlyBudget:
+					budgetCents, err := parseCategoryBudgetCents(normalizeGoalInput(m.payCycleInput.Value()))
+					if err != nil {
+						m.payCyclePromptErr = err.Error()
+						return m, nil
+					}
+					if budgetCents <= 0 {
+						m.payCyclePromptErr = "budget must be greater than 0"
+						return m, nil
+					}
+					m.payCyclePromptErr = ""
+					m.payCycleMonthlyBudget = fmt.Sprintf("%.2f", float64(budgetCents)/100.0)
+					return m, m.savePayCycleConfigValueCmd(map[string]string{
+						monthlyBudgetKey: m.payCycleMonthlyBudget,
+					})
+				}
+			}
+			var cmd tea.Cmd
+			m.payCycleInput, cmd = m.payCycleInput.Update(msg)
+			m.payCyclePromptErr = ""
+			if m.payCyclePromptMode == payCyclePromptNextDate {
+				m.payCycleInput.SetValue(limitDigits(digitsOnly(m.payCycleInput.Value()), 8))
+			}
+			if m.payCyclePromptMode == payCyclePromptGoal || m.payCyclePromptMode == payCyclePromptMonthlyBudget {
 				m.payCycleInput.SetValue(normalizeGoalInput(m.payCycleInput.Value()))
 			}
 			return m, cmd
@@ -1201,77 +1970,74 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.accountsGoalInput.SetValue(normalizeGoalInput(m.accountsGoalInput.Value()))
 			return m, cmd
 		}
+		if m.screen == screenAccounts && m.accountsRenameEditing {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc":
+				m.accountsRenameEditing = false
+				m.accountsRenameErr = ""
+				m.accountsRenameInput.SetValue("")
+				m.accountsRenameInput.Blur()
+				return m, nil
+			case "enter":
+				if len(m.accountsRows) == 0 || m.accountsCursor >= len(m.accountsRows) {
+					m.accountsRenameErr = "no account selected"
+					return m, nil
+				}
+				m.accountsRenameErr = ""
+				return m, m.saveAccountNameCmd(m.accountsRows[m.accountsCursor].id, m.accountsRenameInput.Value())
+			}
+
+			var cmd tea.Cmd
+			m.accountsRenameInput, cmd = m.accountsRenameInput.Update(msg)
+			return m, cmd
+		}
+		if m.screen == screenAccounts && m.accountsSearchActive {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc":
+				m.clearAccountsSearch()
+				return m, nil
+			case "enter":
+				m.accountsSearchActive = false
+				m.accountsSearchInput.Blur()
+				return m, nil
+			}
+			var cmd tea.Cmd
+			m.accountsSearchInput, cmd = m.accountsSearchInput.Update(msg)
+			m.applyAccountsSearch()
+			return m, cmd
+		}
+		if m.screen == screenAccounts &&
+			strings.TrimSpace(m.cmd.Value()) == "" &&
+			!m.shouldShowCommandSuggestions() &&
+			(!m.accountsPaneOpen || m.accountsPaneFocus == accountsFocusCards) &&
+			msg.Type == tea.KeyRunes &&
+			len(msg.Runes) == 1 &&
+			msg.Runes[0] == '/' {
+			m.cmd.SetValue("")
+			m.clearCommandSuggestions()
+			m.accountsSearchActive = true
+			m.accountsSearchInput.Focus()
+			m.accountsSearchInput.CursorEnd()
+			return m, nil
+		}
 
 		if m.screen == screenTransactionsFilters &&
 			strings.TrimSpace(m.cmd.Value()) == "" &&
 			!m.shouldShowCommandSuggestions() &&
 			(m.transactionsFocus == transactionsFocusFromDate || m.transactionsFocus == transactionsFocusToDate) {
 			if m.transactionsCalendarOpen {
-				switch msg.String() {
-				case "shift+left":
-					m.transactionsCalendarCursor = shiftCalendarByMonths(m.transactionsCalendarCursor, -1)
-					m.transactionsCalendarMonth = time.Date(
-						m.transactionsCalendarCursor.Year(),
-						m.transactionsCalendarCursor.Month(),
-						1, 0, 0, 0, 0, time.Local,
-					)
-					return m, nil
-				case "shift+right":
-					m.transactionsCalendarCursor = shiftCalendarByMonths(m.transactionsCalendarCursor, 1)
-					m.transactionsCalendarMonth = time.Date(
-						m.transactionsCalendarCursor.Year(),
-						m.transactionsCalendarCursor.Month(),
-						1, 0, 0, 0, 0, time.Local,
-					)
-					return m, nil
-				case "shift+up":
-					m.transactionsCalendarCursor = shiftCalendarByYears(m.transactionsCalendarCursor, -1)
-					m.transactionsCalendarMonth = time.Date(
-						m.transactionsCalendarCursor.Year(),
-						m.transactionsCalendarCursor.Month(),
-						1, 0, 0, 0, 0, time.Local,
-					)
-					return m, nil
-				case "shift+down":
-					m.transactionsCalendarCursor = shiftCalendarByYears(m.transactionsCalendarCursor, 1)
-					m.transactionsCalendarMonth = time.Date(
-						m.transactionsCalendarCursor.Year(),
-						m.transactionsCalendarCursor.Month(),
-						1, 0, 0, 0, 0, time.Local,
-					)
-					return m, nil
-				case "left":
-					m.transactionsCalendarCursor = m.transactionsCalendarCursor.AddDate(0, 0, -1)
-					m.transactionsCalendarMonth = time.Date(
-						m.transactionsCalendarCursor.Year(),
-						m.transactionsCalendarCursor.Month(),
-						1, 0, 0, 0, 0, time.Local,
-					)
-					return m, nil
-				case "right":
-					m.transactionsCalendarCursor = m.transactionsCalendarCursor.AddDate(0, 0, 1)
-					m.transactionsCalendarMonth = time.Date(
-						m.transactionsCalendarCursor.Year(),
-						m.transactionsCalendarCursor.Month(),
-						1, 0, 0, 0, 0, time.Local,
-					)
-					return m, nil
-				case "up":
-					m.transactionsCalendarCursor = m.transactionsCalendarCursor.AddDate(0, 0, -7)
-					m.transactionsCalendarMonth = time.Date(
-						m.transactionsCalendarCursor.Year(),
-						m.transactionsCalendarCursor.Month(),
-						1, 0, 0, 0, 0, time.Local,
-					)
-					return m, nil
-				case "down":
-					m.transactionsCalendarCursor = m.transactionsCalendarCursor.AddDate(0, 0, 7)
-					m.transactionsCalendarMonth = time.Date(
-						m.transactionsCalendarCursor.Year(),
-						m.transactionsCalendarCursor.Month(),
-						1, 0, 0, 0, 0, time.Local,
-					)
+				if cursor, ok := moveCalendarCursor(m.transactionsCalendarCursor, msg.String()); ok {
+					m.transactionsCalendarCursor = cursor
+					m.transactionsCalendarMonth = calendarMonthOf(cursor)
 					return m, nil
+				}
+				switch msg.String() {
 				case "enter":
 					digits := fmt.Sprintf("%04d%02d%02d",
 						m.transactionsCalendarCursor.Year(),
@@ -1319,6 +2085,198 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if m.screen == screenTransactions && m.transactionsRawOpen {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc", "q", "J":
+				m.transactionsRawOpen = false
+				m.transactionsRawTxID = ""
+				m.transactionsRawLines = nil
+				m.transactionsRawOffset = 0
+				m.transactionsRawErr = ""
+			case "up", "k":
+				m.scrollTransactionsRaw(-1)
+			case "down", "j":
+				m.scrollTransactionsRaw(1)
+			case "pgup":
+				m.scrollTransactionsRaw(-m.transactionsRawVisibleRows())
+			case "pgdown":
+				m.scrollTransactionsRaw(m.transactionsRawVisibleRows())
+			case "home":
+				m.transactionsRawOffset = 0
+			case "end":
+				m.scrollTransactionsRaw(len(m.transactionsRawLines))
+			}
+			return m, nil
+		}
+
+		if m.screen == screenTransactions && m.transactionsHourlyOpen {
+			switch msg.String() {
+			case "ctrl+c", "q":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc", "H":
+				m.transactionsHourlyOpen = false
+				m.transactionsHourlyLoading = false
+				m.transactionsHourlyErr = ""
+			}
+			return m, nil
+		}
+
+		if m.screen == screenTransactions && m.transactionsTagActive {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc":
+				m.transactionsTagActive = false
+				m.transactionsTagErr = ""
+				m.transactionsTagInput.SetValue("")
+				m.transactionsTagInput.Blur()
+				return m, nil
+			case "enter":
+				tag, err := normalizeTagName(m.transactionsTagInput.Value())
+				if err != nil {
+					m.transactionsTagErr = err.Error()
+					return m, nil
+				}
+				m.transactionsTagActive = false
+				m.transactionsTagErr = ""
+				m.transactionsTagInput.SetValue("")
+				m.transactionsTagInput.Blur()
+				if m.offline {
+					return m.withCommandFeedback("offline: /offline to sync again")
+				}
+				m.transactionsTagStatus = fmt.Sprintf("finding transactions to tag with %q...", tag)
+				return m, m.loadBulkTagTargetsCmd(tag)
+			}
+			var cmd tea.Cmd
+			m.transactionsTagInput, cmd = m.transactionsTagInput.Update(msg)
+			m.transactionsTagErr = ""
+			return m, cmd
+		}
+
+		if m.screen == screenTransactions && m.transactionsNoteActive {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc":
+				m.closeTransactionsNotePrompt()
+				return m, nil
+			case "enter":
+				id, note := m.transactionsNoteTxID, m.transactionsNoteInput.Value()
+				m.closeTransactionsNotePrompt()
+				return m, m.saveTransactionNoteCmd(id, note)
+			}
+			var cmd tea.Cmd
+			m.transactionsNoteInput, cmd = m.transactionsNoteInput.Update(msg)
+			return m, cmd
+		}
+
+		if m.screen == screenTransactions && m.transactionsAliasActive {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc":
+				m.closeMerchantAliasPrompt()
+				return m, nil
+			case "enter":
+				pattern, name := parseMerchantAliasInput(m.transactionsAliasMerchant, m.transactionsAliasInput.Value())
+				m.closeMerchantAliasPrompt()
+				return m, m.saveMerchantAliasCmd(pattern, name)
+			}
+			var cmd tea.Cmd
+			m.transactionsAliasInput, cmd = m.transactionsAliasInput.Update(msg)
+			return m, cmd
+		}
+
+		if m.screen == screenTransactions && m.transactionsCategoryAliasActive {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc":
+				m.closeCategoryAliasPrompt()
+				return m, nil
+			case "enter":
+				category := strings.TrimSpace(m.transactionsChartPaneTitle)
+				name := strings.TrimSpace(m.transactionsCategoryAliasInput.Value())
+				m.closeCategoryAliasPrompt()
+				return m, m.saveCategoryAliasCmd(category, name)
+			}
+			var cmd tea.Cmd
+			m.transactionsCategoryAliasInput, cmd = m.transactionsCategoryAliasInput.Update(msg)
+			return m, cmd
+		}
+
+		if m.screen == screenTransactions && m.transactionsBudgetActive {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc":
+				m.transactionsBudgetActive = false
+				m.transactionsBudgetErr = ""
+				m.transactionsBudgetInput.SetValue("")
+				m.transactionsBudgetInput.Blur()
+				return m, nil
+			case "enter":
+				cents, err := parseCategoryBudgetCents(m.transactionsBudgetInput.Value())
+				if err != nil {
+					m.transactionsBudgetErr = err.Error()
+					return m, nil
+				}
+				category := strings.TrimSpace(m.transactionsChartPaneTitle)
+				m.transactionsBudgetActive = false
+				m.transactionsBudgetErr = ""
+				m.transactionsBudgetInput.SetValue("")
+				m.transactionsBudgetInput.Blur()
+				return m, m.saveCategoryBudgetCmd(category, cents)
+			}
+			var cmd tea.Cmd
+			m.transactionsBudgetInput, cmd = m.transactionsBudgetInput.Update(msg)
+			m.transactionsBudgetInput.SetValue(normalizeGoalInput(m.transactionsBudgetInput.Value()))
+			m.transactionsBudgetErr = ""
+			return m, cmd
+		}
+
+		if m.screen == screenTransactions && m.transactionsJumpActive {
+			switch msg.String() {
+			case "ctrl+c":
+				m.quitting = true
+				return m, tea.Quit
+			case "esc":
+				m.transactionsJumpActive = false
+				m.transactionsJumpErr = ""
+				m.transactionsJumpInput.SetValue("")
+				m.transactionsJumpInput.Blur()
+				return m, nil
+			case "enter":
+				page, err := parseTransactionsJumpPage(m.transactionsJumpInput.Value(), m.transactionsTotal, m.transactionsPageSize)
+				if err != nil {
+					m.transactionsJumpErr = err.Error()
+					return m, nil
+				}
+				m.transactionsJumpActive = false
+				m.transactionsJumpErr = ""
+				m.transactionsJumpInput.SetValue("")
+				m.transactionsJumpInput.Blur()
+				m.transactionsPage = page
+				m.transactionsCursor = 0
+				next, feedbackCmd := m.withCommandFeedback(fmt.Sprintf("jumped to page %d", page+1))
+				return next, tea.Batch(feedbackCmd, m.loadTransactionsPreviewCmd())
+			}
+			var cmd tea.Cmd
+			m.transactionsJumpInput, cmd = m.transactionsJumpInput.Update(msg)
+			m.transactionsJumpInput.SetValue(digitsOnly(m.transactionsJumpInput.Value()))
+			m.transactionsJumpErr = ""
+			return m, cmd
+		}
+
 		if m.screen == screenTransactions {
 			if m.transactionsViewMode == transactionsViewModeTimeSeries && m.transactionsSearchActive {
 				m.transactionsSearchActive = false
@@ -1326,7 +2284,17 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			if m.transactionsViewMode != transactionsViewModeTimeSeries && m.transactionsSearchActive {
 				switch msg.String() {
+				case "up", "down":
+					delta := 1
+					if msg.String() == "down" {
+						delta = -1
+					}
+					if m.recallTransactionsSearch(delta) {
+						m.transactionsSearchErr = ""
+					}
+					return m, nil
 				case "enter":
+					m.transactionsSearchRecall = 0
 					searchInput := strings.TrimSpace(m.transactionsSearchInput.Value())
 					appliedSearch := strings.TrimSpace(m.transactionsSearchApplied)
 					if isTransactionsSearchResetQuery(searchInput) {
@@ -1343,7 +2311,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if searchInput != appliedSearch {
 						if !isHelp {
 							if err := validateTransactionsSearchSyntax(searchInput); err != nil {
-								m.transactionsSearchErr = "invalid search syntax, type /help for info"
+								m.transactionsSearchErr = err.Error() + " (type /help for info)"
 								return m, nil
 							}
 						}
@@ -1359,7 +2327,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.transactionsSearchInput.Blur()
 						m.transactionsPage = 0
 						m.transactionsCursor = 0
-						return m, m.loadTransactionsPreviewCmd()
+						m.transactionsSearchHistory = pushTransactionsSearchHistory(m.transactionsSearchHistory, searchInput)
+						return m, tea.Batch(
+							m.loadTransactionsPreviewCmd(),
+							m.saveTransactionsSearchHistoryCmd(m.transactionsSearchHistory),
+						)
 					}
 					if isHelp {
 						// Enter should not leave search mode while help instructions are active.
@@ -1372,6 +2344,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.transactionsSearchInput.Blur()
 					return m, nil
 				case "esc":
+					m.transactionsSearchRecall = 0
 					if isTransactionsSearchHelpQuery(m.transactionsSearchApplied) {
 						m.transactionsSearchInput.SetValue("")
 						m.transactionsSearchApplied = ""
@@ -1389,9 +2362,193 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					var cmd tea.Cmd
 					m.transactionsSearchInput, cmd = m.transactionsSearchInput.Update(msg)
 					m.transactionsSearchErr = ""
+					m.transactionsSearchRecall = 0
 					return m, cmd
 				}
 			}
+			if m.transactionsViewMode == transactionsViewModeChart &&
+				m.transactionsChartPaneOpen &&
+				m.transactionsChartPaneMode == transactionsChartPaneModeList &&
+				!m.transactionsChartByMerchant &&
+				!m.transactionsChartShowsParents() &&
+				strings.TrimSpace(m.transactionsChartPaneTitle) != "" &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'B' {
+				m.transactionsBudgetActive = true
+				m.transactionsBudgetErr = ""
+				m.transactionsBudgetInput.SetValue("")
+				if cents, ok := m.transactionsCategoryBudgets[strings.TrimSpace(m.transactionsChartPaneTitle)]; ok {
+					m.transactionsBudgetInput.SetValue(fmt.Sprintf("%.2f", float64(cents)/100.0))
+				}
+				m.transactionsBudgetInput.CursorEnd()
+				m.transactionsBudgetInput.Focus()
+				return m, nil
+			}
+			if m.transactionsViewMode == transactionsViewModeChart &&
+				m.transactionsChartPaneOpen &&
+				m.transactionsChartPaneMode == transactionsChartPaneModeList &&
+				!m.transactionsChartByMerchant &&
+				strings.TrimSpace(m.transactionsChartPaneTitle) != "" &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'A' {
+				m.transactionsCategoryAliasActive = true
+				m.transactionsCategoryAliasInput.SetValue(m.transactionsCategoryAliases[strings.TrimSpace(m.transactionsChartPaneTitle)])
+				m.transactionsCategoryAliasInput.CursorEnd()
+				m.transactionsCategoryAliasInput.Focus()
+				return m, nil
+			}
+			if m.transactionsViewMode == transactionsViewModeChart &&
+				m.transactionsChartPaneOpen &&
+				m.transactionsChartPaneMode == transactionsChartPaneModeList &&
+				!m.transactionsChartByMerchant &&
+				!m.transactionsChartShowsParents() &&
+				strings.TrimSpace(m.transactionsChartPaneTitle) != "" &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'x' {
+				return m, m.toggleHiddenCategoryCmd(strings.TrimSpace(m.transactionsChartPaneTitle))
+			}
+			if m.transactionsViewMode != transactionsViewModeTable &&
+				len(m.transactionsHiddenCategories) > 0 &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'X' {
+				return m, m.unhideCategoriesCmd()
+			}
+			if m.transactionsViewMode == transactionsViewModeChart &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == '%' {
+				m.transactionsChartBudgetPct = !m.transactionsChartBudgetPct
+				return m, nil
+			}
+			if m.transactionsViewMode == transactionsViewModeChart &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'v' {
+				m.transactionsChartVsPrevious = !m.transactionsChartVsPrevious
+				return m, m.loadTransactionsPreviewCmd()
+			}
+			if m.transactionsViewMode == transactionsViewModeTable &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == ':' {
+				m.transactionsJumpActive = true
+				m.transactionsJumpErr = ""
+				m.transactionsJumpInput.SetValue("")
+				m.transactionsJumpInput.Focus()
+				return m, nil
+			}
+			if m.transactionsViewMode == transactionsViewModeTable &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'T' {
+				if m.transactionsTagging {
+					return m, nil
+				}
+				m.transactionsTagActive = true
+				m.transactionsTagErr = ""
+				m.transactionsTagStatus = ""
+				m.transactionsTagInput.SetValue("")
+				m.transactionsTagInput.Focus()
+				return m, nil
+			}
+			if (m.transactionsPaneOpen ||
+				(m.transactionsChartPaneOpen && m.transactionsChartPaneMode == transactionsChartPaneModeDetails)) &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'd' {
+				m.transactionsDetailsToggled = !m.transactionsDetailsToggled
+				return m, nil
+			}
+			if (m.transactionsPaneOpen ||
+				(m.transactionsChartPaneOpen && m.transactionsChartPaneMode == transactionsChartPaneModeDetails)) &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'y' {
+				details, ok := m.selectedTransactionDetails()
+				if !ok {
+					return m.withCommandFeedback("no transaction selected")
+				}
+				return m, copyTransactionCmd(formatTransactionSummary(details))
+			}
+			if (m.transactionsPaneOpen ||
+				(m.transactionsChartPaneOpen && m.transactionsChartPaneMode == transactionsChartPaneModeDetails)) &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'u' {
+				details, ok := m.selectedTransactionDetails()
+				if !ok {
+					return m.withCommandFeedback("no transaction selected")
+				}
+				return m, m.openTransactionDeepLinkCmd(details.id)
+			}
+			if (m.transactionsPaneOpen ||
+				(m.transactionsChartPaneOpen && m.transactionsChartPaneMode == transactionsChartPaneModeDetails)) &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'n' {
+				details, ok := m.selectedTransactionDetails()
+				if !ok {
+					return m.withCommandFeedback("no transaction selected")
+				}
+				m.transactionsNoteActive = true
+				m.transactionsNoteTxID = details.id
+				m.transactionsNoteInput.SetValue(details.localNote)
+				m.transactionsNoteInput.CursorEnd()
+				m.transactionsNoteInput.Focus()
+				return m, nil
+			}
+			if (m.transactionsPaneOpen ||
+				(m.transactionsChartPaneOpen && m.transactionsChartPaneMode == transactionsChartPaneModeDetails)) &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'a' {
+				details, ok := m.selectedTransactionDetails()
+				if !ok || strings.TrimSpace(details.merchant) == "" {
+					return m.withCommandFeedback("no merchant selected")
+				}
+				m.transactionsAliasActive = true
+				m.transactionsAliasMerchant = details.merchant
+				m.transactionsAliasInput.SetValue("")
+				if a, found := merchantAliasFor(m.transactionsMerchantAliases, details.merchant); found {
+					if a.pattern == normalizeMerchantAliasPattern(details.merchant) {
+						m.transactionsAliasInput.SetValue(a.name)
+					} else {
+						m.transactionsAliasInput.SetValue(a.pattern + " = " + a.name)
+					}
+				}
+				m.transactionsAliasInput.CursorEnd()
+				m.transactionsAliasInput.Focus()
+				return m, nil
+			}
+			if m.transactionsViewMode != transactionsViewModeTimeSeries &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'J' {
+				id := m.selectedTransactionID()
+				if id == "" {
+					return m, nil
+				}
+				m.transactionsRawOpen = true
+				m.transactionsRawTxID = id
+				m.transactionsRawLines = nil
+				m.transactionsRawOffset = 0
+				m.transactionsRawErr = ""
+				return m, m.loadTransactionRawCmd(id)
+			}
+			if m.transactionsViewMode != transactionsViewModeTimeSeries &&
+				msg.Type == tea.KeyRunes &&
+				len(msg.Runes) == 1 &&
+				msg.Runes[0] == 'H' {
+				m.transactionsHourlyOpen = true
+				m.transactionsHourlyLoading = true
+				m.transactionsHourly = hourlySpend{}
+				m.transactionsHourlyErr = ""
+				return m, m.loadHourlySpendCmd()
+			}
 			if m.transactionsViewMode != transactionsViewModeTimeSeries &&
 				msg.Type == tea.KeyRunes &&
 				len(msg.Runes) == 1 &&
@@ -1412,6 +2569,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "shift+up":
 			if m.screen == screenAccounts &&
+				m.accountsSort != accountsSortManual &&
+				(!m.accountsPaneOpen || m.accountsPaneFocus == accountsFocusCards) {
+				return m.withCommandFeedback("reordering needs manual order: press o to switch")
+			}
+			if m.screen == screenAccounts &&
+				m.accountsSearchQuery() == "" &&
 				(!m.accountsPaneOpen || m.accountsPaneFocus == accountsFocusCards) &&
 				len(m.accountsRows) > 0 &&
 				m.accountsCursor > 0 {
@@ -1424,6 +2587,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "shift+down":
 			if m.screen == screenAccounts &&
+				m.accountsSort != accountsSortManual &&
+				(!m.accountsPaneOpen || m.accountsPaneFocus == accountsFocusCards) {
+				return m.withCommandFeedback("reordering needs manual order: press o to switch")
+			}
+			if m.screen == screenAccounts &&
+				m.accountsSearchQuery() == "" &&
 				(!m.accountsPaneOpen || m.accountsPaneFocus == accountsFocusCards) &&
 				len(m.accountsRows) > 0 &&
 				m.accountsCursor < len(m.accountsRows)-1 {
@@ -1435,8 +2604,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 		case "tab":
+			if m.shouldShowCommandSuggestions() {
+				m.cmd.SetValue(m.commandSuggestions[m.commandSuggestionIndex].name)
+				m.cmd.CursorEnd()
+				m.refreshCommandSuggestions()
+				return m, nil
+			}
 			if m.screen == screenTransactionsFilters {
-				m.transactionsFocus = (m.transactionsFocus + 1) % 4
+				m.transactionsFocus = (m.transactionsFocus + 1) % transactionsFocusCount
 				return m, nil
 			}
 			if m.screen == screenPayCycleBurndown &&
@@ -1511,6 +2686,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.accountsPaneFocus = accountsFocusCards
 				return m, nil
 			}
+			if m.screen == screenAccounts && m.accountsSearchQuery() != "" {
+				m.clearAccountsSearch()
+				return m, nil
+			}
 			if m.screen == screenPayCycleBurndown &&
 				strings.TrimSpace(m.cmd.Value()) == "" &&
 				!m.shouldShowCommandSuggestions() {
@@ -1529,6 +2708,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.transactionsPaneOpen = false
 					return m, nil
 				}
+				if m.transactionsViewMode == transactionsViewModeChart && m.transactionsChartParentDrill != "" {
+					m.transactionsChartParentDrill = ""
+					m.transactionsChartCursor = 0
+					m.transactionsChartOffset = 0
+					return m, m.loadTransactionsPreviewCmd()
+				}
 				m.screen = screenHome
 				m.transactionsSession++
 				m.transactionsSyncing = false
@@ -1550,7 +2735,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "up", "k":
 			if m.screen == screenTransactions {
+				if m.transactionsViewMode == transactionsViewModeWeekly {
+					m.scrollTransactionsWeekly(1)
+					return m, nil
+				}
 				if m.transactionsViewMode == transactionsViewModeTimeSeries {
+					if m.transactionsTimeSeriesRoundUps {
+						return m, nil
+					}
 					if m.shiftTransactionsTimeSeriesCategory(-1) {
 						return m, m.loadTransactionsPreviewCmd()
 					}
@@ -1588,7 +2780,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.transactionsCursor > 0 {
 					m.transactionsCursor--
 					m.ensureTransactionsScrollWindow()
-					return m, nil
+					return m, m.loadCategoryTrendCmd()
 				}
 				if m.transactionsPage > 0 {
 					m.transactionsPage--
@@ -1652,7 +2844,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "down", "j":
 			if m.screen == screenTransactions {
+				if m.transactionsViewMode == transactionsViewModeWeekly {
+					m.scrollTransactionsWeekly(-1)
+					return m, nil
+				}
 				if m.transactionsViewMode == transactionsViewModeTimeSeries {
+					if m.transactionsTimeSeriesRoundUps {
+						return m, nil
+					}
 					if m.shiftTransactionsTimeSeriesCategory(1) {
 						return m, m.loadTransactionsPreviewCmd()
 					}
@@ -1690,7 +2889,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.transactionsCursor < len(m.transactionsRows)-1 {
 					m.transactionsCursor++
 					m.ensureTransactionsScrollWindow()
-					return m, nil
+					return m, m.loadCategoryTrendCmd()
 				}
 				maxPage := 0
 				if m.transactionsPageSize > 0 && m.transactionsTotal > 0 {
@@ -1765,6 +2964,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.transactionsIncludeInternal = false
 					return m, nil
 				}
+				if m.transactionsFocus == transactionsFocusPageSize {
+					m.transactionsPageSize = clampTransactionsPageSize(m.transactionsPageSize - 1)
+					return m, nil
+				}
 			}
 			if m.screen == screenPayCycleBurndown &&
 				strings.TrimSpace(m.cmd.Value()) == "" &&
@@ -1791,6 +2994,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.transactionsCursor = 0
 				return m, m.loadTransactionsPreviewCmd()
 			}
+		case "home", "end":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				!m.transactionsSearchActive &&
+				m.transactionsViewMode == transactionsViewModeTable {
+				page := 0
+				if msg.String() == "end" {
+					page = transactionsLastPage(m.transactionsTotal, m.transactionsPageSize)
+				}
+				m.transactionsPage = page
+				m.transactionsCursor = 0
+				return m, m.loadTransactionsPreviewCmd()
+			}
 		case "right":
 			if m.screen == screenTransactionsFilters &&
 				strings.TrimSpace(m.cmd.Value()) == "" &&
@@ -1804,6 +3021,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.transactionsIncludeInternal = true
 					return m, nil
 				}
+				if m.transactionsFocus == transactionsFocusPageSize {
+					m.transactionsPageSize = clampTransactionsPageSize(m.transactionsPageSize + 1)
+					return m, nil
+				}
 			}
 			if m.screen == screenPayCycleBurndown &&
 				strings.TrimSpace(m.cmd.Value()) == "" &&
@@ -1843,6 +3064,20 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.zoomTransactionsTimeSeries(true)
 				return m, nil
 			}
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				msg.String() == "+" {
+				return m, m.toggleTransactionsAmountSign(1)
+			}
+		case "0":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeTimeSeries {
+				m.resetTransactionsTimeSeriesZoom()
+				return m, nil
+			}
 		case "-", "_":
 			if m.screen == screenTransactions &&
 				strings.TrimSpace(m.cmd.Value()) == "" &&
@@ -1851,6 +3086,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.zoomTransactionsTimeSeries(false)
 				return m, nil
 			}
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				msg.String() == "-" {
+				return m, m.toggleTransactionsAmountSign(-1)
+			}
 		case "f":
 			if m.screen == screenTransactions &&
 				strings.TrimSpace(m.cmd.Value()) == "" &&
@@ -1859,6 +3100,18 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.transactionsFocus = transactionsFocusFromDate
 				return m, nil
 			}
+		case "d":
+			if m.screen == screenTransactionsFilters &&
+				!m.transactionsCalendarOpen &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() {
+				d, err := m.currentTransactionsDefaultRange()
+				if err != nil {
+					m.transactionsDateErr = err.Error()
+					return m, nil
+				}
+				return m, m.saveTransactionsDefaultRangeCmd(d)
+			}
 		case "c":
 			if m.screen == screenTransactionsFilters &&
 				(m.transactionsFocus == transactionsFocusFromDate || m.transactionsFocus == transactionsFocusToDate) &&
@@ -1901,19 +3154,229 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 					return m, m.loadCategoryTransactionsCmd(category, m.transactionsChartPaneSortIdx)
 				}
-				if m.transactionsViewMode == transactionsViewModeTable {
-					sorts := transactionsSortOptions()
-					m.transactionsSortIdx = (m.transactionsSortIdx + 1) % len(sorts)
-					m.transactionsPage = 0
-					return m, m.loadTransactionsPreviewCmd()
+				if m.transactionsViewMode == transactionsViewModeChart && !m.transactionsChartPaneOpen {
+					m.transactionsChartSort = (m.transactionsChartSort + 1) % len(transactionsChartSortLabels())
+					m.transactionsChartCursor = 0
+					m.transactionsChartOffset = 0
+					return m, m.loadTransactionsPreviewCmd()
+				}
+				if m.transactionsViewMode == transactionsViewModeTable {
+					sorts := transactionsSortOptions()
+					m.transactionsSortIdx = (m.transactionsSortIdx + 1) % len(sorts)
+					m.transactionsPage = 0
+					return m, m.loadTransactionsPreviewCmd()
+				}
+			}
+		case "S":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeTable {
+				sorts := transactionsSortOptions()
+				if m.transactionsSortIdx < 0 || m.transactionsSortIdx >= len(sorts) || !sorts[m.transactionsSortIdx].grouped {
+					return m, nil
+				}
+				m.transactionsSortNewestFirst = !m.transactionsSortNewestFirst
+				m.transactionsPage = 0
+				m.transactionsCursor = 0
+				return m, m.loadTransactionsPreviewCmd()
+			}
+		case "b":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeTable {
+				m.transactionsShowBalance = !m.transactionsShowBalance
+				if !m.transactionsShowBalance {
+					m.transactionsBalances = nil
+					return m, nil
+				}
+				return m, m.loadRunningBalancesCmd()
+			}
+		case "m":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeChart &&
+				!m.transactionsChartPaneOpen {
+				grouping := transactionsChartGroupMerchant
+				if m.transactionsChartByMerchant {
+					grouping = transactionsChartGroupCategory
+				}
+				m.setTransactionsChartGrouping(grouping)
+				m.transactionsChartCursor = 0
+				m.transactionsChartOffset = 0
+				return m, tea.Batch(m.loadTransactionsPreviewCmd(), m.saveTransactionsChartGroupingCmd())
+			}
+			if m.screen == screenPayCycleBurndown &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.payCyclePromptMode == payCyclePromptNone {
+				m.payCycleMonthly = !m.payCycleMonthly
+				m.payCycleOverlay = false
+				m.payCycleCursor = 0
+				m.payCycleSeries = nil
+				m.payCycleTransactions = nil
+				m.payCycleTxCursor = 0
+				m.payCycleCurrentBalanceCents = 0
+				m.payCycleGoalCents = 0
+				m.payCycleStartDate = ""
+				m.payCycleEndDate = ""
+				m.payCyclePaneOpen = false
+				m.payCyclePaneFocus = payCyclePaneFocusMain
+				return m, m.loadPayCycleStateCmd()
+			}
+		case "o":
+			if m.screen == screenAccounts &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				(!m.accountsPaneOpen || m.accountsPaneFocus == accountsFocusCards) {
+				m.accountsSort = nextAccountsSort(m.accountsSort)
+				m.accountsCursor = 0
+				m.accountsOffset = 0
+				m.accountsAction = 0
+				return m, m.saveAccountsSortCmd(m.accountsSort)
+			}
+			if m.screen == screenPayCycleBurndown &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.payCyclePromptMode == payCyclePromptNone &&
+				!m.payCycleMonthly {
+				m.payCycleOverlay = !m.payCycleOverlay
+				m.payCycleOverlaySeries = nil
+				m.payCyclePaneOpen = false
+				m.payCyclePaneFocus = payCyclePaneFocusMain
+				return m, m.loadPayCycleSeriesCmd()
+			}
+		case " ":
+			if m.screen == screenPayCycleBurndown &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.payCyclePromptMode == payCyclePromptNone &&
+				m.payCycleOverlay &&
+				!m.payCycleMonthly {
+				account, ok := m.payCycleSelectedAccount()
+				if !ok {
+					return m, nil
+				}
+				m.togglePayCycleOverlayAccount(account.id)
+				return m, m.loadPayCycleOverlayCmd()
+			}
+		case "p":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeChart &&
+				!m.transactionsChartPaneOpen {
+				grouping := transactionsChartGroupParent
+				if m.transactionsChartByParent {
+					grouping = transactionsChartGroupCategory
+				}
+				m.setTransactionsChartGrouping(grouping)
+				m.transactionsChartCursor = 0
+				m.transactionsChartOffset = 0
+				return m, tea.Batch(m.loadTransactionsPreviewCmd(), m.saveTransactionsChartGroupingCmd())
+			}
+		case "r":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeTimeSeries {
+				// Cycle off, cumulative round-ups, round-ups by month.
+				switch {
+				case !m.transactionsTimeSeriesRoundUps:
+					m.transactionsTimeSeriesRoundUps = true
+					m.transactionsRoundUpsMonthly = false
+				case !m.transactionsRoundUpsMonthly:
+					m.transactionsRoundUpsMonthly = true
+				default:
+					m.transactionsTimeSeriesRoundUps = false
+					m.transactionsRoundUpsMonthly = false
+				}
+				m.transactionsTimeSeriesCategory = ""
+				m.transactionsTimeSeriesZoomStart = 0
+				m.transactionsTimeSeriesZoomWindow = 0
+				m.transactionsTimeSeriesSelection = -1
+				return m, m.loadTransactionsPreviewCmd()
+			}
+		case "e":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeTable {
+				next, cmd := m.withCommandFeedback("exporting transactions...")
+				return next, tea.Batch(cmd, m.exportTransactionsCmd())
+			}
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeChart {
+				bar := strings.TrimSpace(m.transactionsChartPaneTitle)
+				if !m.transactionsChartPaneOpen || bar == "" {
+					if m.transactionsChartCursor < 0 || m.transactionsChartCursor >= len(m.transactionsCategorySpend) {
+						return m, nil
+					}
+					bar = m.transactionsCategorySpend[m.transactionsChartCursor].category
+				}
+				next, cmd := m.withCommandFeedback("exporting " + bar + " transactions...")
+				return next, tea.Batch(cmd, m.exportChartBarCmd(bar))
+			}
+		case "t":
+			if m.screen == screenAccounts &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				(!m.accountsPaneOpen || m.accountsPaneFocus == accountsFocusCards) {
+				m.accountsTypeFilter = nextAccountsTypeFilter(m.accountsTypeFilter)
+				m.accountsCursor = 0
+				m.accountsOffset = 0
+				m.accountsAction = 0
+				return m, m.saveAccountsTypeFilterCmd(m.accountsTypeFilter)
+			}
+			if m.screen == screenPayCycleBurndown &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.payCyclePromptMode == payCyclePromptNone &&
+				!m.payCycleOverlay {
+				idx, inWindow := payCycleTodayTransactionIndex(m.payCycleSeries, m.payCycleTransactions, m.payCycleStartDate, m.payCycleEndDate, time.Now().In(time.Local))
+				if !inWindow {
+					return m.withCommandFeedback("today is outside this cycle")
+				}
+				if idx < 0 {
+					return m.withCommandFeedback("no transactions to jump to")
+				}
+				m.payCycleTxCursor = idx
+				m.payCyclePaneOpen = true
+				m.payCyclePaneFocus = payCyclePaneFocusDetails
+				return m, nil
+			}
+		case "I":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeTable &&
+				m.transactionsCursor >= 0 &&
+				m.transactionsCursor < len(m.transactionsRows) {
+				merchant := strings.TrimSpace(m.transactionsRows[m.transactionsCursor].merchant)
+				if merchant == "" {
+					return m, nil
 				}
+				return m, m.toggleIgnoredMerchantCmd(merchant)
+			}
+		case "?":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() {
+				m.showHelpOverlay = true
+				m.helpOverlayViewKeys = true
+				return m, nil
 			}
 		case "1":
 			if m.screen == screenTransactions &&
 				strings.TrimSpace(m.cmd.Value()) == "" &&
 				!m.shouldShowCommandSuggestions() {
+				// Each view loads only the aggregates it shows.
 				m.transactionsViewMode = transactionsViewModeTable
-				return m, nil
+				return m, tea.Batch(m.saveTransactionsViewModeCmd(), m.loadTransactionsPreviewCmd())
 			}
 		case "2":
 			if m.screen == screenTransactions &&
@@ -1929,7 +3392,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.transactionsChartPaneFocus = transactionsChartFocusMain
 				m.transactionsChartPaneMode = transactionsChartPaneModeList
 				m.transactionsChartPaneDetailTxID = ""
-				return m, nil
+				return m, tea.Batch(m.saveTransactionsViewModeCmd(), m.loadTransactionsPreviewCmd())
 			}
 		case "3":
 			if m.screen == screenTransactions &&
@@ -1952,9 +3415,42 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.transactionsChartPaneDetailTxID = ""
 				m.transactionsSearchActive = false
 				m.transactionsSearchInput.Blur()
-				return m, m.loadTransactionsPreviewCmd()
+				return m, tea.Batch(m.saveTransactionsViewModeCmd(), m.loadTransactionsPreviewCmd())
+			}
+		case "4":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() {
+				m.transactionsViewMode = transactionsViewModeWeekly
+				m.transactionsWeeklyScroll = 0
+				m.transactionsPaneOpen = false
+				m.transactionsChartPaneOpen = false
+				return m, tea.Batch(m.saveTransactionsViewModeCmd(), m.loadTransactionsPreviewCmd())
+			}
+		case "5":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() {
+				m.transactionsViewMode = transactionsViewModeHeatmap
+				m.transactionsPaneOpen = false
+				m.transactionsChartPaneOpen = false
+				return m, tea.Batch(m.saveTransactionsViewModeCmd(), m.loadTransactionsPreviewCmd())
 			}
 		case "g":
+			if m.screen == screenTransactions &&
+				strings.TrimSpace(m.cmd.Value()) == "" &&
+				!m.shouldShowCommandSuggestions() &&
+				m.transactionsViewMode == transactionsViewModeTimeSeries {
+				m.transactionsTimeSeriesGrouping = (m.transactionsTimeSeriesGrouping + 1) % timeSeriesGroupCount
+				m.transactionsTimeSeries = m.groupTransactionsTimeSeries(m.transactionsTimeSeriesRaw)
+				m.transactionsTimeSeriesZoomStart = 0
+				m.transactionsTimeSeriesZoomWindow = 0
+				m.transactionsTimeSeriesSelection = len(m.transactionsTimeSeries) - 1
+				m.normalizeTransactionsTimeSeriesSelection()
+				m.normalizeTransactionsTimeSeriesZoom()
+				m.ensureTransactionsTimeSeriesSelectionVisible()
+				return m, m.saveTransactionsTimeSeriesGroupCmd()
+			}
 			if m.screen == screenPayCycleBurndown &&
 				strings.TrimSpace(m.cmd.Value()) == "" &&
 				!m.shouldShowCommandSuggestions() {
@@ -1965,11 +3461,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if !ok {
 					return m, nil
 				}
-				m.payCyclePromptMode = payCyclePromptGoal
-				m.payCyclePromptErr = ""
-				m.payCycleInput.Placeholder = "0.00"
-				m.payCycleInput.SetValue(strings.TrimSpace(account.goalBalance))
-				m.payCycleInput.Focus()
+				if m.payCycleMonthly {
+					m.openMonthlyBudgetPrompt()
+					return m, nil
+				}
+				m.openPayCycleGoalPrompt(account)
 				return m, nil
 			}
 		case "enter":
@@ -1979,6 +3475,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.payCyclePromptMode != payCyclePromptNone {
 					return m, nil
 				}
+				if len(m.payCycleTransactions) == 0 && m.payCycleMonthly {
+					return m, nil
+				}
 				if len(m.payCycleTransactions) == 0 {
 					m.payCycleConfigReturn = true
 					m.payCyclePromptGoalAfterConfig = false
@@ -2007,6 +3506,12 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, nil
 					}
 					category := m.transactionsCategorySpend[m.transactionsChartCursor].category
+					if m.transactionsChartShowsParents() {
+						m.transactionsChartParentDrill = category
+						m.transactionsChartCursor = 0
+						m.transactionsChartOffset = 0
+						return m, m.loadTransactionsPreviewCmd()
+					}
 					return m, m.loadCategoryTransactionsCmd(category, m.transactionsChartPaneSortIdx)
 				}
 				if m.transactionsViewMode == transactionsViewModeTimeSeries {
@@ -2024,7 +3529,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 				m.transactionsPaneOpen = !m.transactionsPaneOpen
-				return m, nil
+				return m, m.loadCategoryTrendCmd()
 			}
 			if m.screen == screenTransactionsFilters &&
 				strings.TrimSpace(m.cmd.Value()) == "" &&
@@ -2035,6 +3540,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.transactionsFilterMode = transactionsFilterModeQuick
 					m.transactionsPage = 0
 					m.transactionsDateErr = ""
+					if m.transactionsQuickIdx == transactionsLastPayCycleIdx {
+						if _, _, err := lastPayCycleWindow(m.transactionsPayCycle); err != nil {
+							m.transactionsDateErr = "no pay cycle configured; showing the last month"
+						}
+					}
 					return m, tea.Batch(m.saveTransactionsFiltersCmd(), m.loadTransactionsPreviewCmd())
 				case transactionsFocusFromDate, transactionsFocusToDate:
 					if err := validateTransactionsDateRange(m.transactionsFromDate, m.transactionsToDate); err != nil {
@@ -2047,6 +3557,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, tea.Batch(m.saveTransactionsFiltersCmd(), m.loadTransactionsPreviewCmd())
 				case transactionsFocusIncludeInternal:
 					return m, tea.Batch(m.saveTransactionsFiltersCmd(), m.loadTransactionsPreviewCmd())
+				case transactionsFocusPageSize:
+					m.transactionsPage = 0
+					m.transactionsCursor = 0
+					m.transactionsOffset = 0
+					return m, tea.Batch(m.saveTransactionsPageSizeCmd(), m.loadTransactionsPreviewCmd())
 				}
 			}
 			if m.screen == screenAccounts &&
@@ -2077,9 +3592,41 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					m.accountsGoalInput.Focus()
 					return m, nil
 				}
+				if selectedAction == "rename account" {
+					m.accountsRenameEditing = true
+					m.accountsRenameErr = ""
+					m.accountsRenameInput.SetValue(m.accountsRows[m.accountsCursor].displayName)
+					m.accountsRenameInput.CursorEnd()
+					m.accountsRenameInput.Focus()
+					return m, nil
+				}
+				if selectedAction == "cycle colour" {
+					row := m.accountsRows[m.accountsCursor]
+					return m, m.saveAccountColorCmd(row.id, nextAccountColor(row.color))
+				}
+				if selectedAction == "pin to home" || selectedAction == "unpin from home" {
+					return m, m.togglePinnedAccountCmd(m.accountsRows[m.accountsCursor].id)
+				}
+				if selectedAction == "clear name override" {
+					return m, m.saveAccountNameCmd(m.accountsRows[m.accountsCursor].id, "")
+				}
 				if selectedAction == "burndown chart" {
 					return m.enterPayCycleBurndownView()
 				}
+				if selectedAction == "refresh now" {
+					if m.offline {
+						return m.withCommandFeedback("offline: /offline to sync again")
+					}
+					m.accountsLoading = true
+					next, cmd := m.withCommandFeedback("refreshing every account...")
+					return next, tea.Batch(cmd, m.refreshEveryAccountCmd())
+				}
+				if selectedAction == "skip auto-sync" || selectedAction == "resume auto-sync" {
+					return m, m.saveAccountSkipAutoSyncCmd(
+						m.accountsRows[m.accountsCursor].id,
+						selectedAction == "skip auto-sync",
+					)
+				}
 				return m.withCommandFeedback(fmt.Sprintf("%s: coming soon", selectedAction))
 			}
 			if m.screen == screenHome &&
@@ -2150,7 +3697,7 @@ func (m model) View() string {
 	if m.screen == screenAccounts {
 		content := contentStyle.Render(m.renderAccountsScreen(layoutWidth))
 		if m.showHelpOverlay {
-			helpOverlay := renderHelpOverlay(layoutWidth)
+			helpOverlay := m.renderHelpOverlay(layoutWidth)
 			layoutHeight := max(1, m.height-frame.GetVerticalFrameSize()-contentStyle.GetVerticalFrameSize())
 			centered := lipgloss.Place(layoutWidth, layoutHeight, lipgloss.Center, lipgloss.Center, helpOverlay)
 			return frame.Render(contentStyle.Render(centered))
@@ -2167,7 +3714,7 @@ func (m model) View() string {
 		content := contentStyle.Render(m.renderConfigScreen(layoutWidth))
 		layoutHeight := max(1, m.height-frame.GetVerticalFrameSize()-contentStyle.GetVerticalFrameSize())
 		if m.showHelpOverlay {
-			helpOverlay := renderHelpOverlay(layoutWidth)
+			helpOverlay := m.renderHelpOverlay(layoutWidth)
 			centered := lipgloss.Place(layoutWidth, layoutHeight, lipgloss.Center, lipgloss.Center, helpOverlay)
 			return frame.Render(contentStyle.Render(centered))
 		}
@@ -2180,8 +3727,20 @@ func (m model) View() string {
 	}
 	if m.screen == screenTransactions {
 		content := contentStyle.Render(m.renderTransactionsScreen(layoutWidth))
+		if m.transactionsRawOpen {
+			rawOverlay := m.renderTransactionsRawOverlay(layoutWidth)
+			layoutHeight := max(1, m.height-frame.GetVerticalFrameSize()-contentStyle.GetVerticalFrameSize())
+			centered := lipgloss.Place(layoutWidth, layoutHeight, lipgloss.Center, lipgloss.Center, rawOverlay)
+			return frame.Render(contentStyle.Render(centered))
+		}
+		if m.transactionsHourlyOpen {
+			hourlyOverlay := m.renderTransactionsHourlyOverlay(layoutWidth)
+			layoutHeight := max(1, m.height-frame.GetVerticalFrameSize()-contentStyle.GetVerticalFrameSize())
+			centered := lipgloss.Place(layoutWidth, layoutHeight, lipgloss.Center, lipgloss.Center, hourlyOverlay)
+			return frame.Render(contentStyle.Render(centered))
+		}
 		if m.showHelpOverlay {
-			helpOverlay := renderHelpOverlay(layoutWidth)
+			helpOverlay := m.renderHelpOverlay(layoutWidth)
 			layoutHeight := max(1, m.height-frame.GetVerticalFrameSize()-contentStyle.GetVerticalFrameSize())
 			centered := lipgloss.Place(layoutWidth, layoutHeight, lipgloss.Center, lipgloss.Center, helpOverlay)
 			return frame.Render(contentStyle.Render(centered))
@@ -2197,7 +3756,7 @@ func (m model) View() string {
 	if m.screen == screenTransactionsFilters {
 		content := contentStyle.Render(m.renderTransactionsFiltersScreen(layoutWidth))
 		if m.showHelpOverlay {
-			helpOverlay := renderHelpOverlay(layoutWidth)
+			helpOverlay := m.renderHelpOverlay(layoutWidth)
 			layoutHeight := max(1, m.height-frame.GetVerticalFrameSize()-contentStyle.GetVerticalFrameSize())
 			centered := lipgloss.Place(layoutWidth, layoutHeight, lipgloss.Center, lipgloss.Center, helpOverlay)
 			return frame.Render(contentStyle.Render(centered))
@@ -2213,7 +3772,7 @@ func (m model) View() string {
 	if m.screen == screenPayCycleBurndown {
 		content := contentStyle.Render(m.renderPayCycleBurndownScreen(layoutWidth))
 		if m.showHelpOverlay {
-			helpOverlay := renderHelpOverlay(layoutWidth)
+			helpOverlay := m.renderHelpOverlay(layoutWidth)
 			layoutHeight := max(1, m.height-frame.GetVerticalFrameSize()-contentStyle.GetVerticalFrameSize())
 			centered := lipgloss.Place(layoutWidth, layoutHeight, lipgloss.Center, lipgloss.Center, helpOverlay)
 			return frame.Render(contentStyle.Render(centered))
@@ -2234,8 +3793,10 @@ func (m model) View() string {
 	header = lipgloss.NewStyle().PaddingBottom(1).Render(header)
 
 	statusLabel := lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render("status: ")
-	statusValue := lipgloss.NewStyle().Foreground(lipgloss.Color("#F15B5B")).Bold(true).Render("not connected")
-	if m.status == stateConnected {
+	statusValue := lipgloss.NewStyle().Foreground(lipgloss.Color("#F15B5B")).Bold(true).Render(m.statusDetail)
+	if m.offline {
+		statusValue = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD54A")).Bold(true).Render("offline")
+	} else if m.status == stateConnected {
 		statusValue = lipgloss.NewStyle().Foreground(lipgloss.Color("#5CCB76")).Bold(true).Render("connected")
 	}
 	statusLine := statusLabel + statusValue
@@ -2258,13 +3819,8 @@ func (m model) View() string {
 		Padding(0, 1).
 		Width(panelWidth).
 		Height(panelHeight)
-	pinTitle := pinIconOrFallback()
-	leftSelect := renderSelectButton(m.clicked == 0)
-	rightSelect := renderSelectButton(m.clicked == 1)
-	leftHeader := pinTitle + " " + leftSelect
-	rightHeader := pinTitle + " " + rightSelect
-	pinnedOne := pinnedStyle.Render(leftHeader)
-	pinnedTwo := pinnedStyle.Render(rightHeader)
+	pinnedOne := pinnedStyle.Render(renderHomeSyncPanel(m.homeSyncStates, m.homeSyncErr, panelWidth, time.Now()))
+	pinnedTwo := pinnedStyle.Render(renderPinnedAccountsPanel(m.homePinnedRows, panelWidth))
 	rightPanels := lipgloss.JoinHorizontal(lipgloss.Top, pinnedOne, "  ", pinnedTwo)
 	canvasWidth := layoutWidth
 	mainPanelsRaw := lipgloss.JoinHorizontal(lipgloss.Top, listBox, "  ", rightPanels)
@@ -2359,7 +3915,7 @@ func (m model) View() string {
 	content := contentStyle.Render(bodyText)
 
 	if m.showHelpOverlay {
-		helpOverlay := renderHelpOverlay(layoutWidth)
+		helpOverlay := m.renderHelpOverlay(layoutWidth)
 		layoutHeight := max(1, m.height-frame.GetVerticalFrameSize()-contentStyle.GetVerticalFrameSize())
 		centered := lipgloss.Place(layoutWidth, layoutHeight, lipgloss.Center, lipgloss.Center, helpOverlay)
 		return frame.Render(contentStyle.Render(centered))
@@ -2375,11 +3931,29 @@ func (m model) View() string {
 }
 
 func (m model) runSlashCommand(input string) (tea.Model, tea.Cmd) {
+	if fields := strings.Fields(input); len(fields) > 0 && (fields[0] == "/save-filter" || fields[0] == "/load-filter") {
+		return m.runSavedFilterCommand(fields)
+	}
+	if fields := strings.Fields(input); len(fields) > 1 && fields[0] == "/accounts" && fields[1] == "sort" {
+		return m.runAccountsSortCommand(fields[2:])
+	}
+	if fields := strings.Fields(input); len(fields) > 0 && fields[0] == "/share-summary" {
+		if len(fields) > 2 {
+			return m.withCommandFeedback("usage: /share-summary [" + strings.Join(shareDetailOptions(), "|") + "]")
+		}
+		detail := ""
+		if len(fields) == 2 {
+			detail = fields[1]
+		}
+		next, cmd := m.withCommandFeedback("copying summary...")
+		return next, tea.Batch(cmd, m.copyShareSummaryCmd(detail))
+	}
 	switch input {
 	case "":
 		return m, nil
 	case "/help":
 		m.showHelpOverlay = true
+		m.helpOverlayViewKeys = false
 		m.commandText = ""
 		m.cmd.SetValue("")
 		m.clearCommandSuggestions()
@@ -2392,12 +3966,20 @@ func (m model) runSlashCommand(input string) (tea.Model, tea.Cmd) {
 		return m.enterTransactionsView()
 	case "/pay-cycle-burndown", "/burndown":
 		return m.enterPayCycleBurndownView()
+	case "/export-keys":
+		next, cmd := m.withCommandFeedback("writing keybindings...")
+		return next, tea.Batch(cmd, exportKeybindingsCmd)
 	case "/ping":
 		next, cmd := m.withCommandFeedback("checking connection...")
 		return next, tea.Batch(cmd, checkConnectionCmd)
+	case "/offline":
+		return m.toggleOffline()
 	case "/db-wipe", "/db wipe":
 		next, cmd := m.withCommandFeedback("wiping local database...")
 		return next, tea.Batch(cmd, wipeDBCmd)
+	case "/db-optimize", "/db optimize":
+		next, cmd := m.withCommandFeedback("optimizing local database...")
+		return next, tea.Batch(cmd, next.(model).optimizeDBCmd())
 	case "/disconnect":
 		m.authDialog = authDialogDisconnect
 		m.pat.SetValue("")
@@ -2420,10 +4002,38 @@ func (m model) runSlashCommand(input string) (tea.Model, tea.Cmd) {
 		m.clearCommandSuggestions()
 		return m, nil
 	default:
+		if suggestion, ok := closestCommand(input); ok {
+			return m.withCommandFeedback(fmt.Sprintf("Unknown command: %s. Did you mean %s?", input, suggestion))
+		}
 		return m.withCommandFeedback(fmt.Sprintf("Unknown command: %s", input))
 	}
 }
 
+// patInvalidFeedback is shown when the Up API rejects the stored PAT.
+const patInvalidFeedback = "PAT invalid — run /connect"
+
+// withPATInvalid marks the connection as failed because of the token rather
+// than the network, and says how to fix it.
+func (m model) withPATInvalid() (tea.Model, tea.Cmd) {
+	m.status = stateDisconnected
+	m.statusDetail = "PAT invalid"
+	return m.withCommandFeedback(patInvalidFeedback)
+}
+
+// syncStateError turns a sync error read back from sync_state into an error.
+// Only the text survives the round trip, so the Up API sentinels are
+// recognised by their messages and wrapped again for errors.Is.
+func syncStateError(collection, msg string) error {
+	switch {
+	case strings.Contains(msg, upapi.ErrUnauthorized.Error()):
+		return fmt.Errorf("%s sync failed: %w", collection, upapi.ErrUnauthorized)
+	case strings.Contains(msg, upapi.ErrRateLimited.Error()):
+		return fmt.Errorf("%s sync paused: %w, try again shortly", collection, upapi.ErrRateLimited)
+	default:
+		return errors.New(msg)
+	}
+}
+
 func (m model) withCommandFeedback(text string) (tea.Model, tea.Cmd) {
 	m.commandText = text
 	m.commandTextID++
@@ -2447,6 +4057,13 @@ func (m model) enterAccountsView() (tea.Model, tea.Cmd) {
 	m.accountsGoalErr = ""
 	m.accountsGoalInput.SetValue("")
 	m.accountsGoalInput.Blur()
+	m.accountsRenameEditing = false
+	m.accountsRenameErr = ""
+	m.accountsRenameInput.SetValue("")
+	m.accountsRenameInput.Blur()
+	m.accountsSearchActive = false
+	m.accountsSearchInput.SetValue("")
+	m.accountsSearchInput.Blur()
 	m.accountsSession++
 	return m, tea.Batch(
 		m.loadAccountsPreviewCmd(),
@@ -2468,6 +4085,23 @@ func (m model) enterTransactionsView() (tea.Model, tea.Cmd) {
 	m.transactionsSearchInput.Blur()
 	m.transactionsSearchInput.SetValue("")
 	m.transactionsSearchApplied = ""
+	m.transactionsSearchRecall = 0
+	m.transactionsAmountSign = 0
+	m.transactionsTagActive = false
+	m.transactionsTagErr = ""
+	m.transactionsTagInput.SetValue("")
+	m.transactionsTagInput.Blur()
+	m.closeTransactionsNotePrompt()
+	m.closeMerchantAliasPrompt()
+	m.transactionsRawOpen = false
+	m.transactionsRawTxID = ""
+	m.transactionsRawLines = nil
+	m.transactionsRawOffset = 0
+	m.transactionsRawErr = ""
+	m.transactionsHourlyOpen = false
+	m.transactionsHourlyLoading = false
+	m.transactionsHourly = hourlySpend{}
+	m.transactionsHourlyErr = ""
 	m.transactionsChartCursor = 0
 	m.transactionsChartOffset = 0
 	m.transactionsChartPaneOpen = false
@@ -2479,7 +4113,17 @@ func (m model) enterTransactionsView() (tea.Model, tea.Cmd) {
 	m.transactionsChartPaneFocus = transactionsChartFocusMain
 	m.transactionsChartPaneMode = transactionsChartPaneModeList
 	m.transactionsChartPaneDetailTxID = ""
+	m.transactionsBudgetActive = false
+	m.transactionsBudgetErr = ""
+	m.transactionsBudgetInput.SetValue("")
+	m.transactionsBudgetInput.Blur()
+	m.transactionsJumpActive = false
+	m.transactionsJumpErr = ""
+	m.transactionsJumpInput.SetValue("")
+	m.transactionsJumpInput.Blur()
 	m.transactionsTimeSeriesCategory = ""
+	m.transactionsTimeSeriesRoundUps = false
+	m.transactionsRoundUpsMonthly = false
 	m.transactionsTimeSeriesZoomStart = 0
 	m.transactionsTimeSeriesZoomWindow = 0
 	m.transactionsTimeSeriesSelection = 0
@@ -2488,9 +4132,9 @@ func (m model) enterTransactionsView() (tea.Model, tea.Cmd) {
 	m.transactionsCursor = 0
 	m.transactionsOffset = 0
 	m.transactionsPage = 0
-	m.transactionsPageSize = max(1, m.transactionsVisibleRows())
+	m.transactionsPageSize = clampTransactionsPageSize(m.transactionsPageSize)
 	if m.transactionsFromDate == "" && m.transactionsToDate == "" {
-		m.transactionsQuickIdx = 2 // last 3 months
+		m.transactionsQuickIdx = transactionsDefaultQuickIdx
 		m.applyTransactionsQuickRange(m.transactionsQuickIdx)
 		m.transactionsFilterMode = transactionsFilterModeQuick
 	} else {
@@ -2501,6 +4145,8 @@ func (m model) enterTransactionsView() (tea.Model, tea.Cmd) {
 	next, syncCmd := m.maybeStartTransactionsSyncCmd(false)
 	return next, tea.Batch(
 		next.loadTransactionsFiltersCmd(),
+		next.loadTransactionsPageSizeCmd(),
+		next.loadCategoryBudgetsCmd(),
 		syncCmd,
 		next.transactionsReloadTickCmd(),
 		next.transactionsClockTickCmd(),
@@ -2767,6 +4413,22 @@ func (m *model) moveTransactionsTimeSeriesSelection(delta int) bool {
 	return true
 }
 
+// toggleTransactionsAmountSign switches to credits-only (sign > 0) or
+// debits-only (sign < 0), or back to both when that restriction is already on.
+func (m *model) toggleTransactionsAmountSign(sign int) tea.Cmd {
+	if m.transactionsAmountSign == sign {
+		m.transactionsAmountSign = 0
+	} else {
+		m.transactionsAmountSign = sign
+	}
+	m.transactionsPage = 0
+	m.transactionsCursor = 0
+	m.transactionsOffset = 0
+	m.transactionsChartCursor = 0
+	m.transactionsChartOffset = 0
+	return m.loadTransactionsPreviewCmd()
+}
+
 func (m *model) zoomTransactionsTimeSeries(zoomIn bool) bool {
 	total := len(m.transactionsTimeSeries)
 	if total <= 1 {
@@ -2819,6 +4481,22 @@ func (m *model) zoomTransactionsTimeSeries(zoomIn bool) bool {
 	return true
 }
 
+// resetTransactionsTimeSeriesZoom zooms back out to the whole range, keeping
+// the selected node.
+func (m *model) resetTransactionsTimeSeriesZoom() bool {
+	total := len(m.transactionsTimeSeries)
+	if total <= 1 {
+		return false
+	}
+	m.normalizeTransactionsTimeSeriesSelection()
+	if m.transactionsTimeSeriesZoomStart == 0 && m.transactionsTimeSeriesZoomWindow == total {
+		return false
+	}
+	m.transactionsTimeSeriesZoomStart = 0
+	m.transactionsTimeSeriesZoomWindow = total
+	return true
+}
+
 func (m *model) panTransactionsTimeSeries(direction int) bool {
 	if direction == 0 {
 		return false
@@ -2876,6 +4554,12 @@ func (m model) transactionsChartVisibleRows() int {
 	return 15
 }
 
+// transactionsChartShowsParents reports whether the chart bars are parent
+// categories, i.e. parent grouping is on and no parent is drilled into.
+func (m model) transactionsChartShowsParents() bool {
+	return m.transactionsChartByParent && strings.TrimSpace(m.transactionsChartParentDrill) == ""
+}
+
 func (m model) transactionsChartPaneVisibleRowsForInnerHeight(innerHeight int) int {
 	if innerHeight < 1 {
 		innerHeight = 1
@@ -2896,17 +4580,112 @@ func (m model) transactionsChartPaneVisibleRows() int {
 }
 
 func (m model) maybeStartTransactionsSyncCmd(force bool) (model, tea.Cmd) {
-	if m.transactionsSyncing {
+	if m.offline || m.transactionsSyncing {
 		return m, nil
 	}
-	if !force && m.transactionsLastSync != nil && time.Since(m.transactionsLastSync.UTC()) < 15*time.Second {
+	if m.transactionsLastSync != nil && time.Since(m.transactionsLastSync.UTC()) < m.minSyncInterval() {
 		return m, nil
 	}
 	m.transactionsSyncing = true
+	m.transactionsSyncFetched = 0
 	session := m.transactionsSession
 	return m, m.syncTransactionsCmd(session, force)
 }
 
+func (m model) minSyncInterval() time.Duration {
+	if m.syncMinInterval <= 0 {
+		return syncer.DefaultMinSyncInterval
+	}
+	return m.syncMinInterval
+}
+
+// syncRun is one running sync. err is written before done is closed, so
+// callers that joined the run read it after <-done.
+type syncRun struct {
+	params  string
+	done    chan struct{}
+	err     error
+	joiners int
+}
+
+// syncInFlight tracks running syncs per collection so that rapid view
+// switching joins the running sync instead of stacking another one.
+var syncInFlight = struct {
+	mu   sync.Mutex
+	runs map[string]*syncRun
+}{runs: map[string]*syncRun{}}
+
+// runExclusiveSync runs at most one sync per collection. A caller whose
+// params match the running sync waits for it and returns its result; any
+// other caller waits for it to finish and then runs fn, so a forced or
+// windowed sync never reports the result of a different kind of sync.
+func runExclusiveSync(collection, params string, fn func() error) error {
+	syncInFlight.mu.Lock()
+	for {
+		running, ok := syncInFlight.runs[collection]
+		if !ok {
+			break
+		}
+		if running.params == params {
+			running.joiners++
+			syncInFlight.mu.Unlock()
+			<-running.done
+			return running.err
+		}
+		syncInFlight.mu.Unlock()
+		<-running.done
+		syncInFlight.mu.Lock()
+	}
+	run := &syncRun{params: params, done: make(chan struct{})}
+	syncInFlight.runs[collection] = run
+	syncInFlight.mu.Unlock()
+
+	defer func() {
+		syncInFlight.mu.Lock()
+		delete(syncInFlight.runs, collection)
+		syncInFlight.mu.Unlock()
+		close(run.done)
+	}()
+	run.err = fn()
+	return run.err
+}
+
+// SyncSkippedError reports a sync that did not call the API because the
+// last attempt was within the configured minimum sync interval.
+type SyncSkippedError struct {
+	Collection  string
+	NextAllowed time.Time
+}
+
+func (e *SyncSkippedError) Error() string {
+	return fmt.Sprintf("%s sync skipped: the minimum sync interval has not passed; next sync allowed at %s",
+		e.Collection, e.NextAllowed.In(time.Local).Format("2006-01-02 15:04:05"))
+}
+
+// ignoreSyncSkipped drops a SyncSkippedError. The screens treat a skipped
+// sync as up to date and keep showing the cached rows.
+func ignoreSyncSkipped(err error) error {
+	var skipped *SyncSkippedError
+	if errors.As(err, &skipped) {
+		return nil
+	}
+	return err
+}
+
+// syncIntervalGuard reports whether a sync should be skipped because the
+// last attempt was less than minInterval ago, even when forced. The error is
+// a *SyncSkippedError, or the last attempt's error when nothing is cached to
+// fall back on.
+func syncIntervalGuard(collection string, prevAttempt *time.Time, prevErr string, hasCached bool, minInterval time.Duration, now time.Time) (bool, error) {
+	if prevAttempt == nil || now.Sub(*prevAttempt) >= minInterval {
+		return false, nil
+	}
+	if prevErr != "" && !hasCached {
+		return true, errors.New(prevErr)
+	}
+	return true, &SyncSkippedError{Collection: collection, NextAllowed: prevAttempt.Add(minInterval)}
+}
+
 func (m model) transactionsReloadTickCmd() tea.Cmd {
 	session := m.transactionsSession
 	return tea.Tick(350*time.Millisecond, func(time.Time) tea.Msg {
@@ -2939,6 +4718,13 @@ func (m model) accountsClockTickCmd() tea.Cmd {
 	})
 }
 
+// accountsAutoRefreshDue reports whether an auto-refresh tick should sync.
+// The tick outlives the accounts screen so the home screen's pinned accounts
+// stay current; elsewhere it just waits for the next tick.
+func (m model) accountsAutoRefreshDue() bool {
+	return m.screen == screenAccounts || (m.screen == screenHome && len(m.homePinnedAccounts) > 0)
+}
+
 func (m model) accountsAutoRefreshTickCmd() tea.Cmd {
 	session := m.accountsSession
 	return tea.Tick(2*time.Minute, func(time.Time) tea.Msg {
@@ -2962,6 +4748,12 @@ func (m model) accountsActionItems() []string {
 	return []string{
 		"enter goal balance",
 		"burndown chart",
+		"refresh now",
+		"skip auto-sync",
+		"rename account",
+		"clear name override",
+		"cycle colour",
+		"pin to home",
 	}
 }
 
@@ -2970,10 +4762,22 @@ func (m model) currentAccountActionItems() []string {
 	if len(m.accountsRows) == 0 || m.accountsCursor < 0 || m.accountsCursor >= len(m.accountsRows) {
 		return items
 	}
-	if m.accountsRows[m.accountsCursor].accountType == "TRANSACTIONAL" {
-		return []string{"burndown chart"}
+	row := m.accountsRows[m.accountsCursor]
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		switch {
+		case item == "enter goal balance" && row.accountType == "TRANSACTIONAL":
+			continue
+		case item == "clear name override" && !row.nameOverridden:
+			continue
+		case item == "skip auto-sync" && row.skipAutoSync:
+			item = "resume auto-sync"
+		case item == "pin to home" && slices.Contains(m.homePinnedAccounts, row.id):
+			item = "unpin from home"
+		}
+		out = append(out, item)
 	}
-	return items
+	return out
 }
 
 func (m *model) clampAccountsAction() {
@@ -3003,6 +4807,18 @@ func (m model) saveAccountGoalCmd(accountID, goalBalance string) tea.Cmd {
 	}
 }
 
+func (m model) saveAccountSkipAutoSyncCmd(accountID string, skip bool) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveAccountSkipAutoSyncMsg{err: fmt.Errorf("database is not initialized")}
+		}
+		if err := saveAccountSkipAutoSync(context.Background(), m.db, accountID, skip); err != nil {
+			return saveAccountSkipAutoSyncMsg{err: err}
+		}
+		return saveAccountSkipAutoSyncMsg{skip: skip}
+	}
+}
+
 func normalizeGoalInput(raw string) string {
 	var b strings.Builder
 	hasDot := false
@@ -3049,12 +4865,6 @@ func savePATCmd(pat string) tea.Cmd {
 	}
 }
 
-func clearButtonFlashCmd(id int) tea.Cmd {
-	return tea.Tick(450*time.Millisecond, func(time.Time) tea.Msg {
-		return clearButtonFlashMsg{id: id}
-	})
-}
-
 func deletePATCmd() tea.Msg {
 	return deletePATMsg{err: auth.RemovePAT()}
 }
@@ -3074,6 +4884,16 @@ func wipeDBCmd() tea.Msg {
 	return wipeDBMsg{path: cfg.Path}
 }
 
+func (m model) optimizeDBCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return optimizeDBMsg{err: errors.New("database is not initialized")}
+		}
+		result, err := storage.Optimize(context.Background(), m.db)
+		return optimizeDBMsg{result: result, err: err}
+	}
+}
+
 func (m model) transactionsPrewarmCheckCmd() tea.Cmd {
 	return func() tea.Msg {
 		if m.db == nil {
@@ -3093,20 +4913,6 @@ func validateTransactionsSearchSyntax(query string) error {
 	return appendTransactionsSearchClauses(strings.TrimSpace(query), &where, &args)
 }
 
-func max(a, b int) int {
-	if a > b {
-		return a
-	}
-	return b
-}
-
-func min(a, b int) int {
-	if a < b {
-		return a
-	}
-	return b
-}
-
 func canvasSafeWidth(width int) int {
 	return max(20, width-10)
 }
@@ -3187,13 +4993,70 @@ func commandCatalog() []commandSpec {
 		{name: "/help", description: "show command help overlay"},
 		{name: "/config", description: "open app config"},
 		{name: "/accounts", description: "select the accounts view"},
+		{name: "/accounts sort", description: "change account order (manual|balance|name|type)"},
 		{name: "/transactions", description: "select the transactions view"},
 		{name: "/pay-cycle-burndown", description: "open pay cycle burndown view"},
 		{name: "/ping", description: "check Up API connectivity"},
+		{name: "/offline", description: "toggle offline mode (cached data only, no syncing)"},
 		{name: "/disconnect", description: "remove saved PAT from keychain"},
 		{name: "/db-wipe", description: "wipe and reinitialize the local database"},
+		{name: "/db-optimize", description: "compact the local database file"},
 		{name: "/connect", description: "open the PAT connect prompt"},
+		{name: "/export-keys", description: "save the key reference as markdown"},
+		{name: "/share-summary", description: "copy a balances summary (totals|goals|accounts)"},
+		{name: "/save-filter", description: "save the transactions search and dates under a name"},
+		{name: "/load-filter", description: "apply a saved transactions filter"},
+	}
+}
+
+// closestCommand suggests a catalog command for a mistyped one: the shortest
+// command the input starts, else the nearest by edit distance when it is
+// close enough to be a typo.
+func closestCommand(input string) (string, bool) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if input == "" || input == "/" {
+		return "", false
+	}
+	best := ""
+	for _, spec := range commandCatalog() {
+		if strings.HasPrefix(spec.name, input) && (best == "" || len(spec.name) < len(best)) {
+			best = spec.name
+		}
+	}
+	if best != "" {
+		return best, true
 	}
+	bestDist := 0
+	for _, spec := range commandCatalog() {
+		if d := levenshtein(input, spec.name); best == "" || d < bestDist {
+			best, bestDist = spec.name, d
+		}
+	}
+	if bestDist > max(2, len([]rune(input))/3) {
+		return "", false
+	}
+	return best, true
+}
+
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur := make([]int, len(br)+1)
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(min(prev[j]+1, cur[j-1]+1), prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(br)]
 }
 
 func (m *model) refreshCommandSuggestions() {
@@ -3203,6 +5066,17 @@ func (m *model) refreshCommandSuggestions() {
 		return
 	}
 
+	if matches, ok := savedFilterSuggestions(m.cmd.Value(), m.transactionsSavedFilters); ok {
+		if len(matches) == 0 {
+			m.clearCommandSuggestions()
+			return
+		}
+		m.commandSuggestions = matches
+		m.commandSuggestionIndex = min(max(0, m.commandSuggestionIndex), len(matches)-1)
+		m.adjustSuggestionWindow(2)
+		return
+	}
+
 	prefix := strings.ToLower(input)
 	all := commandCatalog()
 	matches := make([]commandSpec, 0, len(all))
@@ -3282,28 +5156,51 @@ func renderCommandSuggestionRows(innerWidth int, matches []commandSpec, selected
 	return strings.Join(rows, "\n")
 }
 
-func renderHelpOverlay(maxWidth int) string {
-	title := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#5FA8FF")).
-		Bold(true).
-		Render("Command Help")
-
-	catalog := commandCatalog()
-	commands := make([]string, 0, len(catalog))
-	for _, cmd := range catalog {
-		commands = append(commands, fmt.Sprintf("%-13s %s", cmd.name, cmd.description))
-	}
-	searchHelp := []string{
-		"",
-		"transactions search:",
+func transactionsSearchHelpExamples() []string {
+	return []string{
 		"merchant: WOO + amount: >60 + category: groceries",
 		"type: +ve or type: -ve",
+		"date: >=2024-01-01 + date: <2024-04-01",
+		"type: -ve + (merchant: WOOL | merchant: COLES)",
+		"tag: holiday + exclude-tag: reimbursed",
+		"status: held + type: -ve",
+		"currency: USD",
+		"has: attachment + type: -ve",
+		"account: spending + merchant: WOOL",
+	}
+}
+
+// renderHelpOverlay lists the commands, or with helpOverlayViewKeys set, every
+// key of the current transactions view.
+func (m model) renderHelpOverlay(maxWidth int) string {
+	titleText := "Command Help"
+	footerText := "Esc to close"
+	var body string
+	if m.helpOverlayViewKeys && m.screen == screenTransactions {
+		mode := "table"
+		if m.transactionsViewMode >= 0 && m.transactionsViewMode < len(transactionsViewModeNames) {
+			mode = strings.ReplaceAll(transactionsViewModeNames[m.transactionsViewMode], "_", " ")
+		}
+		titleText = "Transactions " + mode + " keys"
+		footerText = "?/Esc to close  /help for commands"
+		body = strings.Join(transactionsKeyBindings(m.transactionsViewMode), "\n")
+	} else {
+		catalog := commandCatalog()
+		commands := make([]string, 0, len(catalog))
+		for _, cmd := range catalog {
+			commands = append(commands, fmt.Sprintf("%-13s %s", cmd.name, cmd.description))
+		}
+		searchHelp := append([]string{"", "transactions search:"}, transactionsSearchHelpExamples()...)
+		body = strings.Join(append(commands, searchHelp...), "\n")
 	}
-	body := strings.Join(append(commands, searchHelp...), "\n")
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#5FA8FF")).
+		Bold(true).
+		Render(titleText)
 	footer := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#FFD54A")).
 		Bold(true).
-		Render("Esc to close")
+		Render(footerText)
 
 	content := strings.Join([]string{title, "", body, "", footer}, "\n")
 	panelWidth := min(maxWidth-6, 64)
@@ -3361,142 +5258,3 @@ func (m model) renderAuthDialog(maxWidth int) string {
 		return ""
 	}
 }
-
-type hitRect struct {
-	x int
-	y int
-	w int
-	h int
-}
-
-func (r hitRect) contains(x, y int) bool {
-	return x >= r.x && x < r.x+r.w && y >= r.y && y < r.y+r.h
-}
-
-func renderSelectButton(clicked bool) string {
-	bracketStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFD54A")).
-		Bold(true)
-	textStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color("#FFD54A")).
-		Bold(true)
-	if clicked {
-		textStyle = textStyle.Underline(true)
-	}
-	return bracketStyle.Render("[") + textStyle.Render("select view") + bracketStyle.Render("]")
-}
-
-func (m model) selectButtonAt(x, y int) int {
-	rects := m.selectButtonRects()
-	for i, rect := range rects {
-		if rect.contains(x, y) {
-			return i
-		}
-	}
-	return -1
-}
-
-func (m model) selectButtonRects() []hitRect {
-	if m.width < 1 || m.height < 1 {
-		return nil
-	}
-
-	frame := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#F47A60")).
-		Padding(1, 1)
-	contentStyle := lipgloss.NewStyle().Padding(1, 1, 0, 1)
-	frame = frame.Width(max(1, m.width-frame.GetHorizontalBorderSize()))
-	frame = frame.Height(max(1, m.height-frame.GetVerticalBorderSize()))
-	layoutWidth := max(1, m.width-frame.GetHorizontalFrameSize()-contentStyle.GetHorizontalFrameSize())
-
-	header := renderBlockTitle()
-	header = lipgloss.PlaceHorizontal(layoutWidth, lipgloss.Center, header)
-	header = lipgloss.NewStyle().PaddingBottom(1).Render(header)
-
-	listWidth := 24
-	rightWidth := max(36, m.width-listWidth-20)
-	panelHeight := 14
-	listBox := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#F47A60")).
-		Padding(0, 1).
-		Height(panelHeight).
-		Width(listWidth).
-		Render(renderViews(m.viewItems, m.selected, ""))
-
-	panelWidth := max(18, (rightWidth-2)/2)
-	pinnedStyle := lipgloss.NewStyle().
-		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#FFD54A")).
-		Padding(0, 1).
-		Width(panelWidth).
-		Height(panelHeight)
-	pinTitle := pinIconOrFallback()
-	pinnedOne := pinnedStyle.Render(pinTitle + " " + renderSelectButton(false))
-	pinnedTwo := pinnedStyle.Render(pinTitle + " " + renderSelectButton(false))
-	rightPanels := lipgloss.JoinHorizontal(lipgloss.Top, pinnedOne, "  ", pinnedTwo)
-
-	mainPanelsRaw := lipgloss.JoinHorizontal(lipgloss.Top, listBox, "  ", rightPanels)
-	mainPanelsWidth := lipgloss.Width(mainPanelsRaw)
-
-	mainPanelsTopGap := 1
-	if m.height > 0 {
-		cmdOuterWidth := lipgloss.Width(mainPanelsRaw)
-		cmdInnerWidth := max(8, cmdOuterWidth-4)
-		cmdInput := m.cmd
-		cmdInput.Width = max(6, cmdInnerWidth-2)
-		cmdLines := []string{}
-		if m.shouldShowCommandSuggestions() {
-			cmdLines = append(cmdLines, renderCommandSuggestionRows(cmdInnerWidth, m.commandSuggestions, m.commandSuggestionIndex, m.commandSuggestionOffset))
-		}
-		cmdLines = append(cmdLines, lipgloss.NewStyle().Width(cmdInnerWidth).Render(cmdInput.View()))
-		cmdInner := strings.Join(cmdLines, "\n")
-		bottomSection := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#6CBFE6")).
-			Padding(0, 1).
-			Render(cmdInner)
-
-		headerGap := 1
-		hasMessage := strings.TrimSpace(m.commandText) != ""
-		messageGap := 0
-		if hasMessage {
-			messageGap = 1
-		}
-
-		availableHeight := max(0, m.height-frame.GetVerticalFrameSize()-contentStyle.GetVerticalFrameSize())
-		coreHeight := lipgloss.Height(header) + lipgloss.Height(mainPanelsRaw) + lipgloss.Height(bottomSection) + headerGap
-		if hasMessage {
-			messageArea := lipgloss.NewStyle().
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("#6CBFE6")).
-				Padding(0, 1).
-				Foreground(lipgloss.Color("#D4CDE9")).
-				Width(max(8, lipgloss.Width(mainPanelsRaw)-4)).
-				Render(m.commandText)
-			coreHeight += messageGap + lipgloss.Height(messageArea)
-		}
-		bridgeGap := max(1, availableHeight-coreHeight)
-		if coreHeight+bridgeGap > availableHeight {
-			headerGap = 0
-		}
-		mainPanelsTopGap = headerGap
-	}
-
-	bodyX := frame.GetHorizontalFrameSize()/2 + contentStyle.GetPaddingLeft()
-	bodyY := frame.GetVerticalFrameSize()/2 + contentStyle.GetPaddingTop()
-	mainPanelsX := bodyX + max(0, (layoutWidth-mainPanelsWidth)/2)
-	mainPanelsY := bodyY + lipgloss.Height(header) + mainPanelsTopGap
-
-	leftPanelX := mainPanelsX + lipgloss.Width(listBox) + 2
-	secondPanelX := leftPanelX + lipgloss.Width(pinnedOne) + 2
-	buttonXOffset := 2 + lipgloss.Width(pinTitle+" ")
-	buttonY := mainPanelsY + 1
-	buttonW := lipgloss.Width("[select view]")
-
-	return []hitRect{
-		{x: leftPanelX + buttonXOffset, y: buttonY, w: buttonW, h: 1},
-		{x: secondPanelX + buttonXOffset, y: buttonY, w: buttonW, h: 1},
-	}
-}
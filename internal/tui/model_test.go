@@ -0,0 +1,345 @@
+package tui
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lachiem1/giddyUp/internal/syncer"
+)
+
+func TestClosestCommand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{input: "/trans", want: "/transactions", ok: true},
+		{input: "/acounts", want: "/accounts", ok: true},
+		{input: "/hlep", want: "/help", ok: true},
+		{input: "/CONFIG", want: "/config", ok: true},
+		{input: "/xyzzyplugh", ok: false},
+		{input: "/", ok: false},
+	}
+	for _, tc := range tests {
+		got, ok := closestCommand(tc.input)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("closestCommand(%q) = (%q, %v), want (%q, %v)", tc.input, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestSavedFilterSuggestions(t *testing.T) {
+	t.Parallel()
+
+	names := []string{"coffee", "groceries", "gym"}
+	got, ok := savedFilterSuggestions("/load-filter g", names)
+	if !ok || len(got) != 2 || got[0].name != "/load-filter groceries" || got[1].name != "/load-filter gym" {
+		t.Fatalf("savedFilterSuggestions(g) = %v, %v, want groceries and gym", got, ok)
+	}
+	if got, ok := savedFilterSuggestions("/load-filter ", names); !ok || len(got) != 3 {
+		t.Fatalf("savedFilterSuggestions(empty) = %v, %v, want every name", got, ok)
+	}
+	if _, ok := savedFilterSuggestions("/load-filter", names); ok {
+		t.Fatalf("savedFilterSuggestions(/load-filter) handled the bare command, want catalog completion")
+	}
+}
+
+func TestParseSavedFilterName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{raw: " Groceries ", want: "groceries"},
+		{raw: "eating_out-2", want: "eating_out-2"},
+		{raw: "", wantErr: true},
+		{raw: "a.b", wantErr: true},
+	} {
+		got, err := parseSavedFilterName(tc.raw)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Fatalf("parseSavedFilterName(%q) = %q, %v, want %q, error %v", tc.raw, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
+func TestSyncMaxDaysOptions(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		days  int
+		label string
+		index int
+	}{
+		{days: 0, label: "all", index: 0},
+		{days: 90, label: "90d", index: 1},
+		{days: 730, label: "2y", index: 4},
+		{days: 100, label: "100d", index: 0},
+	} {
+		if got := formatSyncMaxDays(tc.days); got != tc.label {
+			t.Fatalf("formatSyncMaxDays(%d) = %q, want %q", tc.days, got, tc.label)
+		}
+		if got := syncMaxDaysIndexFromDays(tc.days); got != tc.index {
+			t.Fatalf("syncMaxDaysIndexFromDays(%d) = %d, want %d", tc.days, got, tc.index)
+		}
+	}
+}
+
+func TestParseSyncReadAhead(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		raw   string
+		pages int
+		index int
+	}{
+		{raw: "", pages: syncer.DefaultTransactionsReadAhead, index: 1},
+		{raw: "1", pages: 1, index: 0},
+		{raw: " 3 ", pages: 3, index: 2},
+		{raw: "16", pages: 4, index: 3},
+		{raw: "0", pages: syncer.DefaultTransactionsReadAhead, index: 1},
+		{raw: "many", pages: syncer.DefaultTransactionsReadAhead, index: 1},
+	} {
+		got := parseSyncReadAhead(tc.raw)
+		if got != tc.pages {
+			t.Fatalf("parseSyncReadAhead(%q) = %d, want %d", tc.raw, got, tc.pages)
+		}
+		if idx := syncReadAheadIndexFromPages(got); idx != tc.index {
+			t.Fatalf("syncReadAheadIndexFromPages(%d) = %d, want %d", got, idx, tc.index)
+		}
+	}
+}
+
+func TestRenderHomeSyncPanel(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+	synced := now.Add(-5 * time.Minute)
+	panel := renderHomeSyncPanel([]homeSyncStatus{
+		{collection: "accounts", lastSuccess: &synced},
+		{collection: "transactions", lastError: "rate limited"},
+	}, "", 40, now)
+	for _, want := range []string{"connection details", "synced 5m ago", "synced never", "transactions: rate limited"} {
+		if !strings.Contains(panel, want) {
+			t.Fatalf("renderHomeSyncPanel() = %q, want it to contain %q", panel, want)
+		}
+	}
+
+	clean := renderHomeSyncPanel([]homeSyncStatus{{collection: "accounts", lastSuccess: &synced}}, "", 40, now)
+	if !strings.Contains(clean, "none") {
+		t.Fatalf("renderHomeSyncPanel(no errors) = %q, want last error none", clean)
+	}
+}
+
+func TestFormatHomeSyncAge(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(-d)
+		return &ts
+	}
+	for _, tc := range []struct {
+		last *time.Time
+		want string
+	}{
+		{last: nil, want: "never"},
+		{last: at(20 * time.Second), want: "just now"},
+		{last: at(90 * time.Minute), want: "1h ago"},
+		{last: at(48 * time.Hour), want: "14 Oct 12:00"},
+	} {
+		if got := formatHomeSyncAge(tc.last, now); got != tc.want {
+			t.Fatalf("formatHomeSyncAge(%v) = %q, want %q", tc.last, got, tc.want)
+		}
+	}
+}
+
+func TestRunExclusiveSyncJoinersGetLeaderError(t *testing.T) {
+	t.Parallel()
+
+	const collection = "test-exclusive-sync"
+	leaderErr := errors.New("upstream unavailable")
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[0] = runExclusiveSync(collection, "force=true", func() error {
+			close(started)
+			<-release
+			return leaderErr
+		})
+	}()
+	<-started
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[1] = runExclusiveSync(collection, "force=true", func() error {
+			t.Errorf("joiner ran its own sync, want it to join the leader")
+			return nil
+		})
+	}()
+	// Release the leader only once the second call has joined it.
+	for {
+		syncInFlight.mu.Lock()
+		joined := syncInFlight.runs[collection].joiners
+		syncInFlight.mu.Unlock()
+		if joined == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	for i, err := range errs {
+		if !errors.Is(err, leaderErr) {
+			t.Fatalf("runExclusiveSync() caller %d error = %v, want %v", i, err, leaderErr)
+		}
+	}
+}
+
+func TestRunExclusiveSyncDifferentParamsRunAfterLeader(t *testing.T) {
+	t.Parallel()
+
+	const collection = "test-exclusive-sync-params"
+	leaderErr := errors.New("upstream unavailable")
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var leaderDone atomic.Bool
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[0] = runExclusiveSync(collection, "force=false", func() error {
+			close(started)
+			<-release
+			leaderDone.Store(true)
+			return leaderErr
+		})
+	}()
+	<-started
+
+	ran := false
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs[1] = runExclusiveSync(collection, "force=true", func() error {
+			if !leaderDone.Load() {
+				t.Errorf("forced sync ran alongside the leader, want it to wait")
+			}
+			ran = true
+			return nil
+		})
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if !errors.Is(errs[0], leaderErr) {
+		t.Fatalf("runExclusiveSync() leader error = %v, want %v", errs[0], leaderErr)
+	}
+	if errs[1] != nil || !ran {
+		t.Fatalf("runExclusiveSync() forced caller error = %v, ran = %v, want its own sync to run", errs[1], ran)
+	}
+}
+
+func TestSyncIntervalGuard(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	recent := now.Add(-30 * time.Second)
+	old := now.Add(-2 * time.Minute)
+	tests := []struct {
+		name      string
+		attempt   *time.Time
+		prevErr   string
+		hasCached bool
+		wantSkip  bool
+		wantErr   bool
+	}{
+		{name: "never attempted", wantSkip: false},
+		{name: "outside interval", attempt: &old, hasCached: true, wantSkip: false},
+		{name: "inside interval", attempt: &recent, hasCached: true, wantSkip: true, wantErr: true},
+		{name: "inside interval after an error with a cache", attempt: &recent, prevErr: "boom", hasCached: true, wantSkip: true, wantErr: true},
+		{name: "inside interval after an error with no cache", attempt: &recent, prevErr: "boom", wantSkip: true, wantErr: true},
+	}
+	for _, tc := range tests {
+		skip, err := syncIntervalGuard("transactions", tc.attempt, tc.prevErr, tc.hasCached, time.Minute, now)
+		if skip != tc.wantSkip || (err != nil) != tc.wantErr {
+			t.Fatalf("syncIntervalGuard(%s) = %v, %v, want skip %v, error %v", tc.name, skip, err, tc.wantSkip, tc.wantErr)
+		}
+	}
+
+	// A skip with a cache says when the next sync may run, and the screens
+	// treat it as up to date.
+	_, err := syncIntervalGuard("transactions", &recent, "", true, time.Minute, now)
+	var skipped *SyncSkippedError
+	if !errors.As(err, &skipped) || !skipped.NextAllowed.Equal(recent.Add(time.Minute)) {
+		t.Fatalf("syncIntervalGuard() inside the interval = %v, want a SyncSkippedError at %s", err, recent.Add(time.Minute))
+	}
+	if ignoreSyncSkipped(err) != nil {
+		t.Fatalf("ignoreSyncSkipped(%v) = non-nil, want nil", err)
+	}
+}
+
+func TestMaybeStartTransactionsSyncCmdRespectsInterval(t *testing.T) {
+	t.Parallel()
+
+	recent := time.Now().Add(-10 * time.Second)
+	m := model{syncMinInterval: time.Minute, transactionsLastSync: &recent}
+	next, cmd := m.maybeStartTransactionsSyncCmd(true)
+	if cmd != nil || next.transactionsSyncing {
+		t.Fatalf("maybeStartTransactionsSyncCmd() inside the interval started a sync, want it skipped")
+	}
+
+	old := time.Now().Add(-2 * time.Minute)
+	m.transactionsLastSync = &old
+	next, cmd = m.maybeStartTransactionsSyncCmd(false)
+	if cmd == nil || !next.transactionsSyncing {
+		t.Fatalf("maybeStartTransactionsSyncCmd() outside the interval = no sync, want one started")
+	}
+}
+
+func TestAccountsAutoRefreshDue(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		screen screenMode
+		pinned []string
+		want   bool
+	}{
+		{name: "accounts", screen: screenAccounts, want: true},
+		{name: "home with pinned accounts", screen: screenHome, pinned: []string{"acc-1"}, want: true},
+		{name: "home without pinned accounts", screen: screenHome, want: false},
+		{name: "transactions", screen: screenTransactions, pinned: []string{"acc-1"}, want: false},
+		{name: "config", screen: screenConfig, want: false},
+		{name: "pay cycle", screen: screenPayCycleBurndown, pinned: []string{"acc-1"}, want: false},
+	}
+	for _, tt := range tests {
+		m := model{screen: tt.screen, homePinnedAccounts: tt.pinned}
+		if got := m.accountsAutoRefreshDue(); got != tt.want {
+			t.Fatalf("accountsAutoRefreshDue() on %s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	// A tick from an earlier session stops the loop instead of syncing.
+	m := model{screen: screenAccounts, accountsSession: 2}
+	if _, cmd := m.Update(accountsAutoRefreshTickMsg{sessionID: 1}); cmd != nil {
+		t.Fatalf("Update(stale tick) returned a command, want none")
+	}
+}
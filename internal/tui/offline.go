@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// offlineEnvVar starts the app in offline mode, where views read only the
+// local database and never sync with the Up API.
+const offlineEnvVar = "GIDDYUP_OFFLINE"
+
+func offlineFromEnv() bool {
+	return parseOfflineEnv(os.Getenv(offlineEnvVar))
+}
+
+func parseOfflineEnv(raw string) bool {
+	switch strings.ToLower(strings.TrimSpace(raw)) {
+	case "1", "true", "yes", "on":
+		return true
+	default:
+		return false
+	}
+}
+
+// toggleOffline switches offline mode. Going back online checks the
+// connection and syncs whatever the current screen shows; the auto-refresh
+// ticks keep running while offline, so they pick up again on their own.
+func (m model) toggleOffline() (tea.Model, tea.Cmd) {
+	m.offline = !m.offline
+	if m.offline {
+		return m.withCommandFeedback("offline: showing cached data only")
+	}
+	cmds := []tea.Cmd{checkConnectionCmd}
+	switch m.screen {
+	case screenTransactions, screenTransactionsFilters:
+		var syncCmd tea.Cmd
+		m, syncCmd = m.maybeStartTransactionsSyncCmd(false)
+		cmds = append(cmds, syncCmd)
+	case screenAccounts:
+		cmds = append(cmds, m.syncAndReloadAccountsPreviewCmd(false))
+	case screenPayCycleBurndown:
+		var syncCmd tea.Cmd
+		m, syncCmd = m.maybeStartTransactionsSyncCmd(false)
+		cmds = append(cmds, syncCmd, m.syncAndReloadAccountsPreviewCmd(false))
+	}
+	next, cmd := m.withCommandFeedback("online: syncing resumed")
+	return next, tea.Batch(append(cmds, cmd)...)
+}
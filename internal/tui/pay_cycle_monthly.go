@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// monthlyBudgetKey is the app_config key holding the spending account's
+// monthly budget in dollars.
+const monthlyBudgetKey = "budget.monthly_spending"
+
+// monthlyBudgetWindow is the calendar month containing now.
+func monthlyBudgetWindow(now time.Time) (time.Time, time.Time) {
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 1, -1)
+}
+
+// monthlyBudgetBurndown walks the month's spend down from the budget. Only
+// debits count as spend, so pay and other credits into the spending account
+// don't refill the budget. It returns the points, the debits behind them and
+// the budget left at the end.
+func monthlyBudgetBurndown(
+	rows []payCycleTransactionRow,
+	budgetCents int64,
+	startDate time.Time,
+	endDate time.Time,
+) ([]payCycleBurndownPoint, []payCycleTransactionRow, int64) {
+	startDateStr := startDate.Format("2006-01-02")
+	points := []payCycleBurndownPoint{{
+		date:           startDateStr,
+		createdAt:      startDate.Format("2006-01-02T00:00:00"),
+		remainingCents: budgetCents,
+	}}
+	debits := make([]payCycleTransactionRow, 0, len(rows))
+	remaining := budgetCents
+	for _, row := range rows {
+		if row.spendCents <= 0 {
+			continue
+		}
+		remaining -= row.spendCents
+		debits = append(debits, row)
+		createdAt := strings.TrimSpace(row.createdAt)
+		points = append(points, payCycleBurndownPoint{
+			date:           formatTransactionDate(createdAt),
+			createdAt:      createdAt,
+			remainingCents: remaining,
+			hasTransaction: true,
+			transactionID:  row.id,
+		})
+	}
+	points = append(points, payCycleBurndownPoint{
+		date:           endDate.Format("2006-01-02"),
+		createdAt:      endDate.Format("2006-01-02T23:59:59"),
+		remainingCents: remaining,
+	})
+	return points, debits, remaining
+}
+
+func (m model) loadMonthlyBudgetSeriesCmd() tea.Cmd {
+	account, ok := m.payCycleSelectedAccount()
+	if !ok {
+		return nil
+	}
+	budgetCents, err := parseGoalBalanceCents(m.payCycleMonthlyBudget)
+	if err != nil {
+		return nil
+	}
+	startDate, endDate := monthlyBudgetWindow(time.Now())
+	accountID := account.id
+	startDateStr := startDate.Format("2006-01-02")
+	endDateStr := endDate.Format("2006-01-02")
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadPayCycleSeriesMsg{err: fmt.Errorf("database is not initialized")}
+		}
+		rows, err := queryPayCycleTransactionRows(context.Background(), m.db, accountID, startDateStr, endDateStr, false)
+		if err != nil {
+			return loadPayCycleSeriesMsg{accountID: accountID, err: err}
+		}
+		points, transactions, remaining := monthlyBudgetBurndown(rows, budgetCents, startDate, endDate)
+		return loadPayCycleSeriesMsg{
+			accountID:           accountID,
+			startDate:           startDateStr,
+			endDate:             endDateStr,
+			goalCents:           budgetCents,
+			currentBalanceCents: remaining,
+			points:              points,
+			transactions:        transactions,
+		}
+	}
+}
+
+// openMonthlyBudgetPrompt starts entry of the monthly spending budget.
+func (m *model) openMonthlyBudgetPrompt() {
+	m.payCyclePromptMode = payCyclePromptMonthlyBudget
+	m.payCyclePromptErr = ""
+	m.payCycleInput.Placeholder = "0.00"
+	m.payCycleInput.SetValue(strings.TrimSpace(m.payCycleMonthlyBudget))
+	m.payCycleInput.Focus()
+}
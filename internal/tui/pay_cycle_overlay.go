@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type loadPayCycleOverlayMsg struct {
+	series    []payCycleBurndownSeries
+	startDate string
+	endDate   string
+	err       error
+}
+
+// payCycleOverlayAccounts returns the indexes of the savers to compare:
+// those toggled with space, or every saver with a goal when none are.
+func payCycleOverlayAccounts(accounts []payCycleAccountRow, selected map[string]bool) []int {
+	out := make([]int, 0, len(accounts))
+	for i, account := range accounts {
+		if len(selected) > 0 && !selected[account.id] {
+			continue
+		}
+		if _, err := parseGoalBalanceCents(account.goalBalance); err != nil {
+			continue
+		}
+		out = append(out, i)
+	}
+	return out
+}
+
+func (m model) loadPayCycleOverlayCmd() tea.Cmd {
+	startDate, endDate, err := computePayCycleWindow(m.payCycleNextDate, m.payCycleFrequency)
+	if err != nil {
+		return nil
+	}
+	accounts := make([]payCycleAccountRow, 0, len(m.payCycleAccounts))
+	series := make([]payCycleBurndownSeries, 0, len(m.payCycleAccounts))
+	for _, i := range payCycleOverlayAccounts(m.payCycleAccounts, m.payCycleOverlayIDs) {
+		account := m.payCycleAccounts[i]
+		goalCents, _ := parseGoalBalanceCents(account.goalBalance)
+		accounts = append(accounts, account)
+		series = append(series, payCycleBurndownSeries{
+			name:      account.displayName,
+			color:     accountDisplayColor(account.color, i),
+			goalCents: goalCents,
+		})
+	}
+	startDateStr := startDate.Format("2006-01-02")
+	endDateStr := endDate.Format("2006-01-02")
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadPayCycleOverlayMsg{err: fmt.Errorf("database is not initialized")}
+		}
+		for i, account := range accounts {
+			points, _, err := queryPayCycleBurndownSeries(
+				context.Background(),
+				m.db,
+				account.id,
+				startDate,
+				endDate,
+				account.balanceCents,
+				series[i].goalCents,
+			)
+			if err != nil {
+				return loadPayCycleOverlayMsg{err: err}
+			}
+			series[i].points = points
+		}
+		return loadPayCycleOverlayMsg{series: series, startDate: startDateStr, endDate: endDateStr}
+	}
+}
+
+// togglePayCycleOverlayAccount adds or removes a saver from the comparison.
+func (m *model) togglePayCycleOverlayAccount(accountID string) {
+	if m.payCycleOverlayIDs == nil {
+		m.payCycleOverlayIDs = map[string]bool{}
+	}
+	if m.payCycleOverlayIDs[accountID] {
+		delete(m.payCycleOverlayIDs, accountID)
+		return
+	}
+	m.payCycleOverlayIDs[accountID] = true
+}
@@ -23,8 +23,19 @@ const (
 	payCycleCellToday
 	payCycleCellNode
 	payCycleCellNodeSelected
+	// payCycleCellSeries is the first of one code per overlay series, so
+	// each line keeps its own colour.
+	payCycleCellSeries
 )
 
+// payCycleBurndownSeries is one account's burndown line.
+type payCycleBurndownSeries struct {
+	name      string
+	color     lipgloss.Color
+	goalCents int64
+	points    []payCycleBurndownPoint
+}
+
 func renderPayCycleBurndownTitle() string {
 	glyphs := map[rune][3]string{
 		'A': {"▄▀█", "█▀█", "▀ ▀"},
@@ -81,6 +92,7 @@ func (m model) enterPayCycleBurndownView() (tea.Model, tea.Cmd) {
 	m.payCyclePaneFocus = payCyclePaneFocusMain
 	m.payCycleConfigReturn = false
 	m.payCyclePromptGoalAfterConfig = false
+	m.payCycleMonthly = false
 	m.cmd.Blur()
 	next, syncCmd := m.maybeStartTransactionsSyncCmd(false)
 	accountsSyncCmd := next.syncAndReloadAccountsPreviewCmd(false)
@@ -107,19 +119,27 @@ func (m model) loadPayCycleStateCmd() tea.Cmd {
 		if m.db == nil {
 			return loadPayCycleStateMsg{err: fmt.Errorf("database is not initialized")}
 		}
-		accounts, nextPayDate, frequency, err := queryPayCycleState(context.Background(), m.db)
+		ctx := context.Background()
+		accounts, nextPayDate, frequency, err := queryPayCycleState(ctx, m.db, m.payCycleMonthly)
+		if err != nil {
+			return loadPayCycleStateMsg{err: err}
+		}
+		monthlyBudget, _, err := storage.NewAppConfigRepo(m.db).Get(ctx, monthlyBudgetKey)
 		if err != nil {
 			return loadPayCycleStateMsg{err: err}
 		}
 		return loadPayCycleStateMsg{
-			accounts:    accounts,
-			nextPayDate: nextPayDate,
-			frequency:   frequency,
+			accounts:      accounts,
+			nextPayDate:   nextPayDate,
+			frequency:     frequency,
+			monthlyBudget: strings.TrimSpace(monthlyBudget),
 		}
 	}
 }
 
-func queryPayCycleState(ctx context.Context, db *sql.DB) ([]payCycleAccountRow, string, string, error) {
+// queryPayCycleState loads the accounts the burndown can chart: savers for
+// the pay cycle, or the spending account when monthly is set.
+func queryPayCycleState(ctx context.Context, db *sql.DB, monthly bool) ([]payCycleAccountRow, string, string, error) {
 	rows, err := db.QueryContext(
 		ctx,
 		`SELECT
@@ -130,8 +150,9 @@ func queryPayCycleState(ctx context.Context, db *sql.DB) ([]payCycleAccountRow,
 			COALESCE(goal_balance, '')
 		 FROM accounts
 		 WHERE is_active = 1
-		   AND UPPER(account_type) != 'TRANSACTIONAL'
+		   AND (UPPER(account_type) = 'TRANSACTIONAL') = ?
 		 ORDER BY display_order ASC, display_name ASC, id ASC`,
+		monthly,
 	)
 	if err != nil {
 		return nil, "", "", err
@@ -155,6 +176,13 @@ func queryPayCycleState(ctx context.Context, db *sql.DB) ([]payCycleAccountRow,
 	if err := rows.Err(); err != nil {
 		return nil, "", "", err
 	}
+	colors, err := loadAccountColors(ctx, db)
+	if err != nil {
+		return nil, "", "", err
+	}
+	for i := range out {
+		out[i].color = colors[out[i].id]
+	}
 
 	repo := storage.NewAppConfigRepo(db)
 	nextPayDate, _, err := repo.Get(ctx, "pay_cycle.next_date")
@@ -300,6 +328,12 @@ func (m model) payCycleSelectedAccount() (payCycleAccountRow, bool) {
 }
 
 func (m model) loadPayCycleSeriesCmd() tea.Cmd {
+	if m.payCycleMonthly {
+		return m.loadMonthlyBudgetSeriesCmd()
+	}
+	if m.payCycleOverlay {
+		return m.loadPayCycleOverlayCmd()
+	}
 	account, ok := m.payCycleSelectedAccount()
 	if !ok {
 		return nil
@@ -360,6 +394,78 @@ func queryPayCycleBurndownSeries(
 
 	startDateStr := startDate.Format("2006-01-02")
 	endDateStr := endDate.Format("2006-01-02")
+	allRows, err := queryPayCycleTransactionRows(ctx, db, accountID, startDateStr, endDateStr, true)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fundingIdx := -1
+	if goalCents > 0 {
+		for i := range allRows {
+			// spendCents is negative for inflows; funding is a +ve transaction >= goal.
+			if allRows[i].spendCents <= -goalCents {
+				fundingIdx = i
+				break
+			}
+		}
+	}
+
+	transactions := make([]payCycleTransactionRow, 0, len(allRows))
+	totalSpendCents := int64(0)
+	for i := range allRows {
+		if fundingIdx >= 0 && i < fundingIdx {
+			continue
+		}
+		// Ignore likely funding spikes (e.g. salary/seed top-ups) that exceed the goal balance.
+		if goalCents > 0 && absInt64(allRows[i].spendCents) >= goalCents {
+			continue
+		}
+		totalSpendCents += allRows[i].spendCents
+		transactions = append(transactions, allRows[i])
+	}
+
+	startBalanceCents := currentBalanceCents + totalSpendCents
+	points := make([]payCycleBurndownPoint, 0, len(transactions)+2)
+	points = append(points, payCycleBurndownPoint{
+		date:           startDateStr,
+		createdAt:      startDate.Format("2006-01-02T00:00:00"),
+		remainingCents: startBalanceCents,
+		hasTransaction: false,
+	})
+
+	remaining := startBalanceCents
+	for i := range transactions {
+		remaining -= transactions[i].spendCents
+		t := strings.TrimSpace(transactions[i].createdAt)
+		datePart := formatTransactionDate(t)
+		points = append(points, payCycleBurndownPoint{
+			date:           datePart,
+			createdAt:      t,
+			remainingCents: remaining,
+			hasTransaction: true,
+			transactionID:  transactions[i].id,
+		})
+	}
+	points = append(points, payCycleBurndownPoint{
+		date:           endDateStr,
+		createdAt:      endDate.Format("2006-01-02T23:59:59"),
+		remainingCents: currentBalanceCents,
+		hasTransaction: false,
+	})
+	return points, transactions, nil
+}
+
+// queryPayCycleTransactionRows loads an account's non-zero transactions dated
+// within the window, oldest first. Internal transfers are left out unless
+// includeInternal is set.
+func queryPayCycleTransactionRows(
+	ctx context.Context,
+	db *sql.DB,
+	accountID string,
+	startDateStr string,
+	endDateStr string,
+	includeInternal bool,
+) ([]payCycleTransactionRow, error) {
 	rows, err := db.QueryContext(
 		ctx,
 		`SELECT
@@ -390,13 +496,15 @@ func queryPayCycleBurndownSeries(
 		   AND t.amount_value_in_base_units != 0
 		   AND date(t.created_at) >= date(?)
 		   AND date(t.created_at) <= date(?)
+		   AND (? OR t.transfer_account_id IS NULL)
 		 ORDER BY t.created_at ASC, t.id ASC`,
 		accountID,
 		startDateStr,
 		endDateStr,
+		includeInternal,
 	)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -419,7 +527,7 @@ func queryPayCycleBurndownSeries(
 			&row.noteText,
 			&row.accountName,
 		); err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 		if spend.Valid {
 			row.spendCents = spend.Int64
@@ -427,68 +535,27 @@ func queryPayCycleBurndownSeries(
 		allRows = append(allRows, row)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, nil, err
-	}
-
-	fundingIdx := -1
-	if goalCents > 0 {
-		for i := range allRows {
-			// spendCents is negative for inflows; funding is a +ve transaction >= goal.
-			if allRows[i].spendCents <= -goalCents {
-				fundingIdx = i
-				break
-			}
-		}
-	}
-
-	transactions := make([]payCycleTransactionRow, 0, len(allRows))
-	totalSpendCents := int64(0)
-	for i := range allRows {
-		if fundingIdx >= 0 && i < fundingIdx {
-			continue
-		}
-		// Ignore likely funding spikes (e.g. salary/seed top-ups) that exceed the goal balance.
-		if goalCents > 0 && absInt64(allRows[i].spendCents) >= goalCents {
-			continue
-		}
-		totalSpendCents += allRows[i].spendCents
-		transactions = append(transactions, allRows[i])
-	}
-
-	startBalanceCents := currentBalanceCents + totalSpendCents
-	points := make([]payCycleBurndownPoint, 0, len(transactions)+2)
-	points = append(points, payCycleBurndownPoint{
-		date:           startDateStr,
-		createdAt:      startDate.Format("2006-01-02T00:00:00"),
-		remainingCents: startBalanceCents,
-		hasTransaction: false,
-	})
-
-	remaining := startBalanceCents
-	for i := range transactions {
-		remaining -= transactions[i].spendCents
-		t := strings.TrimSpace(transactions[i].createdAt)
-		datePart := formatTransactionDate(t)
-		points = append(points, payCycleBurndownPoint{
-			date:           datePart,
-			createdAt:      t,
-			remainingCents: remaining,
-			hasTransaction: true,
-			transactionID:  transactions[i].id,
-		})
+		return nil, err
 	}
-	points = append(points, payCycleBurndownPoint{
-		date:           endDateStr,
-		createdAt:      endDate.Format("2006-01-02T23:59:59"),
-		remainingCents: currentBalanceCents,
-		hasTransaction: false,
-	})
-	return points, transactions, nil
+	return allRows, nil
 }
 
 func (m *model) refreshPayCyclePrompt() {
 	m.clampPayCycleCursor()
 
+	if m.payCycleMonthly {
+		// The month is the window, so only the budget needs configuring.
+		_, hasAccount := m.payCycleSelectedAccount()
+		if _, err := parseGoalBalanceCents(m.payCycleMonthlyBudget); err != nil && hasAccount {
+			m.openMonthlyBudgetPrompt()
+			return
+		}
+		m.payCyclePromptMode = payCyclePromptNone
+		m.payCycleInput.SetValue("")
+		m.payCycleInput.Blur()
+		return
+	}
+
 	nextPayDate := strings.TrimSpace(m.payCycleNextDate)
 	frequency := strings.TrimSpace(m.payCycleFrequency)
 	if _, err := parsePayCycleDate(nextPayDate); err != nil {
@@ -516,6 +583,35 @@ func (m *model) refreshPayCyclePrompt() {
 	m.payCycleInput.Blur()
 }
 
+// openPayCycleGoalPrompt starts goal entry for account and remembers it as
+// the target, so a selection change before submit can't redirect the goal.
+func (m *model) openPayCycleGoalPrompt(account payCycleAccountRow) {
+	m.payCyclePromptMode = payCyclePromptGoal
+	m.payCyclePromptErr = ""
+	m.payCyclePromptAccountID = account.id
+	m.payCyclePromptAccountName = account.displayName
+	m.payCycleInput.Placeholder = "0.00"
+	m.payCycleInput.SetValue(strings.TrimSpace(account.goalBalance))
+	m.payCycleInput.Focus()
+}
+
+// payCycleGoalTarget returns the account the open goal prompt was started
+// for, failing if the selection has since moved to a different account.
+func (m model) payCycleGoalTarget() (payCycleAccountRow, error) {
+	account, ok := m.payCycleSelectedAccount()
+	if !ok {
+		return payCycleAccountRow{}, fmt.Errorf("no account selected; goal not saved")
+	}
+	if m.payCyclePromptAccountID != "" && account.id != m.payCyclePromptAccountID {
+		return payCycleAccountRow{}, fmt.Errorf(
+			"selected account changed from %s to %s; press esc and set the goal again",
+			m.payCyclePromptAccountName,
+			account.displayName,
+		)
+	}
+	return account, nil
+}
+
 func renderPayCyclePromptLabel(mode int, accountName string) string {
 	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
 	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
@@ -527,6 +623,8 @@ func renderPayCyclePromptLabel(mode int, accountName string) string {
 			valueStyle.Render("weekly / fortnightly / monthly / quarterly")
 	case payCyclePromptGoal:
 		return labelStyle.Render("Set goal balance for ") + valueStyle.Render(accountName) + labelStyle.Render(":")
+	case payCyclePromptMonthlyBudget:
+		return labelStyle.Render("Set monthly spending budget for ") + valueStyle.Render(accountName) + labelStyle.Render(":")
 	default:
 		return ""
 	}
@@ -543,29 +641,51 @@ func absInt64(v int64) int64 {
 	return v
 }
 
+// renderPayCycleBurndownLines plots one burndown in dollars, or with
+// overlay several on shared axes, each as a percentage of its own goal.
 func renderPayCycleBurndownLines(
-	points []payCycleBurndownPoint,
+	series []payCycleBurndownSeries,
 	contentWidth int,
-	goalCents int64,
 	currentBalanceCents int64,
-	accountColor lipgloss.Color,
 	startDateRaw string,
 	endDateRaw string,
 	selectedTransactionID string,
+	monthly bool,
+	overlay bool,
 ) []string {
 	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true)
 	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
 	idealStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6B7280"))
-	lineStyle := lipgloss.NewStyle().Foreground(accountColor)
 	todayStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
-	nodeStyle := lipgloss.NewStyle().Foreground(accountColor).Bold(true)
 	selectedNodeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD54A")).Bold(true)
-	out := []string{titleStyle.Render("pay cycle burndown")}
-	if len(points) == 0 {
+	title, goalLabel, windowLabel := "pay cycle burndown", "goal", "cycle"
+	if monthly {
+		title, goalLabel, windowLabel = "monthly budget burndown", "budget", "month"
+	}
+	if overlay {
+		title = "pay cycle burndown - goals compared"
+	}
+	out := []string{titleStyle.Render(title)}
+	if overlay && len(series) == 0 {
+		return append(out, labelStyle.Render("no savers with goals - press g to set one"))
+	}
+	if len(series) == 0 || len(series[0].points) == 0 {
+		if monthly {
+			return append(out, labelStyle.Render("no budget data - press g to set a monthly budget"))
+		}
 		return append(out, labelStyle.Render("no pay cycle data - press enter to configure burndown"))
 	}
-	if goalCents <= 0 {
-		return append(out, labelStyle.Render("goal balance required"))
+	if series[0].goalCents <= 0 {
+		return append(out, labelStyle.Render(goalLabel+" required"))
+	}
+	goalCents := series[0].goalCents
+	axisLabel := func(ratio float64) string {
+		return renderPayCycleDollars(int64(math.Round(ratio * float64(goalCents))))
+	}
+	if overlay {
+		axisLabel = func(ratio float64) string {
+			return fmt.Sprintf("%d%%", int(math.Round(ratio*100)))
+		}
 	}
 
 	innerWidth := max(16, contentWidth-2)
@@ -577,17 +697,16 @@ func renderPayCycleBurndownLines(
 		plotHeight = 10
 	}
 	yTickCount := min(5, max(3, plotHeight-1))
-	yTickByRow := make(map[int]int64, yTickCount)
+	yTickByRow := make(map[int]string, yTickCount)
 	for i := 0; i < yTickCount; i++ {
 		row := int(math.Round(float64(i) * float64((plotHeight-1)-1) / float64(yTickCount-1)))
 		ratio := float64((plotHeight-1)-row) / float64(plotHeight-1)
-		yTickByRow[row] = int64(math.Round(ratio * float64(goalCents)))
+		yTickByRow[row] = axisLabel(ratio)
 	}
-	yTickByRow[plotHeight-1] = 0
+	yTickByRow[plotHeight-1] = axisLabel(0)
 	yLabelWidth := 1
-	for _, cents := range yTickByRow {
-		w := lipgloss.Width(renderPayCycleDollars(cents))
-		if w > yLabelWidth {
+	for _, label := range yTickByRow {
+		if w := lipgloss.Width(label); w > yLabelWidth {
 			yLabelWidth = w
 		}
 	}
@@ -616,84 +735,60 @@ func renderPayCycleBurndownLines(
 	// Straight dotted benchmark line from top of y-axis to end of x-axis.
 	drawPayCycleSegment(grid, codes, 1, 0, dataCols, xAxisRow, '·', payCycleCellIdeal, xAxisRow, -1, false)
 
-	pointX := make([]int, len(points))
-	pointY := make([]int, len(points))
-	for i := range points {
-		pointX[i] = payCyclePointColumn(points[i], startDate, endDate, hasWindow, dataCols)
-	}
-	todayCol := payCycleTodayColumn(startDate, endDate, hasWindow, dataCols)
+	now := time.Now().In(time.Local)
+	todayCol := payCycleTodayColumn(startDate, endDate, hasWindow, dataCols, now)
 	if todayCol > 0 {
 		for y := 0; y <= xAxisRow; y++ {
 			setPayCycleCell(grid, codes, todayCol, y, '·', payCycleCellToday)
 		}
 	}
+	futureCol := payCycleFutureColumn(startDate, endDate, hasWindow, dataCols, now)
 
-	prevX, prevY := -1, -1
-	for i, p := range points {
-		ratio := float64(p.remainingCents) / float64(goalCents)
-		if ratio < 0 {
-			ratio = 0
+	seriesStyles := make([]lipgloss.Style, len(series))
+	for s, line := range series {
+		seriesStyles[s] = lipgloss.NewStyle().Foreground(line.color)
+		if line.goalCents <= 0 {
+			continue
 		}
-		if ratio > 1 {
-			ratio = 1
+		code := payCycleCellActual
+		if overlay {
+			code = payCycleCellSeries + s
 		}
-		y := xAxisRow - int(math.Round(ratio*float64(xAxisRow)))
-		y = max(0, min(plotHeight-1, y))
-		if y == xAxisRow && xAxisRow > 0 {
-			y = xAxisRow - 1
+		pointX, pointY := plotPayCycleSeries(grid, codes, line, startDate, endDate, hasWindow, dataCols, xAxisRow, futureCol, code)
+		if overlay {
+			continue
 		}
-		x := pointX[i] + 1
-		pointY[i] = y
-		if prevX >= 0 {
-			// Render burndown as a step graph:
-			// hold previous balance horizontally until the transaction time, then jump vertically.
-			if x != prevX {
-				skipFutureTail := todayCol > 0 && prevX <= todayCol && x > todayCol
-				drawPayCycleSegment(grid, codes, prevX, prevY, x, prevY, '.', payCycleCellActual, xAxisRow, todayCol, skipFutureTail)
+		for i, p := range line.points {
+			if !p.hasTransaction {
+				continue
 			}
-			if y != prevY {
-				skipFutureTail := false
-				if todayCol > 0 && x > todayCol {
-					skipFutureTail = true
-				}
-				drawPayCycleSegment(grid, codes, x, prevY, x, y, '.', payCycleCellActual, xAxisRow, todayCol, skipFutureTail)
+			node := '●'
+			cellCode := payCycleCellNode
+			if futureCol > 0 && pointX[i]+1 > futureCol {
+				cellCode = payCycleCellFutureActual
 			}
+			if strings.TrimSpace(selectedTransactionID) != "" &&
+				strings.TrimSpace(p.transactionID) == strings.TrimSpace(selectedTransactionID) {
+				node = '◉'
+				cellCode = payCycleCellNodeSelected
+			}
+			setPayCycleCell(grid, codes, pointX[i]+1, pointY[i], node, cellCode)
 		}
-		prevX, prevY = x, y
-	}
-	for i := range points {
-		if !points[i].hasTransaction {
-			continue
-		}
-		node := '●'
-		cellCode := payCycleCellNode
-		if todayCol > 0 && pointX[i]+1 > todayCol {
-			cellCode = payCycleCellFutureActual
-		}
-		if strings.TrimSpace(selectedTransactionID) != "" &&
-			strings.TrimSpace(points[i].transactionID) == strings.TrimSpace(selectedTransactionID) {
-			node = '◉'
-			cellCode = payCycleCellNodeSelected
-		}
-		setPayCycleCell(grid, codes, pointX[i]+1, pointY[i], node, cellCode)
 	}
 
 	for row := 0; row < plotHeight; row++ {
-		axisLabel := ""
-		if cents, ok := yTickByRow[row]; ok {
-			axisLabel = renderPayCycleDollars(cents)
-		}
-		prefix := fmt.Sprintf("%*s ", yLabelWidth, axisLabel)
+		prefix := fmt.Sprintf("%*s ", yLabelWidth, yTickByRow[row])
 		graphPart := renderPayCycleGraphRow(
 			grid[row],
 			codes[row],
 			max(1, innerWidth-lipgloss.Width(prefix)),
 			labelStyle,
 			idealStyle,
-			lineStyle,
+			seriesStyles[0],
 			todayStyle,
-			nodeStyle,
+			seriesStyles[0].Bold(true),
 			selectedNodeStyle,
+			seriesStyles,
 		)
 		out = append(out, labelStyle.Render(prefix)+graphPart)
 	}
@@ -723,36 +818,203 @@ func renderPayCycleBurndownLines(
 	out = append(out, labelStyle.Render(truncateDisplayWidth(axisPrefix+renderTimeSeriesLabelRow(graphWidth, shiftedTicks, tickLabels), innerWidth)))
 	xAxisLabel := lipgloss.NewStyle().Width(graphWidth).Align(lipgloss.Center).Render("date")
 	out = append(out, labelStyle.Render(truncateDisplayWidth(axisPrefix+xAxisLabel, innerWidth)))
-	daysLeft := payCycleDaysLeft(endDateRaw)
-	out = append(out, labelStyle.Render(
-		truncateDisplayWidth(
-			fmt.Sprintf(
-				"goal: %s  |  remaining: %s  |  days left in cycle: %d",
-				renderPayCycleDollars(goalCents),
-				renderPayCycleDollars(currentBalanceCents),
-				daysLeft,
-			),
-			innerWidth,
-		),
-	))
-	return out
+	daysLeft := payCycleDaysLeft(endDateRaw, now)
+	if overlay {
+		out = append(out, renderPayCycleOverlayLegend(series, innerWidth)...)
+		return append(out, labelStyle.Render(fmt.Sprintf("days left in %s: %d", windowLabel, daysLeft)))
+	}
+	footer := fmt.Sprintf(
+		"%s: %s  |  remaining: %s  |  days left in %s: %d",
+		goalLabel,
+		renderPayCycleDollars(goalCents),
+		renderPayCycleDollars(currentBalanceCents),
+		windowLabel,
+		daysLeft,
+	)
+	if pace, ok := payCyclePaceCents(series[0].points, goalCents, startDateRaw, endDateRaw, now); ok {
+		paceText := "ahead of ideal by " + renderPayCycleDollars(pace)
+		paceColor := lipgloss.Color("#5CCB76")
+		if pace < 0 {
+			paceText = "behind ideal by " + renderPayCycleDollars(-pace)
+			paceColor = lipgloss.Color("#F15B5B")
+		}
+		out = append(out, lipgloss.NewStyle().Foreground(paceColor).Render(truncateDisplayWidth(paceText, innerWidth)))
+	}
+	projected, ok := projectPayCycleEndBalance(series[0].points, startDateRaw, endDateRaw, now)
+	if !ok {
+		return append(out, labelStyle.Render(truncateDisplayWidth(footer, innerWidth)))
+	}
+	projectedLabel := "  |  projected: "
+	projectedValue := renderPayCycleDollars(projected)
+	if lipgloss.Width(footer+projectedLabel+projectedValue) > innerWidth {
+		// Too narrow for one line, so the projection gets its own.
+		out = append(out, labelStyle.Render(truncateDisplayWidth(footer, innerWidth)))
+		footer, projectedLabel = "", "projected end of "+windowLabel+": "
+	}
+	projectedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
+	if projected < 0 {
+		projectedStyle = projectedStyle.Foreground(lipgloss.Color("#F15B5B"))
+	}
+	return append(out, labelStyle.Render(footer+projectedLabel)+projectedStyle.Render(projectedValue))
+}
+
+// plotPayCycleSeries draws one burndown as a step graph scaled to its own
+// goal and returns each point's column and row.
+func plotPayCycleSeries(
+	grid [][]rune,
+	codes [][]int,
+	line payCycleBurndownSeries,
+	startDate time.Time,
+	endDate time.Time,
+	hasWindow bool,
+	dataCols int,
+	xAxisRow int,
+	futureCol int,
+	code int,
+) ([]int, []int) {
+	pointX := make([]int, len(line.points))
+	pointY := make([]int, len(line.points))
+	prevX, prevY := -1, -1
+	for i, p := range line.points {
+		pointX[i] = payCyclePointColumn(p, startDate, endDate, hasWindow, dataCols)
+		y := xAxisRow - int(math.Round(payCycleGoalRatio(p.remainingCents, line.goalCents)*float64(xAxisRow)))
+		y = max(0, min(xAxisRow, y))
+		if y == xAxisRow && xAxisRow > 0 {
+			y = xAxisRow - 1
+		}
+		x := pointX[i] + 1
+		pointY[i] = y
+		if prevX >= 0 {
+			// Render burndown as a step graph:
+			// hold previous balance horizontally until the transaction time, then jump vertically.
+			if x != prevX {
+				skipFutureTail := futureCol > 0 && prevX <= futureCol && x > futureCol
+				drawPayCycleSegment(grid, codes, prevX, prevY, x, prevY, '.', code, xAxisRow, futureCol, skipFutureTail)
+			}
+			if y != prevY {
+				skipFutureTail := futureCol > 0 && x > futureCol
+				drawPayCycleSegment(grid, codes, x, prevY, x, y, '.', code, xAxisRow, futureCol, skipFutureTail)
+			}
+		}
+		prevX, prevY = x, y
+	}
+	return pointX, pointY
+}
+
+// payCycleGoalRatio is the share of goal remaining, clamped to 0..1.
+func payCycleGoalRatio(remainingCents int64, goalCents int64) float64 {
+	if goalCents <= 0 {
+		return 0
+	}
+	return math.Max(0, math.Min(1, float64(remainingCents)/float64(goalCents)))
+}
+
+// renderPayCycleOverlayLegend names each overlay line in its colour with
+// the share of its goal left, wrapping entries to width.
+func renderPayCycleOverlayLegend(series []payCycleBurndownSeries, width int) []string {
+	lines := []string{}
+	current, currentWidth := "", 0
+	for _, line := range series {
+		remaining := int64(0)
+		if len(line.points) > 0 {
+			remaining = line.points[len(line.points)-1].remainingCents
+		}
+		text := fmt.Sprintf("● %s %d%% of %s", line.name, int(math.Round(payCycleGoalRatio(remaining, line.goalCents)*100)), renderPayCycleDollars(line.goalCents))
+		text = truncateDisplayWidth(text, width)
+		entry := lipgloss.NewStyle().Foreground(line.color).Render(text)
+		entryWidth := lipgloss.Width(text)
+		if currentWidth > 0 && currentWidth+2+entryWidth > width {
+			lines = append(lines, current)
+			current, currentWidth = "", 0
+		}
+		if currentWidth > 0 {
+			current += "  "
+			currentWidth += 2
+		}
+		current += entry
+		currentWidth += entryWidth
+	}
+	if currentWidth > 0 {
+		lines = append(lines, current)
+	}
+	return lines
 }
 
-func payCycleDaysLeft(endDateRaw string) int {
+// payCycleDaysLeft counts calendar days from today through the cycle end,
+// inclusive, so the final day of a cycle still reports one day left. Days are
+// compared as dates rather than durations so DST changes cannot drop a day.
+func payCycleDaysLeft(endDateRaw string, now time.Time) int {
 	endDateRaw = strings.TrimSpace(endDateRaw)
 	if endDateRaw == "" {
 		return 0
 	}
-	endDate, err := time.ParseInLocation("2006-01-02", endDateRaw, time.Local)
+	endDate, err := time.Parse("2006-01-02", endDateRaw)
 	if err != nil {
 		return 0
 	}
-	now := time.Now().In(time.Local)
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
 	if endDate.Before(today) {
 		return 0
 	}
-	return int(endDate.Sub(today).Hours() / 24)
+	return int(endDate.Sub(today).Hours()/24) + 1
+}
+
+// projectPayCycleEndBalance extrapolates the average daily spend so far to
+// the end of the cycle. Days are counted inclusively, like payCycleDaysLeft,
+// and ok is false without points or a valid window.
+func projectPayCycleEndBalance(points []payCycleBurndownPoint, startDateRaw string, endDateRaw string, now time.Time) (int64, bool) {
+	if len(points) == 0 {
+		return 0, false
+	}
+	startDate, errStart := time.Parse("2006-01-02", strings.TrimSpace(startDateRaw))
+	endDate, errEnd := time.Parse("2006-01-02", strings.TrimSpace(endDateRaw))
+	if errStart != nil || errEnd != nil || endDate.Before(startDate) {
+		return 0, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	totalDays := int(endDate.Sub(startDate).Hours()/24) + 1
+	elapsedDays := int(today.Sub(startDate).Hours()/24) + 1
+	elapsedDays = max(1, min(totalDays, elapsedDays))
+
+	currentCents := payCycleRemainingAt(points, now)
+	spentCents := points[0].remainingCents - currentCents
+	dailyCents := float64(spentCents) / float64(elapsedDays)
+	return currentCents - int64(math.Round(dailyCents*float64(totalDays-elapsedDays))), true
+}
+
+// payCycleRemainingAt is the balance after the last point up to the end
+// of today, ignoring points the series carries forward into the future.
+func payCycleRemainingAt(points []payCycleBurndownPoint, now time.Time) int64 {
+	if len(points) == 0 {
+		return 0
+	}
+	endOfToday := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
+	currentCents := points[0].remainingCents
+	for _, p := range points {
+		if ts, ok := payCyclePointTime(p); ok && ts.After(endOfToday) {
+			break
+		}
+		currentCents = p.remainingCents
+	}
+	return currentCents
+}
+
+// payCyclePaceCents compares today's balance with the ideal line, which
+// runs from the goal at the start of the window to zero at its end. It is
+// positive when ahead of the line, and ok is false outside the window.
+func payCyclePaceCents(points []payCycleBurndownPoint, goalCents int64, startDateRaw string, endDateRaw string, now time.Time) (int64, bool) {
+	if len(points) == 0 || goalCents <= 0 {
+		return 0, false
+	}
+	startDate, endDate, hasWindow := parsePayCycleWindowDates(startDateRaw, endDateRaw)
+	now = now.In(time.Local)
+	if !hasWindow || now.Before(startDate) || now.After(endDate) {
+		return 0, false
+	}
+	endOfToday := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, time.Local)
+	elapsed := math.Min(1, endOfToday.Sub(startDate).Seconds()/endDate.Sub(startDate).Seconds())
+	idealCents := int64(math.Round(float64(goalCents) * (1 - elapsed)))
+	return payCycleRemainingAt(points, now) - idealCents, true
 }
 
 func payCycleTickLabel(startDate time.Time, endDate time.Time, hasWindow bool, pos int, colCount int) string {
@@ -856,18 +1118,70 @@ func payCyclePointColumn(
 	return payCycleTimeColumn(ts, startDate, endDate, hasWindow, dataCols)
 }
 
-func payCycleTodayColumn(startDate time.Time, endDate time.Time, hasWindow bool, dataCols int) int {
+func payCycleTodayColumn(startDate time.Time, endDate time.Time, hasWindow bool, dataCols int, now time.Time) int {
 	if !hasWindow {
 		return -1
 	}
-	now := time.Now().In(time.Local)
-	today := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, time.Local)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, now.Location())
 	if today.Before(startDate) || today.After(endDate) {
 		return -1
 	}
 	return payCycleTimeColumn(today, startDate, endDate, hasWindow, dataCols) + 1
 }
 
+// payCycleTodayTransactionIndex maps today's position on the burndown back
+// to the nearest plotted transaction node and returns its index in
+// transactions, or -1 when no node is plotted. inWindow is false when today
+// lies outside the cycle, matching where payCycleTodayColumn draws no line.
+func payCycleTodayTransactionIndex(points []payCycleBurndownPoint, transactions []payCycleTransactionRow, startRaw string, endRaw string, now time.Time) (int, bool) {
+	startDate, endDate, hasWindow := parsePayCycleWindowDates(startRaw, endRaw)
+	if payCycleTodayColumn(startDate, endDate, hasWindow, 2, now) < 0 {
+		return -1, false
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 12, 0, 0, 0, now.Location())
+	nearestID := ""
+	var nearest time.Duration
+	for _, point := range points {
+		if !point.hasTransaction || strings.TrimSpace(point.transactionID) == "" {
+			continue
+		}
+		ts, ok := payCyclePointTime(point)
+		if !ok {
+			continue
+		}
+		dist := ts.Sub(today)
+		if dist < 0 {
+			dist = -dist
+		}
+		// Ties go to the later node so the cursor lands on today's spend.
+		if nearestID == "" || dist <= nearest {
+			nearestID = strings.TrimSpace(point.transactionID)
+			nearest = dist
+		}
+	}
+	for i := range transactions {
+		if nearestID != "" && strings.TrimSpace(transactions[i].id) == nearestID {
+			return i, true
+		}
+	}
+	return -1, true
+}
+
+// payCycleFutureColumn is the last graph column that belongs to today. Points
+// right of it are dated after today, which only happens with clock skew or
+// pending holds, and are drawn muted. It returns -1 when no part of the
+// window lies after today, including when the cycle ends today.
+func payCycleFutureColumn(startDate time.Time, endDate time.Time, hasWindow bool, dataCols int, now time.Time) int {
+	if !hasWindow {
+		return -1
+	}
+	endOfToday := time.Date(now.Year(), now.Month(), now.Day(), 23, 59, 59, 0, now.Location())
+	if endOfToday.Before(startDate) || !endOfToday.Before(endDate) {
+		return -1
+	}
+	return payCycleTimeColumn(endOfToday, startDate, endDate, hasWindow, dataCols) + 1
+}
+
 func renderPayCycleGraphRow(
 	rowRunes []rune,
 	rowCodes []int,
@@ -878,6 +1192,7 @@ func renderPayCycleGraphRow(
 	todayStyle lipgloss.Style,
 	nodeStyle lipgloss.Style,
 	selectedNodeStyle lipgloss.Style,
+	seriesStyles []lipgloss.Style,
 ) string {
 	if maxWidth <= 0 || len(rowRunes) == 0 {
 		return ""
@@ -902,6 +1217,10 @@ func renderPayCycleGraphRow(
 		case payCycleCellNodeSelected:
 			b.WriteString(selectedNodeStyle.Render(ch))
 		default:
+			if series := rowCodes[i] - payCycleCellSeries; series >= 0 && series < len(seriesStyles) {
+				b.WriteString(seriesStyles[series].Render(ch))
+				continue
+			}
 			b.WriteString(ch)
 		}
 	}
@@ -928,7 +1247,7 @@ func drawPayCycleSegment(
 	ch rune,
 	code int,
 	xAxisRow int,
-	todayCol int,
+	futureCol int,
 	skipFutureTail bool,
 ) {
 	dx := x1 - x0
@@ -944,11 +1263,12 @@ func drawPayCycleSegment(
 		if x <= 0 {
 			continue
 		}
-		if y == xAxisRow && code == payCycleCellActual {
+		isActual := code == payCycleCellActual || code >= payCycleCellSeries
+		if y == xAxisRow && isActual {
 			continue
 		}
 		cellCode := code
-		if code == payCycleCellActual && todayCol > 0 && x > todayCol {
+		if isActual && futureCol > 0 && x > futureCol {
 			if skipFutureTail {
 				continue
 			}
@@ -964,7 +1284,8 @@ func (m model) renderPayCycleBurndownScreen(layoutWidth int) string {
 
 	paneWidth := max(30, min(40, layoutWidth/3))
 	gapWidth := 3
-	hasPane := m.payCyclePaneOpen && len(m.payCycleTransactions) > 0
+	overlay := m.payCycleOverlay && !m.payCycleMonthly
+	hasPane := m.payCyclePaneOpen && len(m.payCycleTransactions) > 0 && !overlay
 	mainBorder := lipgloss.Color("#FFFFFF")
 	paneBorder := lipgloss.Color("#FFD54A")
 	if hasPane {
@@ -1035,7 +1356,7 @@ func (m model) renderPayCycleBurndownScreen(layoutWidth int) string {
 	account, hasAccount := m.payCycleSelectedAccount()
 	accountColor := lipgloss.Color("#6CBFE6")
 	if hasAccount {
-		accountColor = transactionsCategoryColor(m.payCycleCursor)
+		accountColor = accountDisplayColor(account.color, m.payCycleCursor)
 	}
 
 	selectedTransactionID := ""
@@ -1046,20 +1367,33 @@ func (m model) renderPayCycleBurndownScreen(layoutWidth int) string {
 		}
 		selectedTransactionID = strings.TrimSpace(m.payCycleTransactions[txCursor].id)
 	}
+	series := []payCycleBurndownSeries{{
+		name:      account.displayName,
+		color:     accountColor,
+		goalCents: m.payCycleGoalCents,
+		points:    m.payCycleSeries,
+	}}
+	if overlay {
+		series = m.payCycleOverlaySeries
+	}
 	cardLines := renderPayCycleBurndownLines(
-		m.payCycleSeries,
+		series,
 		cardContentWidth,
-		m.payCycleGoalCents,
 		m.payCycleCurrentBalanceCents,
-		accountColor,
 		m.payCycleStartDate,
 		m.payCycleEndDate,
 		selectedTransactionID,
+		m.payCycleMonthly,
+		overlay,
 	)
 	if len(m.payCycleAccounts) == 0 {
+		title, empty := "pay cycle burndown", "No non-transactional accounts found."
+		if m.payCycleMonthly {
+			title, empty = "monthly budget burndown", "No transactional account found."
+		}
 		cardLines = []string{
-			lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render("pay cycle burndown"),
-			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("No non-transactional accounts found."),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render(title),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(empty),
 		}
 	}
 	cardLines = padTransactionsBodyLines(cardLines, cardBodyHeight)
@@ -1111,10 +1445,18 @@ func (m model) renderPayCycleBurndownScreen(layoutWidth int) string {
 	metaAccountStyle := lipgloss.NewStyle().Foreground(accountColor).Bold(true)
 	metaValueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
 	if hasAccount {
-		metaLines = append(metaLines, metaLabelStyle.Render("account: ")+metaAccountStyle.Render(account.displayName))
+		accountLine := metaLabelStyle.Render("account: ") + metaAccountStyle.Render(account.displayName)
+		if overlay && m.payCycleOverlayIDs[account.id] {
+			accountLine += metaLabelStyle.Render(" (compared)")
+		}
+		metaLines = append(metaLines, accountLine)
 	}
 	if strings.TrimSpace(m.payCycleStartDate) != "" && strings.TrimSpace(m.payCycleEndDate) != "" {
-		metaLines = append(metaLines, metaLabelStyle.Render("cycle: ")+metaValueStyle.Render(m.payCycleStartDate+" to "+m.payCycleEndDate))
+		windowLabel := "cycle: "
+		if m.payCycleMonthly {
+			windowLabel = "month: "
+		}
+		metaLines = append(metaLines, metaLabelStyle.Render(windowLabel)+metaValueStyle.Render(m.payCycleStartDate+" to "+m.payCycleEndDate))
 	}
 	metaBlock := ""
 	if len(metaLines) > 0 {
@@ -1125,11 +1467,18 @@ func (m model) renderPayCycleBurndownScreen(layoutWidth int) string {
 		metaBlock = lipgloss.PlaceHorizontal(layoutWidth, lipgloss.Center, strings.Join(aligned, "\n"))
 	}
 
-	hint := "↑/↓ account  enter details  g set goal  esc back"
-	if m.payCyclePromptMode != payCyclePromptNone {
-		hint = "enter save  esc back"
+	hint := payCycleHelpText
+	if m.payCycleMonthly {
+		hint = payCycleMonthlyHelpText
+	} else if overlay {
+		hint = payCycleOverlayHelpText
+	}
+	if m.payCyclePromptMode == payCyclePromptNextDate {
+		hint = payCycleDatePromptHelpText
+	} else if m.payCyclePromptMode != payCyclePromptNone {
+		hint = payCyclePromptHelpText
 	} else if hasAccount && hasPane {
-		hint = "↑/↓ account  ←/→ transaction  tab focus  g set goal  esc close"
+		hint = payCyclePaneHelpText
 	}
 	footer := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#9CA3AF")).
@@ -1154,7 +1503,11 @@ func (m model) renderPayCycleBurndownScreen(layoutWidth int) string {
 	parts = append(parts, "", mainBlock)
 
 	if m.payCyclePromptMode != payCyclePromptNone {
-		label := renderPayCyclePromptLabel(m.payCyclePromptMode, account.displayName)
+		targetName := account.displayName
+		if m.payCyclePromptMode == payCyclePromptGoal && m.payCyclePromptAccountName != "" {
+			targetName = m.payCyclePromptAccountName
+		}
+		label := renderPayCyclePromptLabel(m.payCyclePromptMode, targetName)
 		input := m.payCycleInput
 		input.Width = max(18, cardContentWidth-8)
 		promptBody := []string{
@@ -1171,6 +1524,10 @@ func (m model) renderPayCycleBurndownScreen(layoutWidth int) string {
 			Width(cardContentWidth).
 			Render(strings.Join(promptBody, "\n"))
 		parts = append(parts, "", lipgloss.PlaceHorizontal(layoutWidth, lipgloss.Center, promptCard))
+		if m.payCyclePromptMode == payCyclePromptNextDate && m.payCycleCalendarOpen {
+			overlay := renderCalendarOverlay("next pay date", calendarMonthOf(m.payCycleCalendarCursor), m.payCycleCalendarCursor)
+			parts = append(parts, "", lipgloss.PlaceHorizontal(layoutWidth, lipgloss.Center, overlay))
+		}
 	}
 	if strings.TrimSpace(m.payCycleErr) != "" {
 		parts = append(parts, "", lipgloss.PlaceHorizontal(layoutWidth, lipgloss.Center, lipgloss.NewStyle().Foreground(lipgloss.Color("#F15B5B")).Render("error: "+m.payCycleErr)))
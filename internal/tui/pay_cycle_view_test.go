@@ -0,0 +1,275 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPayCycleDaysLeft(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		end  string
+		want int
+	}{
+		{end: "2026-03-09", want: 0},
+		{end: "2026-03-10", want: 1},
+		{end: "2026-03-11", want: 2},
+		{end: "2026-04-09", want: 31},
+		{end: "", want: 0},
+		{end: "not-a-date", want: 0},
+	}
+	for _, tt := range tests {
+		if got := payCycleDaysLeft(tt.end, now); got != tt.want {
+			t.Fatalf("payCycleDaysLeft(%q) = %d, want %d", tt.end, got, tt.want)
+		}
+	}
+}
+
+func TestProjectPayCycleEndBalance(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 5, 15, 0, 0, 0, time.Local)
+	series := func(start, current int64) []payCycleBurndownPoint {
+		return []payCycleBurndownPoint{
+			{date: "2026-03-01", createdAt: "2026-03-01T00:00:00", remainingCents: start},
+			{date: "2026-03-03", createdAt: "2026-03-03T09:00:00", remainingCents: (start + current) / 2, hasTransaction: true},
+			{date: "2026-03-05", createdAt: "2026-03-05T10:00:00", remainingCents: current, hasTransaction: true},
+			{date: "2026-03-10", createdAt: "2026-03-10T23:59:59", remainingCents: current},
+		}
+	}
+	tests := []struct {
+		name   string
+		points []payCycleBurndownPoint
+		start  string
+		end    string
+		want   int64
+		wantOK bool
+	}{
+		{name: "on track", points: series(100000, 80000), start: "2026-03-01", end: "2026-03-10", want: 60000, wantOK: true},
+		{name: "overspending", points: series(50000, 10000), start: "2026-03-01", end: "2026-03-10", want: -30000, wantOK: true},
+		{name: "no spend", points: series(50000, 50000), start: "2026-03-01", end: "2026-03-10", want: 50000, wantOK: true},
+		{name: "no points", start: "2026-03-01", end: "2026-03-10"},
+		{name: "bad window", points: series(50000, 10000), start: "2026-03-10", end: "2026-03-01"},
+	}
+	for _, tt := range tests {
+		got, ok := projectPayCycleEndBalance(tt.points, tt.start, tt.end, now)
+		if ok != tt.wantOK || got != tt.want {
+			t.Fatalf("projectPayCycleEndBalance(%s) = %d, %v, want %d, %v", tt.name, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestPayCyclePaceCents(t *testing.T) {
+	t.Parallel()
+
+	points := []payCycleBurndownPoint{
+		{date: "2026-03-01", createdAt: "2026-03-01T00:00:00", remainingCents: 100000},
+		{date: "2026-03-05", createdAt: "2026-03-05T10:00:00", remainingCents: 60000, hasTransaction: true},
+	}
+	// Five of ten days have elapsed by the end of 5 March, so the ideal
+	// line sits at roughly half the goal.
+	now := time.Date(2026, 3, 5, 15, 0, 0, 0, time.Local)
+	if got, ok := payCyclePaceCents(points, 100000, "2026-03-01", "2026-03-10", now); !ok || got < 9990 || got > 10010 {
+		t.Fatalf("payCyclePaceCents(ahead) = %d, %v, want about 10000, true", got, ok)
+	}
+	if got, ok := payCyclePaceCents(points, 200000, "2026-03-01", "2026-03-10", now); !ok || got > -39990 || got < -40010 {
+		t.Fatalf("payCyclePaceCents(behind) = %d, %v, want about -40000, true", got, ok)
+	}
+	if _, ok := payCyclePaceCents(points, 100000, "2026-02-01", "2026-02-10", now); ok {
+		t.Fatal("payCyclePaceCents(past window) ok = true, want false")
+	}
+}
+
+func TestPayCycleColumnsWhenCycleEndsToday(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	start, end, ok := parsePayCycleWindowDates("2026-02-25", "2026-03-10")
+	if !ok {
+		t.Fatal("parsePayCycleWindowDates() ok = false, want true")
+	}
+	const dataCols = 40
+	todayCol := payCycleTodayColumn(start, end, ok, dataCols, now)
+	if todayCol <= 0 || todayCol > dataCols {
+		t.Fatalf("payCycleTodayColumn() = %d, want a column inside the graph", todayCol)
+	}
+	if got := payCycleFutureColumn(start, end, ok, dataCols, now); got != -1 {
+		t.Fatalf("payCycleFutureColumn() = %d, want -1 on the final day", got)
+	}
+}
+
+func TestPayCycleFutureColumnKeepsLaterTodayPointsCurrent(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local)
+	start, end, ok := parsePayCycleWindowDates("2026-03-01", "2026-03-14")
+	const dataCols = 60
+	futureCol := payCycleFutureColumn(start, end, ok, dataCols, now)
+	if futureCol <= 0 {
+		t.Fatalf("payCycleFutureColumn() = %d, want a positive column", futureCol)
+	}
+
+	// A row stamped a few minutes ahead of the local clock is still today.
+	skewed := payCycleBurndownPoint{createdAt: now.Add(5 * time.Minute).Format(time.RFC3339)}
+	if col := payCyclePointColumn(skewed, start, end, ok, dataCols) + 1; col > futureCol {
+		t.Fatalf("skewed point column = %d, want <= %d", col, futureCol)
+	}
+	later := payCycleBurndownPoint{createdAt: now.AddDate(0, 0, 3).Format(time.RFC3339)}
+	if col := payCyclePointColumn(later, start, end, ok, dataCols) + 1; col <= futureCol {
+		t.Fatalf("future point column = %d, want > %d", col, futureCol)
+	}
+	beyond := payCycleBurndownPoint{createdAt: now.AddDate(0, 1, 0).Format(time.RFC3339)}
+	if col := payCyclePointColumn(beyond, start, end, ok, dataCols); col != dataCols-1 {
+		t.Fatalf("point after cycle end column = %d, want %d", col, dataCols-1)
+	}
+}
+
+func TestTimeSeriesDateSpanDaysWithFutureDatedPoint(t *testing.T) {
+	t.Parallel()
+
+	points := []transactionsTimeSeriesPoint{
+		{date: "2026-03-01"},
+		{date: "2026-03-20"},
+		{date: "2026-03-05"},
+	}
+	if got := timeSeriesDateSpanDays(points); got != 20 {
+		t.Fatalf("timeSeriesDateSpanDays() = %d, want 20", got)
+	}
+}
+
+func TestPayCycleGoalTargetRejectsChangedSelection(t *testing.T) {
+	t.Parallel()
+
+	m := model{
+		payCycleAccounts: []payCycleAccountRow{
+			{id: "acc-1", displayName: "Holiday"},
+			{id: "acc-2", displayName: "Rainy Day"},
+		},
+		payCycleInput: textinput.New(),
+	}
+	m.openPayCycleGoalPrompt(m.payCycleAccounts[0])
+	if account, err := m.payCycleGoalTarget(); err != nil || account.id != "acc-1" {
+		t.Fatalf("payCycleGoalTarget() = %q, %v, want acc-1, nil", account.id, err)
+	}
+
+	m.payCycleCursor = 1
+	if _, err := m.payCycleGoalTarget(); err == nil {
+		t.Fatal("payCycleGoalTarget() error = nil after selection changed, want error")
+	}
+}
+
+func TestMonthlyBudgetWindowCoversCalendarMonth(t *testing.T) {
+	t.Parallel()
+
+	start, end := monthlyBudgetWindow(time.Date(2028, 2, 17, 9, 0, 0, 0, time.Local))
+	if got := start.Format("2006-01-02"); got != "2028-02-01" {
+		t.Fatalf("monthlyBudgetWindow() start = %s, want 2028-02-01", got)
+	}
+	if got := end.Format("2006-01-02"); got != "2028-02-29" {
+		t.Fatalf("monthlyBudgetWindow() end = %s, want 2028-02-29", got)
+	}
+}
+
+func TestMonthlyBudgetBurndownCountsOnlyDebits(t *testing.T) {
+	t.Parallel()
+
+	start, end := monthlyBudgetWindow(time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local))
+	rows := []payCycleTransactionRow{
+		{id: "tx-1", createdAt: "2026-03-02T08:00:00+11:00", spendCents: 4500},
+		{id: "pay", createdAt: "2026-03-03T09:00:00+11:00", spendCents: -350000},
+		{id: "tx-2", createdAt: "2026-03-04T12:00:00+11:00", spendCents: 12000},
+	}
+	points, debits, remaining := monthlyBudgetBurndown(rows, 100000, start, end)
+	if remaining != 83500 {
+		t.Fatalf("monthlyBudgetBurndown() remaining = %d, want 83500", remaining)
+	}
+	if len(debits) != 2 || debits[0].id != "tx-1" || debits[1].id != "tx-2" {
+		t.Fatalf("monthlyBudgetBurndown() debits = %+v, want tx-1 and tx-2", debits)
+	}
+	want := []int64{100000, 95500, 83500, 83500}
+	if len(points) != len(want) {
+		t.Fatalf("monthlyBudgetBurndown() returned %d points, want %d", len(points), len(want))
+	}
+	for i, cents := range want {
+		if points[i].remainingCents != cents {
+			t.Fatalf("points[%d].remainingCents = %d, want %d", i, points[i].remainingCents, cents)
+		}
+	}
+	if points[0].hasTransaction || points[len(points)-1].hasTransaction {
+		t.Fatal("window edge points should not be transaction nodes")
+	}
+}
+
+func TestPayCycleOverlayAccounts(t *testing.T) {
+	t.Parallel()
+
+	accounts := []payCycleAccountRow{
+		{id: "a", goalBalance: "500.00"},
+		{id: "b"},
+		{id: "c", goalBalance: "200.00"},
+	}
+	if got := payCycleOverlayAccounts(accounts, nil); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Fatalf("payCycleOverlayAccounts(none selected) = %v, want [0 2]", got)
+	}
+	if got := payCycleOverlayAccounts(accounts, map[string]bool{"b": true, "c": true}); !reflect.DeepEqual(got, []int{2}) {
+		t.Fatalf("payCycleOverlayAccounts(b, c) = %v, want [2]", got)
+	}
+}
+
+func TestRenderPayCycleBurndownOverlayNormalisesToGoals(t *testing.T) {
+	t.Parallel()
+
+	points := func(start, end int64) []payCycleBurndownPoint {
+		return []payCycleBurndownPoint{
+			{date: "2026-03-01", createdAt: "2026-03-01T00:00:00", remainingCents: start},
+			{date: "2026-03-14", createdAt: "2026-03-14T23:59:59", remainingCents: end},
+		}
+	}
+	series := []payCycleBurndownSeries{
+		{name: "Bills", color: lipgloss.Color("#6CBFE6"), goalCents: 100000, points: points(100000, 25000)},
+		{name: "Fun", color: lipgloss.Color("#F15B5B"), goalCents: 20000, points: points(20000, 15000)},
+	}
+	out := strings.Join(renderPayCycleBurndownLines(series, 70, 0, "2026-03-01", "2026-03-14", "", false, true), "\n")
+	for _, want := range []string{"100%", "0%", "Bills 25% of $1000", "Fun 75% of $200"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("overlay burndown missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "remaining:") {
+		t.Fatalf("overlay burndown shows a single account's remaining balance:\n%s", out)
+	}
+}
+
+func TestPayCycleTodayTransactionIndex(t *testing.T) {
+	t.Parallel()
+
+	points := []payCycleBurndownPoint{
+		{date: "2026-03-01", createdAt: "2026-03-01T00:00:00"},
+		{date: "2026-03-02", createdAt: "2026-03-02T10:00:00", hasTransaction: true, transactionID: "a"},
+		{date: "2026-03-05", createdAt: "2026-03-05T18:00:00", hasTransaction: true, transactionID: "b"},
+		{date: "2026-03-09", createdAt: "2026-03-09T08:00:00", hasTransaction: true, transactionID: "c"},
+	}
+	transactions := []payCycleTransactionRow{{id: "a"}, {id: "b"}, {id: "c"}}
+
+	now := time.Date(2026, 3, 6, 9, 0, 0, 0, time.Local)
+	idx, inWindow := payCycleTodayTransactionIndex(points, transactions, "2026-03-01", "2026-03-14", now)
+	if !inWindow || idx != 1 {
+		t.Fatalf("payCycleTodayTransactionIndex() = %d, %v, want 1, true", idx, inWindow)
+	}
+
+	later := time.Date(2026, 3, 20, 9, 0, 0, 0, time.Local)
+	if _, inWindow := payCycleTodayTransactionIndex(points, transactions, "2026-03-01", "2026-03-14", later); inWindow {
+		t.Fatal("payCycleTodayTransactionIndex(after cycle) inWindow = true, want false")
+	}
+
+	if idx, inWindow := payCycleTodayTransactionIndex(points[:1], nil, "2026-03-01", "2026-03-14", now); !inWindow || idx != -1 {
+		t.Fatalf("payCycleTodayTransactionIndex(no nodes) = %d, %v, want -1, true", idx, inWindow)
+	}
+}
@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	schemaPromptHelpText    = "a abort  w back up and wipe"
+	schemaPromptConfirmText = "y confirm  any other key back"
+)
+
+// schemaPromptModel is shown instead of the main TUI when the local database
+// was written by a newer build than this one.
+type schemaPromptModel struct {
+	found      int
+	supported  int
+	path       string
+	confirming bool
+	wipe       bool
+	width      int
+	height     int
+}
+
+// RunSchemaMismatchPrompt explains that the binary is older than the
+// database and reports whether the user confirmed backing it up and wiping
+// it. Aborting returns false with a nil error.
+func RunSchemaMismatchPrompt(found, supported int, path string) (bool, error) {
+	final, err := tea.NewProgram(
+		schemaPromptModel{found: found, supported: supported, path: path},
+		tea.WithAltScreen(),
+	).Run()
+	if err != nil {
+		return false, err
+	}
+	prompt, ok := final.(schemaPromptModel)
+	return ok && prompt.wipe, nil
+}
+
+func (m schemaPromptModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m schemaPromptModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		key := msg.String()
+		if key == "ctrl+c" {
+			return m, tea.Quit
+		}
+		if m.confirming {
+			if key == "y" || key == "Y" {
+				m.wipe = true
+				return m, tea.Quit
+			}
+			m.confirming = false
+			return m, nil
+		}
+		switch key {
+		case "w", "W":
+			m.confirming = true
+		case "a", "A", "q", "esc", "enter":
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+func (m schemaPromptModel) View() string {
+	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F15B5B")).Bold(true)
+	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+	mutedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+
+	lines := []string{
+		titleStyle.Render("this giddyup build is older than its database"),
+		"",
+		textStyle.Render(fmt.Sprintf("database schema version %d, this build supports up to %d.", m.found, m.supported)),
+		textStyle.Render("it was most likely written by a newer giddyup that has since been replaced."),
+		"",
+		textStyle.Render("to keep your data, abort and install the newer build again."),
+		textStyle.Render("to start fresh, back up and wipe: the database is moved aside and"),
+		textStyle.Render("accounts and transactions are synced again from Up."),
+	}
+	if strings.TrimSpace(m.path) != "" {
+		lines = append(lines, "", mutedStyle.Render("database: "+m.path))
+	}
+	lines = append(lines, "")
+	if m.confirming {
+		lines = append(lines,
+			titleStyle.Render("back up and wipe the local database?"),
+			mutedStyle.Render(schemaPromptConfirmText),
+		)
+	} else {
+		lines = append(lines, mutedStyle.Render(schemaPromptHelpText))
+	}
+
+	card := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#F15B5B")).
+		Padding(1, 2).
+		Render(strings.Join(lines, "\n"))
+	if m.width <= 0 || m.height <= 0 {
+		return card
+	}
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, card)
+}
@@ -0,0 +1,89 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var sparklineGlyphs = []rune("▁▂▃▄▅▆▇█")
+
+// renderSparkline draws values as a one-line bar strip at most width columns
+// wide. Longer series are summed into equal buckets; zero buckets stay blank
+// so quiet stretches read as gaps.
+func renderSparkline(values []int64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	cols := min(width, len(values))
+	buckets := make([]int64, cols)
+	var peak int64
+	for i := range buckets {
+		lo := i * len(values) / cols
+		hi := (i + 1) * len(values) / cols
+		for _, v := range values[lo:hi] {
+			buckets[i] += max(0, v)
+		}
+		peak = max(peak, buckets[i])
+	}
+
+	var b strings.Builder
+	for _, v := range buckets {
+		if v <= 0 || peak <= 0 {
+			b.WriteRune(' ')
+			continue
+		}
+		level := int(v * int64(len(sparklineGlyphs)-1) / peak)
+		b.WriteRune(sparklineGlyphs[level])
+	}
+	return b.String()
+}
+
+// queryDailyTransactionCounts counts matching transactions per local
+// calendar day, from the first active day to the last, with empty days as
+// zero.
+func queryDailyTransactionCounts(ctx context.Context, db *sql.DB, whereSQL string, args []any) ([]int64, error) {
+	// created_at carries the local offset; take the wall-clock date rather
+	// than letting date() shift it to UTC.
+	q := fmt.Sprintf(
+		`SELECT substr(t.created_at, 1, 10) AS day, COUNT(*)
+		 FROM transactions t
+		 WHERE %s
+		 GROUP BY day
+		 ORDER BY day`,
+		whereSQL,
+	)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var first time.Time
+	counts := make([]int64, 0, 64)
+	for rows.Next() {
+		var day string
+		var count int64
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, err
+		}
+		d, ok := parseTimeSeriesDate(day)
+		if !ok {
+			continue
+		}
+		if first.IsZero() {
+			first = d
+		}
+		idx := int(d.Sub(first).Round(24*time.Hour).Hours() / 24)
+		for len(counts) <= idx {
+			counts = append(counts, 0)
+		}
+		counts[idx] += count
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
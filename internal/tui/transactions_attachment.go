@@ -0,0 +1,28 @@
+package tui
+
+import "strings"
+
+// transactionsAttachmentSQL matches transactions with a receipt attached in
+// the Up app.
+const transactionsAttachmentSQL = "t.attachment_id IS NOT NULL"
+
+// transactionsAttachmentGlyph marks table rows that have a receipt.
+const transactionsAttachmentGlyph = "▤"
+
+// isTransactionAttachmentValue reports whether a has: value asks for an
+// attachment; "receipt" is accepted too.
+func isTransactionAttachmentValue(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "attachment", "receipt":
+		return true
+	default:
+		return false
+	}
+}
+
+func formatTransactionReceipt(attached bool) string {
+	if attached {
+		return "attached"
+	}
+	return "none"
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// transactionsBalanceWidth is the width of the running balance column,
+// including its leading gap.
+const transactionsBalanceWidth = 2 + 11
+
+type loadRunningBalancesMsg struct {
+	balances map[string]int64
+	err      error
+}
+
+// loadRunningBalancesCmd fetches balances for the rows on screen. Results are
+// keyed by transaction id, so a reply that arrives after the page changed
+// simply fails to cover the new rows and the column stays hidden.
+func (m model) loadRunningBalancesCmd() tea.Cmd {
+	if !m.transactionsShowBalance || len(m.transactionsRows) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(m.transactionsRows))
+	for _, row := range m.transactionsRows {
+		ids = append(ids, row.id)
+	}
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadRunningBalancesMsg{err: errors.New("database is not initialized")}
+		}
+		balances, err := queryRunningBalances(context.Background(), m.db, ids)
+		return loadRunningBalancesMsg{balances: balances, err: err}
+	}
+}
+
+// queryRunningBalances works back from each account's current balance: the
+// balance after a transaction is the account balance less every later
+// transaction on that same account. Search and transfer filters are ignored
+// so hidden transactions still count. Transactions whose account has no
+// stored balance are left out of the result.
+func queryRunningBalances(ctx context.Context, db *sql.DB, ids []string) (map[string]int64, error) {
+	out := make(map[string]int64, len(ids))
+	if len(ids) == 0 {
+		return out, nil
+	}
+	placeholders := make([]string, 0, len(ids))
+	args := make([]any, 0, len(ids))
+	for _, id := range ids {
+		placeholders = append(placeholders, "?")
+		args = append(args, id)
+	}
+	q := fmt.Sprintf(
+		`SELECT
+			t.id,
+			a.balance_value_in_base_units - COALESCE((
+				SELECT SUM(later.amount_value_in_base_units)
+				FROM transactions later
+				WHERE later.account_id = t.account_id
+				  AND later.is_active = 1
+				  AND (later.created_at > t.created_at
+				    OR (later.created_at = t.created_at AND later.id > t.id))
+			), 0)
+		 FROM transactions t
+		 JOIN accounts a ON a.id = t.account_id
+		 WHERE t.id IN (%s)`,
+		strings.Join(placeholders, ", "),
+	)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var balance int64
+		if err := rows.Scan(&id, &balance); err != nil {
+			return nil, err
+		}
+		out[id] = balance
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// runningBalanceColumn formats the balance after each row. It reports false
+// when any row's balance is unknown, since a column with gaps could be read
+// as belonging to a neighbouring row's account.
+func runningBalanceColumn(rows []transactionPreviewRow, balances map[string]int64) ([]string, bool) {
+	if len(rows) == 0 || len(balances) == 0 {
+		return nil, false
+	}
+	out := make([]string, 0, len(rows))
+	for _, row := range rows {
+		cents, ok := balances[row.id]
+		if !ok {
+			return nil, false
+		}
+		out = append(out, fmt.Sprintf("%.2f", float64(cents)/100.0))
+	}
+	return out, true
+}
@@ -0,0 +1,151 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+// categoryBudgetKeyPrefix prefixes the app_config key holding a category's
+// monthly budget in dollars. An empty value means no budget.
+const categoryBudgetKeyPrefix = "budget.category."
+
+// averageMonthDays converts day counts to months when a budget is scaled to
+// a range spanning more than one calendar month.
+const averageMonthDays = 365.25 / 12
+
+type loadCategoryBudgetsMsg struct {
+	budgets map[string]int64
+	err     error
+}
+
+type saveCategoryBudgetMsg struct {
+	category string
+	cents    int64
+	err      error
+}
+
+func categoryBudgetKey(category string) string {
+	return categoryBudgetKeyPrefix + strings.TrimSpace(category)
+}
+
+func (m model) loadCategoryBudgetsCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadCategoryBudgetsMsg{err: errors.New("database is not initialized")}
+		}
+		values, err := storage.NewAppConfigRepo(m.db).ListByPrefix(context.Background(), categoryBudgetKeyPrefix)
+		if err != nil {
+			return loadCategoryBudgetsMsg{err: err}
+		}
+		return loadCategoryBudgetsMsg{budgets: parseCategoryBudgets(values)}
+	}
+}
+
+// saveCategoryBudgetCmd stores a category's monthly budget; zero clears it.
+func (m model) saveCategoryBudgetCmd(category string, cents int64) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveCategoryBudgetMsg{category: category, err: errors.New("database is not initialized")}
+		}
+		value := ""
+		if cents > 0 {
+			value = fmt.Sprintf("%.2f", float64(cents)/100.0)
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			categoryBudgetKey(category): value,
+		})
+		return saveCategoryBudgetMsg{category: category, cents: cents, err: err}
+	}
+}
+
+// parseCategoryBudgets maps category ids to monthly budgets in cents,
+// skipping cleared and unreadable values.
+func parseCategoryBudgets(values map[string]string) map[string]int64 {
+	out := make(map[string]int64, len(values))
+	for key, value := range values {
+		category := strings.TrimPrefix(key, categoryBudgetKeyPrefix)
+		cents, err := parseCategoryBudgetCents(value)
+		if category == "" || err != nil || cents <= 0 {
+			continue
+		}
+		out[category] = cents
+	}
+	return out
+}
+
+// parseCategoryBudgetCents parses a budget entered in dollars. An empty
+// value clears the budget and returns 0.
+func parseCategoryBudgetCents(raw string) (int64, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("budget is invalid")
+	}
+	return int64(math.Round(n * 100)), nil
+}
+
+// categoryBudgetsForRange scales monthly budgets to the selected date range.
+// A range inside one calendar month gets the monthly budget as is; a longer
+// one gets it pro rata by day. Open-ended ranges have no budgets, so the
+// chart keeps its usual colours there.
+func categoryBudgetsForRange(monthly map[string]int64, fromDigits, toDigits string, now time.Time) map[string]int64 {
+	if len(monthly) == 0 || len(strings.TrimSpace(fromDigits)) != 8 {
+		return nil
+	}
+	fromRaw, err := parseTransactionsDateDigits(fromDigits)
+	if err != nil {
+		return nil
+	}
+	from, err := time.ParseInLocation("2006-01-02", fromRaw, time.Local)
+	if err != nil {
+		return nil
+	}
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if len(strings.TrimSpace(toDigits)) == 8 {
+		toRaw, err := parseTransactionsDateDigits(toDigits)
+		if err != nil {
+			return nil
+		}
+		to, err = time.ParseInLocation("2006-01-02", toRaw, time.Local)
+		if err != nil {
+			return nil
+		}
+	}
+	if to.Before(from) {
+		return nil
+	}
+	months := 1.0
+	if from.Year() != to.Year() || from.Month() != to.Month() {
+		days := math.Round(to.Sub(from).Hours()/24) + 1
+		months = days / averageMonthDays
+	}
+	out := make(map[string]int64, len(monthly))
+	for category, cents := range monthly {
+		out[category] = int64(math.Round(float64(cents) * months))
+	}
+	return out
+}
+
+// chartCategoryBudgets returns budgets scaled to the selected range, or nil
+// while the chart groups by merchant or parent category.
+func (m model) chartCategoryBudgets() map[string]int64 {
+	if m.transactionsChartByMerchant || m.transactionsChartShowsParents() {
+		return nil
+	}
+	return categoryBudgetsForRange(m.transactionsCategoryBudgets, m.transactionsFromDate, m.transactionsToDate, time.Now())
+}
+
+func formatCategoryBudget(cents int64) string {
+	return "$" + formatMoneyDisplay(fmt.Sprintf("%.2f", float64(cents)/100.0))
+}
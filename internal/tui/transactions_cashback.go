@@ -0,0 +1,31 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// transactionsCashbackSQL matches transactions Up paid cashback on.
+const transactionsCashbackSQL = "t.cashback_amount_value_in_base_units IS NOT NULL"
+
+// cashbackTotalSQL sums cashback over the transactions whereSQL selects.
+func cashbackTotalSQL(whereSQL string) string {
+	return fmt.Sprintf(
+		`SELECT COALESCE(SUM(t.cashback_amount_value_in_base_units), 0)
+		 FROM transactions t
+		 WHERE %s AND %s`,
+		whereSQL,
+		transactionsCashbackSQL,
+	)
+}
+
+// queryCashbackTotal returns the cashback received, in cents, across the
+// filtered transactions.
+func queryCashbackTotal(ctx context.Context, db *sql.DB, whereSQL string, args []any) (int64, error) {
+	var cents int64
+	if err := db.QueryRowContext(ctx, cashbackTotalSQL(whereSQL), args...).Scan(&cents); err != nil {
+		return 0, err
+	}
+	return cents, nil
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+type saveCategoryAliasMsg struct {
+	categoryID string
+	name       string
+	err        error
+}
+
+// displayCategory is the category as rendered: its alias when one is set.
+// Search, budgets and chart drill-downs keep using the ID.
+func displayCategory(aliases map[string]string, categoryID string) string {
+	if name := strings.TrimSpace(aliases[strings.TrimSpace(categoryID)]); name != "" {
+		return name
+	}
+	return categoryID
+}
+
+func loadCategoryAliases(ctx context.Context, db *sql.DB) (map[string]string, error) {
+	return storage.NewCategoryAliasesRepo(db).List(ctx)
+}
+
+// saveCategoryAliasCmd stores a display name for a category, or removes it
+// when name is blank.
+func (m model) saveCategoryAliasCmd(categoryID, name string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveCategoryAliasMsg{err: errors.New("database is not initialized")}
+		}
+		if categoryID == "" {
+			return saveCategoryAliasMsg{err: errors.New("no category selected")}
+		}
+		repo := storage.NewCategoryAliasesRepo(m.db)
+		var err error
+		if name == "" {
+			err = repo.Delete(context.Background(), categoryID)
+		} else {
+			err = repo.Upsert(context.Background(), categoryID, name)
+		}
+		return saveCategoryAliasMsg{categoryID: categoryID, name: name, err: err}
+	}
+}
+
+func (m *model) closeCategoryAliasPrompt() {
+	m.transactionsCategoryAliasActive = false
+	m.transactionsCategoryAliasInput.SetValue("")
+	m.transactionsCategoryAliasInput.Blur()
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type copyTransactionMsg struct {
+	err error
+}
+
+// formatTransactionSummary is the plain-text summary copied with y: one
+// "label: value" line per field, with "-" for blanks.
+func formatTransactionSummary(d transactionDetailFields) string {
+	lines := []struct {
+		label string
+		value string
+	}{
+		{"date", formatTransactionDate(d.createdAt)},
+		{"merchant", d.merchant},
+		{"amount", d.amount},
+		{"category", d.category},
+		{"raw text", d.rawText},
+	}
+	out := make([]string, 0, len(lines))
+	for _, line := range lines {
+		value := strings.TrimSpace(line.value)
+		if value == "" {
+			value = "-"
+		}
+		out = append(out, line.label+": "+value)
+	}
+	return strings.Join(out, "\n")
+}
+
+// selectedTransactionDetails returns the transaction shown in whichever
+// details pane is open, and false when none is.
+func (m model) selectedTransactionDetails() (transactionDetailFields, bool) {
+	switch {
+	case m.transactionsViewMode == transactionsViewModeChart &&
+		m.transactionsChartPaneOpen &&
+		m.transactionsChartPaneMode == transactionsChartPaneModeDetails:
+		idx := findCategoryTransactionRowIndex(m.transactionsChartPaneRows, m.transactionsChartPaneDetailTxID)
+		if idx < 0 {
+			return transactionDetailFields{}, false
+		}
+		r := m.transactionsChartPaneRows[idx]
+		return transactionDetailFields{id: r.id, createdAt: r.createdAt, merchant: r.merchant, amount: r.amountValue, category: r.categoryID, rawText: r.rawText, localNote: r.localNote}, true
+	case m.transactionsViewMode == transactionsViewModeTable && m.transactionsPaneOpen:
+		if m.transactionsCursor < 0 || m.transactionsCursor >= len(m.transactionsRows) {
+			return transactionDetailFields{}, false
+		}
+		r := m.transactionsRows[m.transactionsCursor]
+		return transactionDetailFields{id: r.id, createdAt: r.createdAt, merchant: r.merchant, amount: r.amountValue, category: r.categoryID, rawText: r.rawText, localNote: r.localNote}, true
+	case m.transactionsViewMode == transactionsViewModeTimeSeries &&
+		m.transactionsPaneOpen &&
+		m.transactionsTimeSeriesGrouping == timeSeriesGroupTransaction &&
+		!(m.transactionsTimeSeriesRoundUps && m.transactionsRoundUpsMonthly) &&
+		len(m.transactionsTimeSeries) > 0:
+		idx := m.transactionsTimeSeriesSelection
+		if idx < 0 || idx >= len(m.transactionsTimeSeries) {
+			idx = len(m.transactionsTimeSeries) - 1
+		}
+		p := m.transactionsTimeSeries[idx]
+		return transactionDetailFields{id: p.id, createdAt: p.createdAt, merchant: p.merchant, amount: p.amountValue, category: p.categoryID, rawText: p.rawText, localNote: p.localNote}, true
+	}
+	return transactionDetailFields{}, false
+}
+
+func copyTransactionCmd(summary string) tea.Cmd {
+	return func() tea.Msg {
+		return copyTransactionMsg{err: clipboard.WriteAll(summary)}
+	}
+}
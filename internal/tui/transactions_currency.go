@@ -0,0 +1,31 @@
+package tui
+
+import "strings"
+
+// transactionsCurrencySQL matches transactions made in one foreign currency.
+const transactionsCurrencySQL = "UPPER(TRIM(t.foreign_amount_currency_code)) = ?"
+
+// parseTransactionCurrencyValue reads a three-letter currency code such as
+// USD, ignoring case.
+func parseTransactionCurrencyValue(value string) (string, bool) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	if len(code) != 3 {
+		return "", false
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", false
+		}
+	}
+	return code, true
+}
+
+// formatForeignAmount shows the amount charged in the original currency,
+// e.g. "-12.50 USD". It is empty for transactions made in dollars.
+func formatForeignAmount(value, currencyCode string) string {
+	currencyCode = strings.ToUpper(strings.TrimSpace(currencyCode))
+	if currencyCode == "" {
+		return ""
+	}
+	return strings.TrimSpace(strings.TrimSpace(value) + " " + currencyCode)
+}
@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+// txDefaultRangeKey holds the date range new sessions start on: a quick
+// range index such as "2", or "custom:YYYYMMDD..YYYYMMDD" for a fixed
+// window. It supersedes txDefaultQuickRangeKey, which is still read when
+// this key is unset.
+const txDefaultRangeKey = "transactions.default_range"
+
+const transactionsCustomDefaultPrefix = "custom:"
+
+// transactionsDefaultRange is a quick range when quickIdx is in range, and
+// a fixed from/to window when quickIdx is -1.
+type transactionsDefaultRange struct {
+	quickIdx int
+	fromDate string
+	toDate   string
+}
+
+type saveTransactionsDefaultRangeMsg struct {
+	label string
+	err   error
+}
+
+func (d transactionsDefaultRange) isCustom() bool {
+	return d.quickIdx < 0
+}
+
+// label names the range for feedback, e.g. "6m" or "2024-01-01..2024-03-31".
+func (d transactionsDefaultRange) label() string {
+	if !d.isCustom() {
+		return transactionsQuickRanges()[d.quickIdx].label
+	}
+	from, _ := parseTransactionsDateDigits(d.fromDate)
+	to, _ := parseTransactionsDateDigits(d.toDate)
+	return from + ".." + to
+}
+
+func formatTransactionsDefaultRange(d transactionsDefaultRange) string {
+	if d.isCustom() {
+		return transactionsCustomDefaultPrefix + d.fromDate + ".." + d.toDate
+	}
+	return strconv.Itoa(d.quickIdx)
+}
+
+// parseTransactionsDefaultRange reads a stored default. Quick indexes must
+// fall inside transactionsQuickRanges and custom windows must be valid
+// dates in order.
+func parseTransactionsDefaultRange(raw string) (transactionsDefaultRange, bool) {
+	raw = strings.TrimSpace(raw)
+	if window, ok := strings.CutPrefix(raw, transactionsCustomDefaultPrefix); ok {
+		from, to, found := strings.Cut(window, "..")
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if !found || (from == "" && to == "") || validateTransactionsDateRange(from, to) != nil {
+			return transactionsDefaultRange{}, false
+		}
+		return transactionsDefaultRange{quickIdx: -1, fromDate: from, toDate: to}, true
+	}
+	idx, err := strconv.Atoi(raw)
+	if err != nil || idx < 0 || idx >= len(transactionsQuickRanges()) {
+		return transactionsDefaultRange{}, false
+	}
+	return transactionsDefaultRange{quickIdx: idx}, true
+}
+
+// loadTransactionsDefaultRange returns the configured default range,
+// falling back to the older quick range label and then the built-in 3m.
+func loadTransactionsDefaultRange(ctx context.Context, repo *storage.AppConfigRepo) (transactionsDefaultRange, error) {
+	raw, found, err := repo.Get(ctx, txDefaultRangeKey)
+	if err != nil {
+		return transactionsDefaultRange{}, err
+	}
+	if found {
+		if d, ok := parseTransactionsDefaultRange(raw); ok {
+			return d, nil
+		}
+	}
+	legacy, _, err := repo.Get(ctx, txDefaultQuickRangeKey)
+	if err != nil {
+		return transactionsDefaultRange{}, err
+	}
+	return transactionsDefaultRange{quickIdx: defaultRangeIndexFromValue(legacy)}, nil
+}
+
+// currentTransactionsDefaultRange captures the filters screen's range so it
+// can be saved as the default.
+func (m model) currentTransactionsDefaultRange() (transactionsDefaultRange, error) {
+	if m.transactionsFilterMode == transactionsFilterModeQuick {
+		if m.transactionsQuickIdx < 0 || m.transactionsQuickIdx >= len(transactionsQuickRanges()) {
+			return transactionsDefaultRange{}, fmt.Errorf("pick a quick range first")
+		}
+		return transactionsDefaultRange{quickIdx: m.transactionsQuickIdx}, nil
+	}
+	from := strings.TrimSpace(m.transactionsFromDate)
+	to := strings.TrimSpace(m.transactionsToDate)
+	if from == "" && to == "" {
+		return transactionsDefaultRange{}, fmt.Errorf("enter a date range first")
+	}
+	if err := validateTransactionsDateRange(from, to); err != nil {
+		return transactionsDefaultRange{}, err
+	}
+	return transactionsDefaultRange{quickIdx: -1, fromDate: from, toDate: to}, nil
+}
+
+func (m model) saveTransactionsDefaultRangeCmd(d transactionsDefaultRange) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveTransactionsDefaultRangeMsg{err: fmt.Errorf("database is not initialized")}
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txDefaultRangeKey: formatTransactionsDefaultRange(d),
+		})
+		return saveTransactionsDefaultRangeMsg{label: d.label(), err: err}
+	}
+}
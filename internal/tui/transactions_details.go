@@ -0,0 +1,78 @@
+package tui
+
+import "github.com/charmbracelet/lipgloss"
+
+// transactionsDetailsFullRows is the pane height the full field set needs:
+// the title, twelve fields and tags, each on one line.
+const transactionsDetailsFullRows = 14
+
+// transactionDetailFields is what a details pane shows for one transaction,
+// whichever list it was picked from.
+type transactionDetailFields struct {
+	id          string
+	account     string
+	createdAt   string
+	amount      string
+	category    string
+	rawText     string
+	status      string
+	message     string
+	description string
+	merchant    string
+	cardMethod  string
+	noteText    string
+	localNote   string
+	foreign     string
+	receipt     bool
+}
+
+// transactionsDetailsCompact picks the field set for a pane. Short panes
+// start compact and tall ones full; toggled swaps to the other set.
+func transactionsDetailsCompact(toggled bool, paneInnerHeight int) bool {
+	return (paneInnerHeight < transactionsDetailsFullRows) != toggled
+}
+
+// renderTransactionDetailFields renders either the compact set (amount,
+// date, merchant, category, plus your note when there is one) or every
+// field.
+func renderTransactionDetailFields(
+	d transactionDetailFields,
+	compact bool,
+	valueWidth int,
+	labelStyle lipgloss.Style,
+	valueStyle lipgloss.Style,
+) []string {
+	if compact {
+		lines := renderDetailLines("amount", d.amount, valueWidth, labelStyle, valueStyle)
+		lines = append(lines, renderForeignAmountLines(d.foreign, valueWidth, labelStyle, valueStyle)...)
+		lines = append(lines, renderDetailLines("date", formatTransactionDate(d.createdAt), valueWidth, labelStyle, valueStyle)...)
+		lines = append(lines, renderDetailLines("merchant", d.merchant, valueWidth, labelStyle, valueStyle)...)
+		lines = append(lines, renderDetailLines("category", d.category, valueWidth, labelStyle, valueStyle)...)
+		if d.localNote == "" {
+			return lines
+		}
+		return append(lines, renderDetailLines("my note", d.localNote, valueWidth, labelStyle, valueStyle)...)
+	}
+	lines := renderDetailLines("account", d.account, valueWidth, labelStyle, valueStyle)
+	lines = append(lines, renderDetailLines("time", formatTransactionTime(d.createdAt), valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("category", d.category, valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderForeignAmountLines(d.foreign, valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("raw text", d.rawText, valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("status", d.status, valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("message", d.message, valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("description", d.description, valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("merchant", d.merchant, valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("card method", d.cardMethod, valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("note text", d.noteText, valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("my note", d.localNote, valueWidth, labelStyle, valueStyle)...)
+	return append(lines, renderDetailLines("receipt", formatTransactionReceipt(d.receipt), valueWidth, labelStyle, valueStyle)...)
+}
+
+// renderForeignAmountLines adds the original-currency amount, and nothing
+// for transactions made in dollars.
+func renderForeignAmountLines(foreign string, valueWidth int, labelStyle, valueStyle lipgloss.Style) []string {
+	if foreign == "" {
+		return nil
+	}
+	return renderDetailLines("foreign amount", foreign, valueWidth, labelStyle, valueStyle)
+}
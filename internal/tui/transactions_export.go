@@ -0,0 +1,323 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// TransactionsFilter selects transactions the same way the transactions view
+// does: an optional YYYYMMDD date window, the search bar syntax, whether
+// internal transfers are kept, and an optional credits (>0) or debits (<0)
+// only restriction.
+type TransactionsFilter struct {
+	FromDigits      string
+	ToDigits        string
+	IncludeInternal bool
+	SearchQuery     string
+	AmountSign      int
+	// ChartBar, when set, keeps only one spend chart bar: a category, or a
+	// parent category or merchant group with ChartByParent or
+	// ChartByMerchant. Ignored merchants drop out, as they do on the chart.
+	ChartBar         string
+	ChartByMerchant  bool
+	ChartByParent    bool
+	IgnoredMerchants []string
+}
+
+var transactionsCSVHeader = []string{"date", "merchant", "description", "amount", "category", "account", "status"}
+
+type transactionExportRecord struct {
+	Date        string `json:"date"`
+	Merchant    string `json:"merchant"`
+	Description string `json:"description"`
+	Amount      string `json:"amount"`
+	Category    string `json:"category"`
+	Account     string `json:"account"`
+	Status      string `json:"status"`
+}
+
+type exportTransactionsMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+func (m model) exportTransactionsCmd() tea.Cmd {
+	filter := m.transactionsFilter()
+	return func() tea.Msg {
+		if m.db == nil {
+			return exportTransactionsMsg{err: errors.New("database is not initialized")}
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return exportTransactionsMsg{err: fmt.Errorf("resolve home directory: %w", err)}
+		}
+		path := filepath.Join(home, fmt.Sprintf("giddyup-export-%s.csv", time.Now().Format("20060102-150405")))
+		count, err := ExportTransactionsCSV(m.db, filter, path)
+		return exportTransactionsMsg{path: path, count: count, err: err}
+	}
+}
+
+// exportChartBarCmd exports just the transactions behind one chart bar, the
+// same set its drill-down pane lists.
+func (m model) exportChartBarCmd(bar string) tea.Cmd {
+	filter := m.transactionsFilter()
+	filter.ChartBar = bar
+	filter.ChartByMerchant = m.transactionsChartByMerchant
+	filter.ChartByParent = m.transactionsChartShowsParents()
+	return func() tea.Msg {
+		if m.db == nil {
+			return exportTransactionsMsg{err: errors.New("database is not initialized")}
+		}
+		ignored, err := loadIgnoredMerchants(context.Background(), m.db)
+		if err != nil {
+			return exportTransactionsMsg{err: err}
+		}
+		filter.IgnoredMerchants = ignored
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return exportTransactionsMsg{err: fmt.Errorf("resolve home directory: %w", err)}
+		}
+		name := fmt.Sprintf("giddyup-export-%s-%s.csv", exportFileSlug(bar), time.Now().Format("20060102-150405"))
+		path := filepath.Join(home, name)
+		count, err := ExportTransactionsCSV(m.db, filter, path)
+		return exportTransactionsMsg{path: path, count: count, err: err}
+	}
+}
+
+// exportFileSlug makes a chart bar label safe for a file name.
+func exportFileSlug(label string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(label)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if slug == "" {
+		return "selection"
+	}
+	return slug
+}
+
+// ExportTransactionsCSV writes every transaction matching filter to a new CSV
+// file at path and returns the number of rows written. An empty result still
+// produces a file with just the header row.
+func ExportTransactionsCSV(db *sql.DB, filter TransactionsFilter, path string) (int, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return 0, err
+	}
+	count, err := WriteTransactionsCSV(context.Background(), db, filter, f)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+		return 0, err
+	}
+	return count, nil
+}
+
+// WriteTransactionsCSV streams the transactions matching filter to w, oldest
+// first, and returns the number of rows written.
+func WriteTransactionsCSV(ctx context.Context, db *sql.DB, filter TransactionsFilter, w io.Writer) (int, error) {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(transactionsCSVHeader); err != nil {
+		return 0, err
+	}
+	count := 0
+	err := scanFilteredTransactions(ctx, db, filter, func(row transactionPreviewRow) error {
+		count++
+		return cw.Write([]string{
+			formatTransactionDate(row.createdAt),
+			row.merchant,
+			row.description,
+			row.amountValue,
+			transactionExportCategory(row.categoryID),
+			row.accountName,
+			row.status,
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// WriteTransactionsJSON streams the transactions matching filter to w as a
+// JSON array, oldest first, using the same fields as the CSV export.
+func WriteTransactionsJSON(ctx context.Context, db *sql.DB, filter TransactionsFilter, w io.Writer) (int, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return 0, err
+	}
+	count := 0
+	err := scanFilteredTransactions(ctx, db, filter, func(row transactionPreviewRow) error {
+		raw, err := json.Marshal(transactionExportRecord{
+			Date:        formatTransactionDate(row.createdAt),
+			Merchant:    row.merchant,
+			Description: row.description,
+			Amount:      row.amountValue,
+			Category:    transactionExportCategory(row.categoryID),
+			Account:     row.accountName,
+			Status:      row.status,
+		})
+		if err != nil {
+			return err
+		}
+		sep := ",\n  "
+		if count == 0 {
+			sep = "\n  "
+		}
+		count++
+		_, err = io.WriteString(w, sep+string(raw))
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	tail := "\n]\n"
+	if count == 0 {
+		tail = "]\n"
+	}
+	if _, err := io.WriteString(w, tail); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// ParseTransactionsDate converts a YYYY-MM-DD date into the YYYYMMDD digits
+// TransactionsFilter expects, validating it like the date filter does.
+func ParseTransactionsDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if len(value) != 10 || value[4] != '-' || value[7] != '-' {
+		return "", fmt.Errorf("date must be YYYY-MM-DD")
+	}
+	digits := value[0:4] + value[5:7] + value[8:10]
+	if _, err := parseTransactionsDateDigits(digits); err != nil {
+		return "", err
+	}
+	return digits, nil
+}
+
+// transactionsFilter captures the filters currently applied on the
+// transactions screen.
+func (m model) transactionsFilter() TransactionsFilter {
+	return TransactionsFilter{
+		FromDigits:      m.transactionsFromDate,
+		ToDigits:        m.transactionsToDate,
+		IncludeInternal: m.transactionsIncludeInternal,
+		SearchQuery:     m.transactionsSearchApplied,
+		AmountSign:      m.transactionsAmountSign,
+	}
+}
+
+func transactionsFilterWhere(filter TransactionsFilter) ([]string, []any, error) {
+	where := []string{"t.is_active = 1"}
+	args := make([]any, 0, 8)
+	if !filter.IncludeInternal {
+		where = append(where, "t.transfer_account_id IS NULL")
+	}
+	if clause := transactionsAmountSignClause(filter.AmountSign); clause != "" {
+		where = append(where, clause)
+	}
+	if err := appendTransactionsSearchClauses(strings.TrimSpace(filter.SearchQuery), &where, &args); err != nil {
+		return nil, nil, err
+	}
+	if err := appendTransactionsDateClauses(filter.FromDigits, filter.ToDigits, &where, &args); err != nil {
+		return nil, nil, err
+	}
+	if strings.TrimSpace(filter.ChartBar) != "" {
+		appendChartBarClause(filter.ChartBar, filter.ChartByMerchant, filter.ChartByParent, &where, &args)
+		appendIgnoredMerchantsClause(filter.IgnoredMerchants, &where, &args)
+	}
+	return where, args, nil
+}
+
+// scanFilteredTransactions calls fn for each matching transaction without
+// paging, so callers can stream result sets of any size.
+func scanFilteredTransactions(ctx context.Context, db *sql.DB, filter TransactionsFilter, fn func(transactionPreviewRow) error) error {
+	where, args, err := transactionsFilterWhere(filter)
+	if err != nil {
+		return err
+	}
+	q := fmt.Sprintf(
+		`SELECT
+			t.created_at,
+			t.id,
+			COALESCE(NULLIF(t.description_norm, ''), COALESCE(t.description, '')),
+			t.amount_value,
+			COALESCE(
+				NULLIF(t.merchant_norm, ''),
+				COALESCE(
+					NULLIF(t.raw_text_norm, ''),
+					NULLIF(t.description_norm, ''),
+					COALESCE(t.raw_text, t.description, '')
+				)
+			),
+			t.status,
+			COALESCE(t.category_id, ''),
+			COALESCE(a.display_name, '')
+		 FROM transactions t
+		 LEFT JOIN accounts a ON a.id = t.account_id
+		 WHERE %s
+		 ORDER BY t.created_at ASC, t.id ASC`,
+		strings.Join(where, " AND "),
+	)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var r transactionPreviewRow
+		if err := rows.Scan(
+			&r.createdAt,
+			&r.id,
+			&r.description,
+			&r.amountValue,
+			&r.merchant,
+			&r.status,
+			&r.categoryID,
+			&r.accountName,
+		); err != nil {
+			return err
+		}
+		if filter.ChartByMerchant && strings.TrimSpace(filter.ChartBar) != "" &&
+			merchantGroupKey(r.merchant) != merchantGroupKey(filter.ChartBar) {
+			continue
+		}
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func transactionExportCategory(categoryID string) string {
+	if category := strings.TrimSpace(categoryID); category != "" {
+		return category
+	}
+	return "uncategorized"
+}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+const (
+	timeSeriesGroupTransaction = iota
+	timeSeriesGroupDay
+	timeSeriesGroupMonth
+	timeSeriesGroupCount
+)
+
+var timeSeriesGroupLabels = []string{"transaction", "day", "month"}
+
+func timeSeriesGroupLabel(grouping int) string {
+	if grouping < 0 || grouping >= len(timeSeriesGroupLabels) {
+		return timeSeriesGroupLabels[timeSeriesGroupTransaction]
+	}
+	return timeSeriesGroupLabels[grouping]
+}
+
+// parseTimeSeriesGroup reads a stored granularity, falling back to plotting
+// individual transactions for anything unrecognised.
+func parseTimeSeriesGroup(raw string) int {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	for i, label := range timeSeriesGroupLabels {
+		if raw == label {
+			return i
+		}
+	}
+	return timeSeriesGroupTransaction
+}
+
+func (m model) saveTransactionsTimeSeriesGroupCmd() tea.Cmd {
+	grouping := timeSeriesGroupLabel(m.transactionsTimeSeriesGrouping)
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveTransactionsFiltersMsg{err: fmt.Errorf("database is not initialized")}
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txTimeSeriesGroupKey: grouping,
+		})
+		return saveTransactionsFiltersMsg{err: err}
+	}
+}
+
+// groupTransactionsTimeSeries applies the chosen granularity. Monthly
+// round-ups arrive already summed per month, so they are left as they are.
+func (m model) groupTransactionsTimeSeries(points []transactionsTimeSeriesPoint) []transactionsTimeSeriesPoint {
+	if m.transactionsTimeSeriesRoundUps && m.transactionsRoundUpsMonthly {
+		return points
+	}
+	return aggregateTimeSeriesPoints(points, m.transactionsTimeSeriesGrouping)
+}
+
+// aggregateTimeSeriesPoints sums per-transaction spend into one point per
+// calendar day or month, oldest first. Each bucket keeps its transactions in
+// members so the details pane can list them.
+func aggregateTimeSeriesPoints(points []transactionsTimeSeriesPoint, grouping int) []transactionsTimeSeriesPoint {
+	if grouping != timeSeriesGroupDay && grouping != timeSeriesGroupMonth {
+		return points
+	}
+	buckets := make(map[string]int, len(points))
+	out := make([]transactionsTimeSeriesPoint, 0, len(points))
+	for _, p := range points {
+		key := strings.TrimSpace(p.date)
+		if grouping == timeSeriesGroupMonth && len(key) >= 7 {
+			key = key[:7] + "-01"
+		}
+		idx, ok := buckets[key]
+		if !ok {
+			idx = len(out)
+			buckets[key] = idx
+			out = append(out, transactionsTimeSeriesPoint{date: key})
+		}
+		out[idx].spendCents += p.spendCents
+		out[idx].members = append(out[idx].members, p)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].date < out[j].date
+	})
+	return out
+}
+
+// cumulativeTimeSeriesPoints returns a copy of points where each value is
+// the running total so far, for series that read better as accumulation.
+func cumulativeTimeSeriesPoints(points []transactionsTimeSeriesPoint) []transactionsTimeSeriesPoint {
+	out := make([]transactionsTimeSeriesPoint, len(points))
+	var total int64
+	for i, p := range points {
+		total += p.spendCents
+		p.spendCents = total
+		out[i] = p
+	}
+	return out
+}
+
+func formatTimeSeriesBucketPeriod(date string, grouping int) string {
+	d, ok := parseTimeSeriesDate(date)
+	if !ok {
+		return date
+	}
+	if grouping == timeSeriesGroupMonth {
+		return d.Format("January 2006")
+	}
+	return d.Format("Mon 02 Jan 2006")
+}
+
+// renderTimeSeriesBucketLines describes an aggregated node and lists the
+// transactions behind it, largest spend first, until maxLines is reached.
+func renderTimeSeriesBucketLines(
+	bucket transactionsTimeSeriesPoint,
+	grouping int,
+	width int,
+	maxLines int,
+	labelStyle lipgloss.Style,
+	valueStyle lipgloss.Style,
+) []string {
+	valueWidth := max(10, width-16)
+	count := fmt.Sprintf("%d", len(bucket.members))
+	lines := renderDetailLines("period", formatTimeSeriesBucketPeriod(bucket.date, grouping), valueWidth, labelStyle, valueStyle)
+	lines = append(lines, renderDetailLines("spend", formatTimeSeriesDollar(bucket.spendCents), valueWidth, labelStyle, valueStyle)...)
+	lines = append(lines, renderDetailLines("transactions", count, valueWidth, labelStyle, valueStyle)...)
+	if len(bucket.members) == 0 {
+		return lines
+	}
+	lines = append(lines, "")
+
+	members := append([]transactionsTimeSeriesPoint{}, bucket.members...)
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].spendCents > members[j].spendCents
+	})
+	// The pane pads one column each side of its content.
+	rowWidth := max(1, width-2)
+	room := max(1, maxLines-len(lines))
+	for i, p := range members {
+		if i == room-1 && len(members) > room {
+			lines = append(lines, labelStyle.Render(fmt.Sprintf("+%d more", len(members)-i)))
+			break
+		}
+		when := p.date
+		if ts, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(p.createdAt)); err == nil {
+			when = ts.In(time.Local).Format("02 Jan")
+		}
+		amount := formatTimeSeriesDollar(p.spendCents)
+		merchantWidth := max(1, rowWidth-lipgloss.Width(when)-lipgloss.Width(amount)-2)
+		merchant := truncateDisplayWidth(strings.TrimSpace(p.merchant), merchantWidth)
+		pad := max(1, rowWidth-lipgloss.Width(when)-lipgloss.Width(merchant)-lipgloss.Width(amount)-1)
+		lines = append(lines, labelStyle.Render(when)+" "+valueStyle.Render(merchant)+strings.Repeat(" ", pad)+valueStyle.Render(amount))
+	}
+	return lines
+}
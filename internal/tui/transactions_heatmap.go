@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// spendHeatmap holds debit totals by weekday (SQLite's %w, Sunday = 0) and
+// hour of day.
+type spendHeatmap [7][24]int64
+
+// heatmapWeekdayOrder lists rows Monday first, matching the weekly view.
+var heatmapWeekdayOrder = []int{1, 2, 3, 4, 5, 6, 0}
+
+var heatmapWeekdayLabels = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+// heatmapShades runs from no spend to the busiest cell.
+var heatmapShades = []string{"·", "░", "▒", "▓", "█"}
+
+// querySpendHeatmap totals debits per weekday and hour. Like the hourly
+// overlay it groups on the wall-clock time the transaction was recorded at,
+// not the UTC time strftime would shift created_at to.
+func querySpendHeatmap(ctx context.Context, db *sql.DB, whereSQL string, args []any) (spendHeatmap, error) {
+	q := fmt.Sprintf(
+		`SELECT
+			CAST(strftime('%%w', substr(t.created_at, 1, 19)) AS INTEGER) AS weekday,
+			CAST(strftime('%%H', substr(t.created_at, 1, 19)) AS INTEGER) AS hour,
+			SUM(-t.amount_value_in_base_units)
+		 FROM transactions t
+		 WHERE %s
+		   AND t.amount_value_in_base_units < 0
+		 GROUP BY weekday, hour`,
+		whereSQL,
+	)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return spendHeatmap{}, err
+	}
+	defer rows.Close()
+
+	var out spendHeatmap
+	for rows.Next() {
+		var weekday, hour sql.NullInt64
+		var spend int64
+		if err := rows.Scan(&weekday, &hour, &spend); err != nil {
+			return spendHeatmap{}, err
+		}
+		if !weekday.Valid || !hour.Valid || weekday.Int64 < 0 || weekday.Int64 > 6 || hour.Int64 < 0 || hour.Int64 > 23 {
+			continue
+		}
+		out[weekday.Int64][hour.Int64] += spend
+	}
+	if err := rows.Err(); err != nil {
+		return spendHeatmap{}, err
+	}
+	return out, nil
+}
+
+// heatmapShade picks the glyph for cents relative to the busiest cell. Any
+// spend at all gets at least the lightest shade.
+func heatmapShade(cents, peak int64) string {
+	if cents <= 0 || peak <= 0 {
+		return heatmapShades[0]
+	}
+	levels := len(heatmapShades) - 1
+	level := int((cents*int64(levels) + peak - 1) / peak)
+	return heatmapShades[min(levels, max(1, level))]
+}
+
+func renderTransactionsHeatmapLines(grid spendHeatmap, contentWidth int) []string {
+	out := []string{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render("spend by weekday and hour"),
+	}
+	var peak int64
+	peakDay, peakHour := -1, -1
+	for day := range grid {
+		for hour, cents := range grid[day] {
+			if cents > peak {
+				peak, peakDay, peakHour = cents, day, hour
+			}
+		}
+	}
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	if peak <= 0 {
+		return append(out, labelStyle.Render("no transactions found"))
+	}
+
+	const labelWidth = 4 // weekday label and a space
+	colWidth := max(1, min(3, (contentWidth-labelWidth)/24))
+	cellStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6CBFE6"))
+	for _, day := range heatmapWeekdayOrder {
+		var b strings.Builder
+		for _, cents := range grid[day] {
+			b.WriteString(strings.Repeat(heatmapShade(cents, peak), colWidth))
+		}
+		out = append(out, labelStyle.Render(heatmapWeekdayLabels[day]+" ")+cellStyle.Render(b.String()))
+	}
+	out = append(out,
+		labelStyle.Render(strings.Repeat(" ", labelWidth)+hourlySpendAxisLine(colWidth)),
+		"",
+		labelStyle.Render("less ")+cellStyle.Render(strings.Join(heatmapShades[1:], ""))+labelStyle.Render(" more"),
+		labelStyle.Render(truncateDisplayWidth(fmt.Sprintf(
+			"busiest: %s %02d:00-%02d:59  %s",
+			heatmapWeekdayLabels[peakDay], peakHour, peakHour, formatTimeSeriesDollar(peak),
+		), max(8, contentWidth))),
+	)
+	return out
+}
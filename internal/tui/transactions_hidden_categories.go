@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+type toggleHiddenCategoryMsg struct {
+	category string
+	hidden   bool
+	list     []string
+	err      error
+}
+
+type unhideCategoriesMsg struct {
+	count int
+	err   error
+}
+
+// toggleHiddenCategoryCmd hides the category from every spend chart, or
+// shows it again when it is already hidden.
+func (m model) toggleHiddenCategoryCmd(category string) tea.Cmd {
+	current := append([]string{}, m.transactionsHiddenCategories...)
+	return func() tea.Msg {
+		if m.db == nil {
+			return toggleHiddenCategoryMsg{category: category, err: errors.New("database is not initialized")}
+		}
+		next, hidden := toggleHiddenCategory(current, category)
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txHiddenCategoriesKey: strings.Join(next, "\n"),
+		})
+		if err != nil {
+			return toggleHiddenCategoryMsg{category: category, err: err}
+		}
+		return toggleHiddenCategoryMsg{category: category, hidden: hidden, list: next}
+	}
+}
+
+func (m model) unhideCategoriesCmd() tea.Cmd {
+	count := len(m.transactionsHiddenCategories)
+	return func() tea.Msg {
+		if m.db == nil {
+			return unhideCategoriesMsg{err: errors.New("database is not initialized")}
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txHiddenCategoriesKey: "",
+		})
+		return unhideCategoriesMsg{count: count, err: err}
+	}
+}
+
+func loadHiddenCategories(ctx context.Context, db *sql.DB) ([]string, error) {
+	raw, _, err := storage.NewAppConfigRepo(db).Get(ctx, txHiddenCategoriesKey)
+	if err != nil {
+		return nil, err
+	}
+	return parseHiddenCategories(raw), nil
+}
+
+// parseHiddenCategories reads the stored hidden set, one lower-case
+// category ID per line.
+func parseHiddenCategories(raw string) []string {
+	out := make([]string, 0, 4)
+	for _, line := range strings.Split(raw, "\n") {
+		category := strings.ToLower(strings.TrimSpace(line))
+		if category == "" || slices.Contains(out, category) {
+			continue
+		}
+		out = append(out, category)
+	}
+	return out
+}
+
+func toggleHiddenCategory(categories []string, category string) ([]string, bool) {
+	category = strings.ToLower(strings.TrimSpace(category))
+	out := make([]string, 0, len(categories)+1)
+	removed := false
+	for _, existing := range categories {
+		if existing == category {
+			removed = true
+			continue
+		}
+		out = append(out, existing)
+	}
+	if removed {
+		return out, false
+	}
+	return append(out, category), true
+}
+
+// appendHiddenCategoriesClause drops hidden categories from spend
+// aggregates. Transactions without a category match as "uncategorized",
+// like the chart bars they would otherwise form.
+func appendHiddenCategoriesClause(categories []string, where *[]string, args *[]any) {
+	if len(categories) == 0 {
+		return
+	}
+	placeholders := make([]string, 0, len(categories))
+	for _, category := range categories {
+		placeholders = append(placeholders, "?")
+		*args = append(*args, category)
+	}
+	*where = append(*where, "LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) NOT IN ("+strings.Join(placeholders, ", ")+")")
+}
@@ -0,0 +1,204 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// hourlySpendChartRows is the height of the hour-of-day bar chart.
+const hourlySpendChartRows = 8
+
+type hourlySpend struct {
+	spendCents [24]int64
+	counts     [24]int
+}
+
+type loadHourlySpendMsg struct {
+	spend hourlySpend
+	err   error
+}
+
+func (m model) loadHourlySpendCmd() tea.Cmd {
+	filter := m.transactionsFilter()
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadHourlySpendMsg{err: errors.New("database is not initialized")}
+		}
+		ignoredMerchants, err := loadIgnoredMerchants(context.Background(), m.db)
+		if err != nil {
+			return loadHourlySpendMsg{err: err}
+		}
+		spend, err := queryHourlySpend(context.Background(), m.db, filter, ignoredMerchants)
+		return loadHourlySpendMsg{spend: spend, err: err}
+	}
+}
+
+func queryHourlySpend(ctx context.Context, db *sql.DB, filter TransactionsFilter, ignoredMerchants []string) (hourlySpend, error) {
+	where, args, err := transactionsFilterWhere(filter)
+	if err != nil {
+		return hourlySpend{}, err
+	}
+	appendIgnoredMerchantsClause(ignoredMerchants, &where, &args)
+	// created_at carries the local offset; strftime would shift it to UTC,
+	// so group on the wall-clock hour the transaction was recorded at.
+	q := fmt.Sprintf(
+		`SELECT
+			CAST(strftime('%%H', substr(t.created_at, 1, 19)) AS INTEGER) AS hour,
+			COUNT(*),
+			SUM(-t.amount_value_in_base_units)
+		 FROM transactions t
+		 WHERE %s
+		   AND t.amount_value_in_base_units < 0
+		 GROUP BY hour`,
+		strings.Join(where, " AND "),
+	)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return hourlySpend{}, err
+	}
+	defer rows.Close()
+
+	var out hourlySpend
+	for rows.Next() {
+		var hour sql.NullInt64
+		var count int
+		var spend int64
+		if err := rows.Scan(&hour, &count, &spend); err != nil {
+			return hourlySpend{}, err
+		}
+		if !hour.Valid || hour.Int64 < 0 || hour.Int64 > 23 {
+			continue
+		}
+		out.counts[hour.Int64] += count
+		out.spendCents[hour.Int64] += spend
+	}
+	if err := rows.Err(); err != nil {
+		return hourlySpend{}, err
+	}
+	return out, nil
+}
+
+// hourlySpendBarLines draws one vertical bar per hour, top row first, using
+// eighth-block glyphs so small hours still show a sliver.
+func hourlySpendBarLines(spend [24]int64, rows int, colWidth int) []string {
+	rows = max(1, rows)
+	colWidth = max(1, colWidth)
+	barWidth := colWidth
+	if colWidth > 1 {
+		barWidth = colWidth - 1
+	}
+	var peak int64
+	for _, cents := range spend {
+		peak = max(peak, cents)
+	}
+	eighths := make([]int, len(spend))
+	if peak > 0 {
+		for i, cents := range spend {
+			if cents > 0 {
+				eighths[i] = max(1, int(cents*int64(rows*8)/peak))
+			}
+		}
+	}
+
+	glyphs := []string{" ", "▁", "▂", "▃", "▄", "▅", "▆", "▇", "█"}
+	lines := make([]string, 0, rows)
+	for r := rows - 1; r >= 0; r-- {
+		var b strings.Builder
+		for _, e := range eighths {
+			level := min(8, max(0, e-r*8))
+			b.WriteString(strings.Repeat(glyphs[level], barWidth))
+			b.WriteString(strings.Repeat(" ", colWidth-barWidth))
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	return lines
+}
+
+func hourlySpendAxisLine(colWidth int) string {
+	width := 24 * max(1, colWidth)
+	axis := []byte(strings.Repeat(" ", width))
+	for _, hour := range []int{0, 6, 12, 18} {
+		label := fmt.Sprintf("%02d", hour)
+		at := hour * max(1, colWidth)
+		copy(axis[at:], label)
+	}
+	return strings.TrimRight(string(axis), " ")
+}
+
+func (m model) renderTransactionsHourlyOverlay(maxWidth int) string {
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#5FA8FF")).
+		Bold(true).
+		Render("Spend by hour of day")
+
+	panelWidth := max(36, min(maxWidth-6, 84))
+	innerWidth := max(1, panelWidth-4)
+	colWidth := max(1, min(3, innerWidth/24))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#E5E7EB"))
+
+	var body string
+	switch {
+	case m.transactionsHourlyErr != "":
+		body = lipgloss.NewStyle().Foreground(lipgloss.Color("#F15B5B")).Render(m.transactionsHourlyErr)
+	case m.transactionsHourlyLoading:
+		body = "loading..."
+	default:
+		spend := m.transactionsHourly
+		var total int64
+		peakHour := -1
+		for hour, cents := range spend.spendCents {
+			total += cents
+			if cents > 0 && (peakHour < 0 || cents > spend.spendCents[peakHour]) {
+				peakHour = hour
+			}
+		}
+		if total <= 0 {
+			body = labelStyle.Render("no spend matches the current filters")
+			break
+		}
+		bars := lipgloss.NewStyle().Foreground(lipgloss.Color("#6CBFE6")).
+			Render(strings.Join(hourlySpendBarLines(spend.spendCents, hourlySpendChartRows, colWidth), "\n"))
+		var lateNight int64
+		for _, hour := range []int{22, 23, 0, 1, 2, 3, 4} {
+			lateNight += spend.spendCents[hour]
+		}
+		charges := "charges"
+		if spend.counts[peakHour] == 1 {
+			charges = "charge"
+		}
+		lines := []string{
+			bars,
+			labelStyle.Render(hourlySpendAxisLine(colWidth)),
+			"",
+			labelStyle.Render("busiest hour: ") + valueStyle.Render(truncateDisplayWidth(fmt.Sprintf(
+				"%02d:00-%02d:59  %s over %d %s",
+				peakHour, peakHour, formatTimeSeriesDollar(spend.spendCents[peakHour]), spend.counts[peakHour], charges,
+			), max(1, innerWidth-14))),
+			labelStyle.Render("late night:   ") + valueStyle.Render(truncateDisplayWidth(fmt.Sprintf(
+				"22:00-04:59  %s (%.0f%% of spend)",
+				formatTimeSeriesDollar(lateNight), float64(lateNight)*100/float64(total),
+			), max(1, innerWidth-14))),
+		}
+		body = strings.Join(lines, "\n")
+	}
+
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFD54A")).
+		Bold(true).
+		Render(transactionsHourlyHelpText)
+
+	content := strings.Join([]string{title, "", body, "", footer}, "\n")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6CBFE6")).
+		Padding(1, 2).
+		Width(panelWidth).
+		Render(content)
+}
@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+type toggleIgnoredMerchantMsg struct {
+	merchant string
+	ignored  bool
+	list     []string
+	err      error
+}
+
+// toggleIgnoredMerchantCmd adds the merchant to the spend analysis ignore
+// list, or removes it when it is already there.
+func (m model) toggleIgnoredMerchantCmd(merchant string) tea.Cmd {
+	current := append([]string{}, m.transactionsIgnoredMerchants...)
+	return func() tea.Msg {
+		if m.db == nil {
+			return toggleIgnoredMerchantMsg{merchant: merchant, err: errors.New("database is not initialized")}
+		}
+		next, ignored := toggleIgnoredMerchant(current, merchant)
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txIgnoredMerchantsKey: formatIgnoredMerchants(next),
+		})
+		if err != nil {
+			return toggleIgnoredMerchantMsg{merchant: merchant, err: err}
+		}
+		return toggleIgnoredMerchantMsg{merchant: merchant, ignored: ignored, list: next}
+	}
+}
+
+func loadIgnoredMerchants(ctx context.Context, db *sql.DB) ([]string, error) {
+	raw, _, err := storage.NewAppConfigRepo(db).Get(ctx, txIgnoredMerchantsKey)
+	if err != nil {
+		return nil, err
+	}
+	return parseIgnoredMerchants(raw), nil
+}
+
+// parseIgnoredMerchants reads the stored ignore list. Entries are one per
+// line because normalized merchant text never spans lines but may contain
+// commas.
+func parseIgnoredMerchants(raw string) []string {
+	out := make([]string, 0, 4)
+	seen := make(map[string]bool, 4)
+	for _, line := range strings.Split(raw, "\n") {
+		merchant := strings.Join(strings.Fields(line), " ")
+		key := strings.ToLower(merchant)
+		if merchant == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, merchant)
+	}
+	return out
+}
+
+func formatIgnoredMerchants(merchants []string) string {
+	return strings.Join(parseIgnoredMerchants(strings.Join(merchants, "\n")), "\n")
+}
+
+func toggleIgnoredMerchant(merchants []string, merchant string) ([]string, bool) {
+	merchant = strings.Join(strings.Fields(merchant), " ")
+	out := make([]string, 0, len(merchants)+1)
+	removed := false
+	for _, existing := range merchants {
+		if strings.EqualFold(existing, merchant) {
+			removed = true
+			continue
+		}
+		out = append(out, existing)
+	}
+	if removed {
+		return out, false
+	}
+	return append(out, merchant), true
+}
+
+// appendIgnoredMerchantsClause drops ignored merchants from spend
+// aggregates. It matches the same merchant text the table displays.
+func appendIgnoredMerchantsClause(merchants []string, where *[]string, args *[]any) {
+	if len(merchants) == 0 {
+		return
+	}
+	placeholders := make([]string, 0, len(merchants))
+	for _, merchant := range merchants {
+		placeholders = append(placeholders, "?")
+		*args = append(*args, strings.ToLower(merchant))
+	}
+	*where = append(*where, `LOWER(COALESCE(
+			NULLIF(t.merchant_norm, ''),
+			NULLIF(t.raw_text_norm, ''),
+			NULLIF(t.description_norm, ''),
+			COALESCE(t.raw_text, t.description, '')
+		)) NOT IN (`+strings.Join(placeholders, ", ")+`)`)
+}
@@ -0,0 +1,142 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+// merchantAlias shows name in place of merchants matching pattern. A
+// pattern ending in * matches every merchant starting with the rest;
+// otherwise it must match the whole merchant. Matching ignores case.
+type merchantAlias struct {
+	pattern string
+	name    string
+}
+
+type saveMerchantAliasMsg struct {
+	pattern string
+	name    string
+	err     error
+}
+
+// normalizeMerchantAliasPattern upper-cases a pattern and collapses its
+// whitespace the way merchant_norm is collapsed.
+func normalizeMerchantAliasPattern(raw string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(raw), " "))
+}
+
+// parseMerchantAliasInput reads the alias prompt. "PATTERN = name" sets a
+// pattern; anything else names the selected merchant exactly.
+func parseMerchantAliasInput(merchant, input string) (pattern, name string) {
+	if left, right, ok := strings.Cut(input, "="); ok {
+		return normalizeMerchantAliasPattern(left), strings.TrimSpace(right)
+	}
+	return normalizeMerchantAliasPattern(merchant), strings.TrimSpace(input)
+}
+
+// merchantAliasFor picks the alias for a merchant. An exact pattern beats
+// any prefix, and a longer prefix beats a shorter one.
+func merchantAliasFor(aliases []merchantAlias, merchant string) (merchantAlias, bool) {
+	key := normalizeMerchantAliasPattern(merchant)
+	if key == "" {
+		return merchantAlias{}, false
+	}
+	var best merchantAlias
+	bestLen := -1
+	for _, a := range aliases {
+		prefix, wildcard := strings.CutSuffix(a.pattern, "*")
+		switch {
+		case !wildcard && a.pattern == key:
+			return a, true
+		case wildcard && strings.HasPrefix(key, prefix) && len(prefix) > bestLen:
+			best, bestLen = a, len(prefix)
+		}
+	}
+	return best, bestLen >= 0
+}
+
+// displayMerchant is the merchant as rendered: its alias when one matches.
+// Search, ignore lists and chart drill-downs keep using the stored name.
+func displayMerchant(aliases []merchantAlias, merchant string) string {
+	if a, ok := merchantAliasFor(aliases, merchant); ok {
+		return a.name
+	}
+	return merchant
+}
+
+func (m model) aliasedTransactionRows(rows []transactionPreviewRow) []transactionPreviewRow {
+	if len(m.transactionsMerchantAliases) == 0 {
+		return rows
+	}
+	out := make([]transactionPreviewRow, len(rows))
+	for i, row := range rows {
+		row.merchant = displayMerchant(m.transactionsMerchantAliases, row.merchant)
+		out[i] = row
+	}
+	return out
+}
+
+// aliasedChartSpend labels merchant bars with merchant aliases and category
+// bars with category aliases. Each bar keeps its stored name in category so
+// drill-downs and budgets still match.
+func (m model) aliasedChartSpend(spend []transactionsCategorySpend) []transactionsCategorySpend {
+	if m.transactionsChartByMerchant && len(m.transactionsMerchantAliases) == 0 ||
+		!m.transactionsChartByMerchant && len(m.transactionsCategoryAliases) == 0 {
+		return spend
+	}
+	out := make([]transactionsCategorySpend, len(spend))
+	for i, s := range spend {
+		if m.transactionsChartByMerchant {
+			s.label = displayMerchant(m.transactionsMerchantAliases, s.category)
+		} else {
+			s.label = displayCategory(m.transactionsCategoryAliases, s.category)
+		}
+		out[i] = s
+	}
+	return out
+}
+
+func loadMerchantAliases(ctx context.Context, db *sql.DB) ([]merchantAlias, error) {
+	stored, err := storage.NewMerchantAliasesRepo(db).List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]merchantAlias, 0, len(stored))
+	for _, a := range stored {
+		out = append(out, merchantAlias{pattern: a.Pattern, name: a.Alias})
+	}
+	return out, nil
+}
+
+// saveMerchantAliasCmd stores an alias, or removes the pattern's alias when
+// name is blank.
+func (m model) saveMerchantAliasCmd(pattern, name string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveMerchantAliasMsg{err: errors.New("database is not initialized")}
+		}
+		if pattern == "" || pattern == "*" {
+			return saveMerchantAliasMsg{err: errors.New("merchant pattern is empty")}
+		}
+		repo := storage.NewMerchantAliasesRepo(m.db)
+		var err error
+		if name == "" {
+			err = repo.Delete(context.Background(), pattern)
+		} else {
+			err = repo.Upsert(context.Background(), pattern, name)
+		}
+		return saveMerchantAliasMsg{pattern: pattern, name: name, err: err}
+	}
+}
+
+func (m *model) closeMerchantAliasPrompt() {
+	m.transactionsAliasActive = false
+	m.transactionsAliasMerchant = ""
+	m.transactionsAliasInput.SetValue("")
+	m.transactionsAliasInput.Blur()
+}
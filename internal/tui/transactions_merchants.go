@@ -0,0 +1,141 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// transactionsMerchantSQL is the merchant text the table displays.
+const transactionsMerchantSQL = `COALESCE(
+			NULLIF(t.merchant_norm, ''),
+			NULLIF(t.raw_text_norm, ''),
+			NULLIF(t.description_norm, ''),
+			COALESCE(t.raw_text, t.description, '')
+		)`
+
+const unknownMerchantLabel = "unknown merchant"
+
+// merchantGroupLabel drops store and terminal numbers so branches of one
+// merchant share a bar: "WOOLWORTHS 1234 SYDNEY" becomes "WOOLWORTHS SYDNEY".
+// A merchant made only of numbers is kept as is.
+func merchantGroupLabel(merchant string) string {
+	fields := strings.Fields(merchant)
+	kept := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if !isStoreNumberToken(field) {
+			kept = append(kept, field)
+		}
+	}
+	if len(kept) == 0 {
+		kept = fields
+	}
+	if len(kept) == 0 {
+		return unknownMerchantLabel
+	}
+	return strings.Join(kept, " ")
+}
+
+func isStoreNumberToken(token string) bool {
+	digits := 0
+	for _, r := range token {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case r == '#' || r == '-' || r == '*':
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+func merchantGroupKey(merchant string) string {
+	return strings.ToLower(merchantGroupLabel(merchant))
+}
+
+// queryMerchantSpend mirrors queryCategorySpend but groups spend by merchant.
+// SQLite totals each distinct merchant text and the branches are then folded
+// together by merchantGroupLabel; the first spelling seen names the group.
+func queryMerchantSpend(ctx context.Context, db *sql.DB, whereSQL string, args []any) ([]transactionsCategorySpend, error) {
+	q := fmt.Sprintf(
+		`SELECT
+			%s AS merchant,
+			SUM(CASE WHEN t.amount_value_in_base_units < 0 THEN -t.amount_value_in_base_units ELSE 0 END) AS spend_cents
+		 FROM transactions t
+		 WHERE %s
+		 GROUP BY merchant
+		 HAVING spend_cents > 0
+		 ORDER BY spend_cents DESC, merchant ASC`,
+		transactionsMerchantSQL,
+		whereSQL,
+	)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]transactionsCategorySpend, 0, 32)
+	indexByKey := make(map[string]int, 32)
+	var total int64
+	for rows.Next() {
+		var merchant string
+		var spend int64
+		if err := rows.Scan(&merchant, &spend); err != nil {
+			return nil, err
+		}
+		label := merchantGroupLabel(merchant)
+		key := strings.ToLower(label)
+		idx, ok := indexByKey[key]
+		if !ok {
+			idx = len(out)
+			indexByKey[key] = idx
+			out = append(out, transactionsCategorySpend{category: label})
+		}
+		out[idx].spendCents += spend
+		total += spend
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].spendCents != out[j].spendCents {
+			return out[i].spendCents > out[j].spendCents
+		}
+		return out[i].category < out[j].category
+	})
+	if total <= 0 {
+		return out, nil
+	}
+	for i := range out {
+		out[i].percentOfSpend = (float64(out[i].spendCents) / float64(total)) * 100.0
+	}
+	return out, nil
+}
+
+// appendMerchantGroupClause narrows to transactions whose merchant contains
+// the group's first word. Rows still need filterMerchantGroupRows, as SQL
+// cannot tell "WOOLWORTHS 12" from "WOOLWORTHS METRO".
+func appendMerchantGroupClause(label string, where *[]string, args *[]any) {
+	key := merchantGroupKey(label)
+	if key == strings.ToLower(unknownMerchantLabel) {
+		return
+	}
+	first := strings.Fields(key)[0]
+	*where = append(*where, "instr(LOWER("+transactionsMerchantSQL+"), ?) > 0")
+	*args = append(*args, first)
+}
+
+func filterMerchantGroupRows(rows []categoryTransactionRow, label string) []categoryTransactionRow {
+	key := merchantGroupKey(label)
+	out := rows[:0]
+	for _, row := range rows {
+		if merchantGroupKey(row.merchant) == key {
+			out = append(out, row)
+		}
+	}
+	return out
+}
@@ -0,0 +1,56 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// transactionsNetSummary is money in and out across the filtered
+// transactions, in cents. Both totals are positive.
+type transactionsNetSummary struct {
+	incomeCents  int64
+	expenseCents int64
+}
+
+func (s transactionsNetSummary) netCents() int64 {
+	return s.incomeCents - s.expenseCents
+}
+
+// netSummarySQL sums credits and debits separately over the transactions
+// whereSQL selects, so internal transfers follow the same toggle as the rows.
+func netSummarySQL(whereSQL string) string {
+	return fmt.Sprintf(
+		`SELECT
+		   COALESCE(SUM(CASE WHEN t.amount_value_in_base_units > 0 THEN t.amount_value_in_base_units ELSE 0 END), 0),
+		   COALESCE(SUM(CASE WHEN t.amount_value_in_base_units < 0 THEN -t.amount_value_in_base_units ELSE 0 END), 0)
+		 FROM transactions t
+		 WHERE %s`,
+		whereSQL,
+	)
+}
+
+func queryNetSummary(ctx context.Context, db *sql.DB, whereSQL string, args []any) (transactionsNetSummary, error) {
+	var s transactionsNetSummary
+	if err := db.QueryRowContext(ctx, netSummarySQL(whereSQL), args...).Scan(&s.incomeCents, &s.expenseCents); err != nil {
+		return transactionsNetSummary{}, err
+	}
+	return s, nil
+}
+
+// formatNetSummary renders the summary footer line, with net signed.
+func formatNetSummary(s transactionsNetSummary) string {
+	net := s.netCents()
+	sign := "+"
+	if net < 0 {
+		sign = "-"
+		net = -net
+	}
+	return fmt.Sprintf(
+		"income %s  |  expenses %s  |  net %s%s",
+		formatTimeSeriesDollar(s.incomeCents),
+		formatTimeSeriesDollar(s.expenseCents),
+		sign,
+		formatTimeSeriesDollar(net),
+	)
+}
@@ -0,0 +1,38 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+type saveTransactionNoteMsg struct {
+	cleared bool
+	err     error
+}
+
+// saveTransactionNoteCmd stores your own note on a transaction, or removes
+// it when the note is blank.
+func (m model) saveTransactionNoteCmd(transactionID, note string) tea.Cmd {
+	note = strings.TrimSpace(note)
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveTransactionNoteMsg{err: errors.New("database is not initialized")}
+		}
+		repo := storage.NewTransactionNotesRepo(m.db)
+		if note == "" {
+			return saveTransactionNoteMsg{cleared: true, err: repo.Delete(context.Background(), transactionID)}
+		}
+		return saveTransactionNoteMsg{err: repo.Upsert(context.Background(), transactionID, note)}
+	}
+}
+
+func (m *model) closeTransactionsNotePrompt() {
+	m.transactionsNoteActive = false
+	m.transactionsNoteTxID = ""
+	m.transactionsNoteInput.SetValue("")
+	m.transactionsNoteInput.Blur()
+}
@@ -0,0 +1,57 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type openTransactionMsg struct {
+	noLink bool
+	err    error
+}
+
+// openURLCommand is the OS default handler invocation for a URL.
+func openURLCommand(goos, url string) (string, []string) {
+	switch goos {
+	case "darwin":
+		return "open", []string{url}
+	case "windows":
+		return "rundll32", []string{"url.dll,FileProtocolHandler", url}
+	default:
+		return "xdg-open", []string{url}
+	}
+}
+
+// openTransactionDeepLinkCmd hands the transaction's deep link to the OS so
+// it opens in the Up app, for actions the TUI doesn't support.
+func (m model) openTransactionDeepLinkCmd(transactionID string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return openTransactionMsg{err: errors.New("database is not initialized")}
+		}
+		var link sql.NullString
+		err := m.db.QueryRowContext(
+			context.Background(),
+			"SELECT deep_link_url FROM transactions WHERE id = ?",
+			transactionID,
+		).Scan(&link)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return openTransactionMsg{err: err}
+		}
+		url := strings.TrimSpace(link.String)
+		if url == "" {
+			return openTransactionMsg{noLink: true}
+		}
+		name, args := openURLCommand(runtime.GOOS, url)
+		if err := exec.Command(name, args...).Start(); err != nil {
+			return openTransactionMsg{err: err}
+		}
+		return openTransactionMsg{}
+	}
+}
@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+// payCycleSettings is the stored pay_cycle.next_date and
+// pay_cycle.frequency, either of which may be unset.
+type payCycleSettings struct {
+	nextDate  string
+	frequency string
+}
+
+func loadPayCycleSettings(ctx context.Context, repo *storage.AppConfigRepo) (payCycleSettings, error) {
+	nextDate, _, err := repo.Get(ctx, "pay_cycle.next_date")
+	if err != nil {
+		return payCycleSettings{}, err
+	}
+	frequency, _, err := repo.Get(ctx, "pay_cycle.frequency")
+	if err != nil {
+		return payCycleSettings{}, err
+	}
+	return payCycleSettings{nextDate: strings.TrimSpace(nextDate), frequency: strings.TrimSpace(frequency)}, nil
+}
+
+// transactionsLastPayCycleIdx is the "last pay cycle" quick range. It sits
+// after the fixed ranges so stored quick range indexes keep their meaning.
+const transactionsLastPayCycleIdx = 6
+
+// lastPayCycleWindow is the completed pay cycle before the current one,
+// as inclusive from and to dates.
+func lastPayCycleWindow(cycle payCycleSettings) (time.Time, time.Time, error) {
+	currentStart, _, err := computePayCycleWindow(cycle.nextDate, cycle.frequency)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	previousStart, _, err := computePayCycleWindow(currentStart.Format("2006-01-02"), cycle.frequency)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	return previousStart, currentStart.AddDate(0, 0, -1), nil
+}
+
+// lastPayCycleQuickRange falls back to the month before now when the pay
+// cycle is not configured.
+func lastPayCycleQuickRange(cycle payCycleSettings) transactionQuickRange {
+	return transactionQuickRange{
+		label: "last pay cycle",
+		apply: func(now time.Time) (time.Time, time.Time) {
+			from, to, err := lastPayCycleWindow(cycle)
+			if err != nil {
+				return now.AddDate(0, -1, 0), now
+			}
+			return from, to
+		},
+	}
+}
@@ -0,0 +1,236 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lachiem1/giddyUp/internal/storage"
+	"github.com/lachiem1/giddyUp/internal/upapi"
+)
+
+type loadTransactionRawMsg struct {
+	id    string
+	lines []string
+	err   error
+}
+
+func (m model) loadTransactionRawCmd(id string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadTransactionRawMsg{id: id, err: errors.New("database is not initialized")}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		rcd, ok, err := storage.NewTransactionsRepo(m.db).GetRecord(ctx, id)
+		if err != nil {
+			return loadTransactionRawMsg{id: id, err: err}
+		}
+		if !ok {
+			return loadTransactionRawMsg{id: id, err: fmt.Errorf("transaction %q is not stored locally", id)}
+		}
+		raw, err := json.MarshalIndent(upapi.ResourceResponse{Data: transactionRecordResource(rcd)}, "", "  ")
+		if err != nil {
+			return loadTransactionRawMsg{id: id, err: err}
+		}
+		return loadTransactionRawMsg{id: id, lines: strings.Split(string(raw), "\n")}
+	}
+}
+
+// selectedTransactionID returns the transaction under the cursor in the
+// table, or in the chart drill-down pane when that pane has focus.
+func (m model) selectedTransactionID() string {
+	if m.transactionsViewMode == transactionsViewModeChart &&
+		m.transactionsChartPaneOpen &&
+		m.transactionsChartPaneFocus == transactionsChartFocusPane {
+		if m.transactionsChartPaneMode == transactionsChartPaneModeDetails {
+			return m.transactionsChartPaneDetailTxID
+		}
+		if m.transactionsChartPaneCursor >= 0 && m.transactionsChartPaneCursor < len(m.transactionsChartPaneRows) {
+			return m.transactionsChartPaneRows[m.transactionsChartPaneCursor].id
+		}
+		return ""
+	}
+	if m.transactionsViewMode == transactionsViewModeTable &&
+		m.transactionsCursor >= 0 &&
+		m.transactionsCursor < len(m.transactionsRows) {
+		return m.transactionsRows[m.transactionsCursor].id
+	}
+	return ""
+}
+
+func (m model) transactionsRawVisibleRows() int {
+	return max(4, m.height-16)
+}
+
+func (m *model) scrollTransactionsRaw(delta int) {
+	maxOffset := max(0, len(m.transactionsRawLines)-m.transactionsRawVisibleRows())
+	m.transactionsRawOffset = min(maxOffset, max(0, m.transactionsRawOffset+delta))
+}
+
+func (m model) renderTransactionsRawOverlay(maxWidth int) string {
+	title := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#5FA8FF")).
+		Bold(true).
+		Render("Raw transaction " + m.transactionsRawTxID)
+
+	panelWidth := max(36, min(maxWidth-6, 96))
+	innerWidth := max(1, panelWidth-4)
+	visible := m.transactionsRawVisibleRows()
+
+	var body string
+	switch {
+	case m.transactionsRawErr != "":
+		body = lipgloss.NewStyle().Foreground(lipgloss.Color("#F15B5B")).Render(m.transactionsRawErr)
+	case m.transactionsRawLines == nil:
+		body = "loading..."
+	default:
+		end := min(len(m.transactionsRawLines), m.transactionsRawOffset+visible)
+		lines := make([]string, 0, visible)
+		for _, line := range m.transactionsRawLines[m.transactionsRawOffset:end] {
+			lines = append(lines, truncateDisplayWidth(line, innerWidth))
+		}
+		body = strings.Join(lines, "\n")
+	}
+
+	footerText := transactionsRawHelpText
+	if total := len(m.transactionsRawLines); total > visible {
+		footerText = fmt.Sprintf("lines %d-%d of %d  %s",
+			m.transactionsRawOffset+1, min(total, m.transactionsRawOffset+visible), total, footerText)
+	}
+	footer := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFD54A")).
+		Bold(true).
+		Render(footerText)
+
+	content := strings.Join([]string{title, "", body, "", footer}, "\n")
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("#6CBFE6")).
+		Padding(1, 2).
+		Width(panelWidth).
+		Render(content)
+}
+
+// transactionRecordResource rebuilds the Up API resource shape from the
+// stored columns so the overlay mirrors what the sync originally received.
+func transactionRecordResource(rcd storage.TransactionRecord) upapi.Resource {
+	attrs := map[string]any{
+		"status":          rcd.Status,
+		"rawText":         rcd.RawText,
+		"description":     rcd.Description,
+		"message":         rcd.Message,
+		"isCategorizable": rcd.IsCategorizable,
+		"amount": map[string]any{
+			"currencyCode":     rcd.AmountCurrencyCode,
+			"value":            rcd.AmountValue,
+			"valueInBaseUnits": rcd.AmountValueInBaseUnits,
+		},
+		"foreignAmount":      rawMoney(rcd.ForeignAmountCurrencyCode, rcd.ForeignAmountValue, rcd.ForeignAmountValueInBaseUnits),
+		"settledAt":          rcd.SettledAt,
+		"createdAt":          rcd.CreatedAt,
+		"transactionType":    rcd.TransactionType,
+		"deepLinkURL":        rcd.DeepLinkURL,
+		"holdInfo":           nil,
+		"roundUp":            nil,
+		"cashback":           nil,
+		"cardPurchaseMethod": nil,
+		"note":               nil,
+		"performingCustomer": nil,
+	}
+	if hold := rawMoney(rcd.HoldAmountCurrencyCode, rcd.HoldAmountValue, rcd.HoldAmountValueInBaseUnits); hold != nil {
+		attrs["holdInfo"] = map[string]any{
+			"amount":        hold,
+			"foreignAmount": rawMoney(rcd.HoldForeignAmountCurrencyCode, rcd.HoldForeignAmountValue, rcd.HoldForeignAmountValueInBaseUnits),
+		}
+	}
+	if roundUp := rawMoney(rcd.RoundUpAmountCurrencyCode, rcd.RoundUpAmountValue, rcd.RoundUpAmountValueInBaseUnits); roundUp != nil {
+		attrs["roundUp"] = map[string]any{
+			"amount":       roundUp,
+			"boostPortion": rawMoney(rcd.RoundUpBoostPortionCurrencyCode, rcd.RoundUpBoostPortionValue, rcd.RoundUpBoostPortionValueInBaseUnits),
+		}
+	}
+	if rcd.CashbackDescription != nil || rcd.CashbackAmountValue != nil {
+		attrs["cashback"] = map[string]any{
+			"description": rcd.CashbackDescription,
+			"amount":      rawMoney(rcd.CashbackAmountCurrencyCode, rcd.CashbackAmountValue, rcd.CashbackAmountValueInBaseUnits),
+		}
+	}
+	if rcd.CardPurchaseMethodMethod != nil {
+		attrs["cardPurchaseMethod"] = map[string]any{
+			"method":           rcd.CardPurchaseMethodMethod,
+			"cardNumberSuffix": rcd.CardPurchaseMethodCardNumberSuffix,
+		}
+	}
+	if rcd.NoteText != nil {
+		attrs["note"] = map[string]any{"text": rcd.NoteText}
+	}
+	if rcd.PerformingCustomerDisplayName != nil {
+		attrs["performingCustomer"] = map[string]any{"displayName": rcd.PerformingCustomerDisplayName}
+	}
+
+	tags := make([]any, 0, len(rcd.Tags))
+	for _, tag := range rcd.Tags {
+		tags = append(tags, map[string]any{"type": tag.TagType, "id": tag.TagID})
+	}
+	rels := map[string]map[string]any{
+		"account":         rawRelationship(rcd.AccountResourceType, &rcd.AccountID, nil, rcd.AccountLinkRelated),
+		"transferAccount": rawRelationship(rcd.TransferAccountResourceType, rcd.TransferAccountID, nil, rcd.TransferAccountLinkRelated),
+		"category":        rawRelationship(rcd.CategoryResourceType, rcd.CategoryID, rcd.CategoryLinkSelf, rcd.CategoryLinkRelated),
+		"parentCategory":  rawRelationship(rcd.ParentCategoryResourceType, rcd.ParentCategoryID, nil, rcd.ParentCategoryLinkRelated),
+		"tags":            {"data": tags, "links": rawLinks(rcd.TagsLinkSelf, nil)},
+		"attachment":      rawRelationship(rcd.AttachmentResourceType, rcd.AttachmentID, nil, rcd.AttachmentLinkRelated),
+	}
+
+	res := upapi.Resource{
+		Type:          rcd.ResourceType,
+		ID:            rcd.ID,
+		Attributes:    attrs,
+		Relationships: rels,
+	}
+	if rcd.ResourceLinkSelf != nil {
+		res.Links = map[string]string{"self": *rcd.ResourceLinkSelf}
+	}
+	return res
+}
+
+func rawMoney(currencyCode, value *string, baseUnits *int64) map[string]any {
+	if currencyCode == nil && value == nil && baseUnits == nil {
+		return nil
+	}
+	return map[string]any{
+		"currencyCode":     currencyCode,
+		"value":            value,
+		"valueInBaseUnits": baseUnits,
+	}
+}
+
+func rawRelationship(resourceType, id, self, related *string) map[string]any {
+	rel := map[string]any{"data": nil}
+	if id != nil && strings.TrimSpace(*id) != "" {
+		rel["data"] = map[string]any{"type": resourceType, "id": *id}
+	}
+	if links := rawLinks(self, related); links != nil {
+		rel["links"] = links
+	}
+	return rel
+}
+
+func rawLinks(self, related *string) map[string]any {
+	links := map[string]any{}
+	if self != nil {
+		links["self"] = *self
+	}
+	if related != nil {
+		links["related"] = *related
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	return links
+}
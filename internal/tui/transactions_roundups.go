@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// queryRoundUpsByMonth sums round-ups per calendar month under the active
+// filters, oldest first. Each point is dated the first of its month. Months
+// come from the local date in created_at, as the heatmap and weekly views do.
+func queryRoundUpsByMonth(ctx context.Context, db *sql.DB, whereSQL string, args []any) ([]transactionsTimeSeriesPoint, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		fmt.Sprintf(
+			`SELECT
+				substr(t.created_at, 1, 7) || '-01' AS month,
+				COALESCE(SUM(ABS(t.round_up_amount_value_in_base_units)), 0),
+				COUNT(*)
+			 FROM transactions t
+			 WHERE %s
+			   AND COALESCE(t.round_up_amount_value_in_base_units, 0) != 0
+			 GROUP BY month
+			 ORDER BY month ASC`,
+			whereSQL,
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]transactionsTimeSeriesPoint, 0, 12)
+	for rows.Next() {
+		var p transactionsTimeSeriesPoint
+		if err := rows.Scan(&p.date, &p.spendCents, &p.count); err != nil {
+			return nil, err
+		}
+		out = append(out, p)
+	}
+	return out, rows.Err()
+}
+
+func roundUpsTotalCents(points []transactionsTimeSeriesPoint) int64 {
+	var total int64
+	for _, p := range points {
+		total += p.spendCents
+	}
+	return total
+}
+
+// renderRoundUpMonthLines describes one month of round-ups in the details
+// pane.
+func renderRoundUpMonthLines(point transactionsTimeSeriesPoint, width int, labelStyle, valueStyle lipgloss.Style) []string {
+	valueWidth := max(10, width-16)
+	lines := renderDetailLines("month", formatTimeSeriesBucketPeriod(point.date, timeSeriesGroupMonth), valueWidth, labelStyle, valueStyle)
+	lines = append(lines, renderDetailLines("round-ups", formatTimeSeriesDollar(point.spendCents), valueWidth, labelStyle, valueStyle)...)
+	return append(lines, renderDetailLines("transactions", strconv.Itoa(point.count), valueWidth, labelStyle, valueStyle)...)
+}
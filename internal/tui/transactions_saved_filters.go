@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+// txSavedFilterKeyPrefix keys a named filter, stored as JSON, by its name.
+const txSavedFilterKeyPrefix = "transactions.saved."
+
+// savedTransactionsFilter is the applied search and date filters saved by
+// /save-filter. Quick ranges are saved by index so they stay relative to
+// the day the filter is loaded.
+type savedTransactionsFilter struct {
+	Search          string `json:"search"`
+	Mode            string `json:"mode"`
+	QuickIdx        int    `json:"quick_idx"`
+	FromDate        string `json:"from_date"`
+	ToDate          string `json:"to_date"`
+	IncludeInternal bool   `json:"include_internal"`
+}
+
+type savedFilterNamesMsg struct {
+	names []string
+	err   error
+}
+
+type saveSavedFilterMsg struct {
+	name string
+	err  error
+}
+
+type loadSavedFilterMsg struct {
+	name   string
+	filter savedTransactionsFilter
+	found  bool
+	err    error
+}
+
+// parseSavedFilterName accepts names made of letters, digits, - and _, and
+// lower-cases them so recall ignores case.
+func parseSavedFilterName(raw string) (string, error) {
+	name := strings.ToLower(strings.TrimSpace(raw))
+	if name == "" {
+		return "", errors.New("filter name is empty")
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return "", fmt.Errorf("filter name %q may only use letters, digits, - and _", raw)
+		}
+	}
+	return name, nil
+}
+
+func (m model) currentSavedTransactionsFilter() savedTransactionsFilter {
+	mode := "quick"
+	if m.transactionsFilterMode == transactionsFilterModeCustom {
+		mode = "custom"
+	}
+	return savedTransactionsFilter{
+		Search:          strings.TrimSpace(m.transactionsSearchApplied),
+		Mode:            mode,
+		QuickIdx:        m.transactionsQuickIdx,
+		FromDate:        strings.TrimSpace(m.transactionsFromDate),
+		ToDate:          strings.TrimSpace(m.transactionsToDate),
+		IncludeInternal: m.transactionsIncludeInternal,
+	}
+}
+
+// applySavedTransactionsFilter restores a saved filter's dates, internal
+// transfers and search, and returns to the first page.
+func (m *model) applySavedTransactionsFilter(f savedTransactionsFilter) {
+	if f.Mode == "custom" {
+		m.transactionsFilterMode = transactionsFilterModeCustom
+		m.transactionsFromDate = f.FromDate
+		m.transactionsToDate = f.ToDate
+	} else {
+		m.transactionsFilterMode = transactionsFilterModeQuick
+		m.applyTransactionsQuickRange(f.QuickIdx)
+	}
+	m.transactionsIncludeInternal = f.IncludeInternal
+	m.transactionsSearchApplied = f.Search
+	m.transactionsSearchInput.SetValue(f.Search)
+	m.transactionsSearchErr = ""
+	m.transactionsPage = 0
+	m.transactionsPageKey = transactionsPageKey{}
+	m.transactionsCursor = 0
+	m.transactionsOffset = 0
+}
+
+// addSavedFilterName keeps the completion list sorted and free of repeats.
+func addSavedFilterName(names []string, name string) []string {
+	if slices.Contains(names, name) {
+		return names
+	}
+	next := append(slices.Clone(names), name)
+	slices.Sort(next)
+	return next
+}
+
+func (m model) loadSavedFilterNamesCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return savedFilterNamesMsg{err: errors.New("database is not initialized")}
+		}
+		values, err := storage.NewAppConfigRepo(m.db).ListByPrefix(context.Background(), txSavedFilterKeyPrefix)
+		if err != nil {
+			return savedFilterNamesMsg{err: err}
+		}
+		names := make([]string, 0, len(values))
+		for key := range values {
+			names = append(names, strings.TrimPrefix(key, txSavedFilterKeyPrefix))
+		}
+		slices.Sort(names)
+		return savedFilterNamesMsg{names: names}
+	}
+}
+
+func (m model) saveSavedFilterCmd(name string, f savedTransactionsFilter) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveSavedFilterMsg{name: name, err: errors.New("database is not initialized")}
+		}
+		raw, err := json.Marshal(f)
+		if err != nil {
+			return saveSavedFilterMsg{name: name, err: err}
+		}
+		err = storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txSavedFilterKeyPrefix + name: string(raw),
+		})
+		return saveSavedFilterMsg{name: name, err: err}
+	}
+}
+
+func (m model) loadSavedFilterCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadSavedFilterMsg{name: name, err: errors.New("database is not initialized")}
+		}
+		raw, found, err := storage.NewAppConfigRepo(m.db).Get(context.Background(), txSavedFilterKeyPrefix+name)
+		if err != nil || !found {
+			return loadSavedFilterMsg{name: name, err: err}
+		}
+		var f savedTransactionsFilter
+		if err := json.Unmarshal([]byte(raw), &f); err != nil {
+			return loadSavedFilterMsg{name: name, err: fmt.Errorf("saved filter %q is unreadable: %w", name, err)}
+		}
+		return loadSavedFilterMsg{name: name, filter: f, found: true}
+	}
+}
+
+// savedFilterSuggestions offers saved names once "/load-filter " is typed,
+// so tab or enter completes them. input is the untrimmed command line.
+func savedFilterSuggestions(input string, names []string) ([]commandSpec, bool) {
+	const command = "/load-filter"
+	rest, ok := strings.CutPrefix(strings.ToLower(strings.TrimLeft(input, " ")), command+" ")
+	if !ok {
+		return nil, false
+	}
+	rest = strings.TrimSpace(rest)
+	out := make([]commandSpec, 0, len(names))
+	for _, name := range names {
+		if strings.HasPrefix(name, rest) {
+			out = append(out, commandSpec{name: command + " " + name, description: "saved filter"})
+		}
+	}
+	return out, true
+}
+
+// runSavedFilterCommand handles /save-filter and /load-filter.
+func (m model) runSavedFilterCommand(fields []string) (tea.Model, tea.Cmd) {
+	if len(fields) != 2 {
+		return m.withCommandFeedback("usage: " + fields[0] + " <name>")
+	}
+	name, err := parseSavedFilterName(fields[1])
+	if err != nil {
+		return m.withCommandFeedback(err.Error())
+	}
+	if fields[0] == "/save-filter" {
+		return m, m.saveSavedFilterCmd(name, m.currentSavedTransactionsFilter())
+	}
+	return m, m.loadSavedFilterCmd(name)
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// explainTransactionsSearch reads a search query back in words, e.g.
+// "merchant contains 'woo' AND amount > $60.00", so a misplaced separator
+// shows up before the query runs. Empty, help and reset queries explain to "".
+func explainTransactionsSearch(searchQuery string) (string, error) {
+	groups, err := parseTransactionsSearch(searchQuery)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, 0, len(groups))
+	for _, terms := range groups {
+		phrases := make([]string, 0, len(terms))
+		for _, term := range terms {
+			phrase, err := explainTransactionsSearchTerm(term)
+			if err != nil {
+				return "", err
+			}
+			phrases = append(phrases, phrase)
+		}
+		if len(phrases) == 1 {
+			parts = append(parts, phrases[0])
+		} else {
+			parts = append(parts, "("+strings.Join(phrases, " OR ")+")")
+		}
+	}
+	return strings.Join(parts, " AND "), nil
+}
+
+func explainTransactionsSearchTerm(term transactionsSearchTerm) (string, error) {
+	if _, _, err := transactionsSearchClause(term.field, term.value); err != nil {
+		return "", term.syntaxError(err.Error())
+	}
+	value := strings.ToLower(term.value)
+	switch term.field {
+	case "merchant":
+		if exact, ok := strings.CutPrefix(value, "="); ok {
+			return fmt.Sprintf("merchant is '%s'", strings.TrimSpace(exact)), nil
+		}
+		return fmt.Sprintf("merchant contains '%s'", value), nil
+	case "exclude-category":
+		return fmt.Sprintf("category does not contain '%s'", value), nil
+	case "tag":
+		return fmt.Sprintf("tagged '%s'", value), nil
+	case "exclude-tag":
+		return fmt.Sprintf("not tagged '%s'", value), nil
+	case "account":
+		return fmt.Sprintf("account contains '%s'", value), nil
+	case "exclude-account":
+		return fmt.Sprintf("account does not contain '%s'", value), nil
+	case "type":
+		if isTransactionCashbackType(term.value) {
+			return "earned cashback", nil
+		}
+		if sign, _ := parseTransactionTypeValue(term.value); sign > 0 {
+			return "is a credit", nil
+		}
+		return "is a debit", nil
+	case "status":
+		status, _ := parseTransactionStatusValue(term.value)
+		return "is " + strings.ToLower(status), nil
+	case "currency":
+		code, _ := parseTransactionCurrencyValue(term.value)
+		return "paid in " + code, nil
+	case "has":
+		return "has a receipt attached", nil
+	case "amount":
+		op, cents, highCents, _ := parseTransactionAmountValue(term.value)
+		if op == "BETWEEN" {
+			return fmt.Sprintf("amount between $%.2f and $%.2f", float64(cents)/100.0, float64(highCents)/100.0), nil
+		}
+		return fmt.Sprintf("amount %s $%.2f", op, float64(cents)/100.0), nil
+	case "date":
+		op, date, _ := parseTransactionDateValue(term.value)
+		if op == "=" {
+			return "on " + date, nil
+		}
+		return fmt.Sprintf("date %s %s", op, date), nil
+	default:
+		return fmt.Sprintf("%s contains '%s'", term.field, value), nil
+	}
+}
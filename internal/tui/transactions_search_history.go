@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+// txSearchHistoryKey holds applied searches as a JSON array, oldest first.
+const txSearchHistoryKey = "transactions.search_history"
+
+const transactionsSearchHistoryMax = 50
+
+type saveTransactionsSearchHistoryMsg struct {
+	err error
+}
+
+// parseTransactionsSearchHistory reads the stored history, dropping blank
+// entries. Unreadable JSON is treated as no history.
+func parseTransactionsSearchHistory(raw string) []string {
+	var stored []string
+	if err := json.Unmarshal([]byte(raw), &stored); err != nil {
+		return nil
+	}
+	out := make([]string, 0, len(stored))
+	for _, query := range stored {
+		if query = strings.TrimSpace(query); query != "" {
+			out = append(out, query)
+		}
+	}
+	return out[max(0, len(out)-transactionsSearchHistoryMax):]
+}
+
+// pushTransactionsSearchHistory records an applied search, skipping a
+// repeat of the newest entry and dropping the oldest past the cap.
+func pushTransactionsSearchHistory(history []string, query string) []string {
+	query = strings.TrimSpace(query)
+	if query == "" || (len(history) > 0 && history[len(history)-1] == query) {
+		return history
+	}
+	next := append(append([]string{}, history...), query)
+	return next[max(0, len(next)-transactionsSearchHistoryMax):]
+}
+
+// stepTransactionsSearchRecall moves through history like a shell: recall
+// counts back from the newest entry, and 0 is the empty input.
+func stepTransactionsSearchRecall(recall, historyLen, delta int) int {
+	return min(historyLen, max(0, recall+delta))
+}
+
+// recallTransactionsSearch handles up/down in the search input. It only
+// starts from an empty input (just the "/" that opening search types), and
+// reports false when it did nothing so the key can fall through.
+func (m *model) recallTransactionsSearch(delta int) bool {
+	if len(m.transactionsSearchHistory) == 0 ||
+		(m.transactionsSearchRecall == 0 && normalizeTransactionsSearchQuery(m.transactionsSearchInput.Value()) != "") {
+		return false
+	}
+	m.transactionsSearchRecall = stepTransactionsSearchRecall(m.transactionsSearchRecall, len(m.transactionsSearchHistory), delta)
+	value := "/"
+	if m.transactionsSearchRecall > 0 {
+		value = m.transactionsSearchHistory[len(m.transactionsSearchHistory)-m.transactionsSearchRecall]
+	}
+	m.transactionsSearchInput.SetValue(value)
+	m.transactionsSearchInput.CursorEnd()
+	return true
+}
+
+func (m model) saveTransactionsSearchHistoryCmd(history []string) tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveTransactionsSearchHistoryMsg{err: errors.New("database is not initialized")}
+		}
+		raw, err := json.Marshal(history)
+		if err != nil {
+			return saveTransactionsSearchHistoryMsg{err: err}
+		}
+		err = storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txSearchHistoryKey: string(raw),
+		})
+		return saveTransactionsSearchHistoryMsg{err: err}
+	}
+}
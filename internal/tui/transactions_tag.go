@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/lachiem1/giddyUp/internal/auth"
+	"github.com/lachiem1/giddyUp/internal/storage"
+	"github.com/lachiem1/giddyUp/internal/upapi"
+)
+
+// maxBulkTagTransactions caps a single bulk tag run so one keypress cannot
+// fire an unreasonable number of API requests.
+const maxBulkTagTransactions = 200
+
+type bulkTagTargetsMsg struct {
+	tag    string
+	ids    []string
+	client *upapi.Client
+	err    error
+}
+
+type bulkTagStepMsg struct {
+	tag   string
+	index int
+	err   error
+}
+
+func (m model) loadBulkTagTargetsCmd(tag string) tea.Cmd {
+	filter := m.transactionsFilter()
+	return func() tea.Msg {
+		if m.db == nil {
+			return bulkTagTargetsMsg{tag: tag, err: errors.New("database is not initialized")}
+		}
+		ids, err := queryFilteredTransactionIDs(m.db, filter, maxBulkTagTransactions+1)
+		if err != nil {
+			return bulkTagTargetsMsg{tag: tag, err: err}
+		}
+		if len(ids) == 0 {
+			return bulkTagTargetsMsg{tag: tag, err: errors.New("no transactions match the current filters")}
+		}
+		if len(ids) > maxBulkTagTransactions {
+			return bulkTagTargetsMsg{
+				tag: tag,
+				err: fmt.Errorf("narrow the filters to %d transactions or fewer to tag them in bulk", maxBulkTagTransactions),
+			}
+		}
+		pat, err := auth.LoadPAT()
+		if err != nil {
+			return bulkTagTargetsMsg{tag: tag, err: err}
+		}
+		return bulkTagTargetsMsg{tag: tag, ids: ids, client: upapi.New(pat)}
+	}
+}
+
+func (m model) bulkTagStepCmd(index int) tea.Cmd {
+	tag := m.transactionsTagName
+	client := m.transactionsTagClient
+	if index < 0 || index >= len(m.transactionsTagIDs) || client == nil {
+		return nil
+	}
+	transactionID := m.transactionsTagIDs[index]
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		if err := client.AddTagsToTransaction(ctx, transactionID, tag); err != nil {
+			return bulkTagStepMsg{tag: tag, index: index, err: err}
+		}
+		if m.db != nil {
+			if err := storage.NewTransactionsRepo(m.db).AddTag(ctx, transactionID, tag); err != nil {
+				return bulkTagStepMsg{tag: tag, index: index, err: err}
+			}
+		}
+		return bulkTagStepMsg{tag: tag, index: index}
+	}
+}
+
+func (m model) handleBulkTagTargets(msg bulkTagTargetsMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.transactionsTagging = false
+		m.transactionsTagStatus = "tag failed: " + msg.err.Error()
+		return m, nil
+	}
+	m.transactionsTagging = true
+	m.transactionsTagName = msg.tag
+	m.transactionsTagIDs = msg.ids
+	m.transactionsTagClient = msg.client
+	m.transactionsTagDone = 0
+	m.transactionsTagFailed = 0
+	m.transactionsTagFirstErr = ""
+	m.transactionsTagStatus = ""
+	return m, m.bulkTagStepCmd(0)
+}
+
+func (m model) handleBulkTagStep(msg bulkTagStepMsg) (tea.Model, tea.Cmd) {
+	if !m.transactionsTagging || msg.tag != m.transactionsTagName {
+		return m, nil
+	}
+	m.transactionsTagDone++
+	if msg.err != nil {
+		m.transactionsTagFailed++
+		if m.transactionsTagFirstErr == "" {
+			m.transactionsTagFirstErr = msg.err.Error()
+		}
+	}
+	if next := msg.index + 1; next < len(m.transactionsTagIDs) {
+		return m, m.bulkTagStepCmd(next)
+	}
+
+	total := len(m.transactionsTagIDs)
+	status := fmt.Sprintf("tagged %d/%d transactions with %q", total-m.transactionsTagFailed, total, m.transactionsTagName)
+	if m.transactionsTagFailed > 0 {
+		status += fmt.Sprintf(" (%d failed: %s)", m.transactionsTagFailed, m.transactionsTagFirstErr)
+	}
+	m.transactionsTagging = false
+	m.transactionsTagIDs = nil
+	m.transactionsTagClient = nil
+	m.transactionsTagStatus = status
+	return m, m.loadTransactionsPreviewCmd()
+}
+
+func (m model) transactionsTagProgressText() string {
+	if m.transactionsTagging {
+		text := fmt.Sprintf("tagging %d/%d with %q", m.transactionsTagDone, len(m.transactionsTagIDs), m.transactionsTagName)
+		if m.transactionsTagFailed > 0 {
+			text += fmt.Sprintf(" (%d failed)", m.transactionsTagFailed)
+		}
+		return text
+	}
+	return m.transactionsTagStatus
+}
+
+// normalizeTagName trims a tag entered in the prompt. Up tag ids are the tag
+// labels themselves and cannot be blank.
+func normalizeTagName(raw string) (string, error) {
+	tag := strings.TrimSpace(raw)
+	if tag == "" {
+		return "", errors.New("enter a tag name")
+	}
+	return tag, nil
+}
+
+func queryFilteredTransactionIDs(db *sql.DB, filter TransactionsFilter, limit int) ([]string, error) {
+	where, args, err := transactionsFilterWhere(filter)
+	if err != nil {
+		return nil, err
+	}
+	q := fmt.Sprintf(
+		"SELECT t.id FROM transactions t WHERE %s ORDER BY t.created_at DESC, t.id DESC LIMIT ?",
+		strings.Join(where, " AND "),
+	)
+	rows, err := db.QueryContext(context.Background(), q, append(args, limit)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]string, 0, 32)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// categoryTrendMonths is how many calendar months, ending with the
+// transaction's own month, the details trend covers.
+const categoryTrendMonths = 4
+
+type categoryTrendMonth struct {
+	month      string
+	spendCents int64
+}
+
+type categoryTrend struct {
+	category       string
+	weekCount      int
+	weekSpendCents int64
+	months         []categoryTrendMonth
+}
+
+type loadCategoryTrendMsg struct {
+	txID  string
+	trend categoryTrend
+	err   error
+}
+
+// loadCategoryTrendCmd fetches the category trend for the transaction under
+// the table cursor while its details pane is open. It returns nil when the
+// pane is closed or the trend for that transaction is already loaded.
+func (m model) loadCategoryTrendCmd() tea.Cmd {
+	if m.transactionsViewMode != transactionsViewModeTable ||
+		!m.transactionsPaneOpen ||
+		m.transactionsCursor < 0 ||
+		m.transactionsCursor >= len(m.transactionsRows) {
+		return nil
+	}
+	row := m.transactionsRows[m.transactionsCursor]
+	if row.id == m.transactionsTrendTxID {
+		return nil
+	}
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadCategoryTrendMsg{txID: row.id, err: errors.New("database is not initialized")}
+		}
+		trend, err := queryCategoryTrend(m.db, row.categoryID, row.createdAt)
+		return loadCategoryTrendMsg{txID: row.id, trend: trend, err: err}
+	}
+}
+
+// queryCategoryTrend buckets by the local date in created_at, as the heatmap
+// and weekly views do, so a charge just after midnight on the 1st counts
+// towards the month it was made in rather than the previous UTC month.
+func queryCategoryTrend(db *sql.DB, category string, createdAt string) (categoryTrend, error) {
+	anchor, err := time.Parse(time.RFC3339, strings.TrimSpace(createdAt))
+	if err != nil {
+		return categoryTrend{}, fmt.Errorf("parse transaction date: %w", err)
+	}
+	anchorDate := anchor.Format("2006-01-02")
+	category = strings.TrimSpace(category)
+	if category == "" {
+		category = "uncategorized"
+	}
+	trend := categoryTrend{category: category}
+
+	ctx := context.Background()
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT
+			substr(t.created_at, 1, 7) AS month,
+			SUM(-t.amount_value_in_base_units) AS spend_cents,
+			SUM(CASE WHEN substr(t.created_at, 1, 10) >= date(?, '-6 days') THEN 1 ELSE 0 END) AS week_count,
+			SUM(CASE WHEN substr(t.created_at, 1, 10) >= date(?, '-6 days') THEN -t.amount_value_in_base_units ELSE 0 END) AS week_spend
+		 FROM transactions t
+		 WHERE t.is_active = 1
+		   AND t.transfer_account_id IS NULL
+		   AND t.amount_value_in_base_units < 0
+		   AND LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) = ?
+		   AND substr(t.created_at, 1, 10) >= date(?, 'start of month', ?)
+		   AND substr(t.created_at, 1, 10) <= date(?)
+		 GROUP BY month`,
+		anchorDate,
+		anchorDate,
+		strings.ToLower(category),
+		anchorDate,
+		fmt.Sprintf("-%d months", categoryTrendMonths-1),
+		anchorDate,
+	)
+	if err != nil {
+		return categoryTrend{}, err
+	}
+	defer rows.Close()
+
+	byMonth := make(map[string]int64, categoryTrendMonths)
+	for rows.Next() {
+		var month string
+		var spend int64
+		var weekCount int
+		var weekSpend int64
+		if err := rows.Scan(&month, &spend, &weekCount, &weekSpend); err != nil {
+			return categoryTrend{}, err
+		}
+		byMonth[month] = spend
+		trend.weekCount += weekCount
+		trend.weekSpendCents += weekSpend
+	}
+	if err := rows.Err(); err != nil {
+		return categoryTrend{}, err
+	}
+
+	first := time.Date(anchor.Year(), anchor.Month(), 1, 0, 0, 0, 0, time.UTC).AddDate(0, -(categoryTrendMonths - 1), 0)
+	for i := 0; i < categoryTrendMonths; i++ {
+		month := first.AddDate(0, i, 0).Format("2006-01")
+		trend.months = append(trend.months, categoryTrendMonth{month: month, spendCents: byMonth[month]})
+	}
+	return trend, nil
+}
+
+func renderCategoryTrendLines(trend categoryTrend, width int, labelStyle lipgloss.Style, valueStyle lipgloss.Style) []string {
+	charges := "charges"
+	if trend.weekCount == 1 {
+		charges = "charge"
+	}
+	lines := []string{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render(truncateDisplayWidth(trend.category+" trend", width)),
+		labelStyle.Render("last 7 days: ") + valueStyle.Render(truncateDisplayWidth(
+			fmt.Sprintf("%d %s, %s", trend.weekCount, charges, formatTimeSeriesDollar(trend.weekSpendCents)),
+			max(1, width-13),
+		)),
+	}
+
+	var peak int64
+	for _, mo := range trend.months {
+		peak = max(peak, mo.spendCents)
+	}
+	const labelWidth = 4
+	const amountWidth = 8
+	barWidth := max(1, width-labelWidth-amountWidth-2)
+	for _, mo := range trend.months {
+		label := mo.month
+		if t, err := time.Parse("2006-01", mo.month); err == nil {
+			label = t.Format("Jan")
+		}
+		bar := 0
+		if peak > 0 {
+			bar = int((mo.spendCents*int64(barWidth) + peak - 1) / peak)
+		}
+		line := fmt.Sprintf("%-*s %*s ", labelWidth, label, amountWidth, formatTimeSeriesDollar(mo.spendCents))
+		lines = append(lines, labelStyle.Render(line)+valueStyle.Render(strings.Repeat("█", bar)))
+	}
+	return lines
+}
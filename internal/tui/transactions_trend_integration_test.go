@@ -0,0 +1,79 @@
+//go:build integration
+// +build integration
+
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+func TestQueryCategoryTrendBucketsByLocalMonth(t *testing.T) {
+	db := openMigratedTestDB(t)
+	defer db.Close()
+
+	// Each charge is just after local midnight on the 1st, which is still the
+	// previous month in UTC.
+	insertTrendTransaction(t, db, "tx-mar", "2026-03-01T00:15:00+11:00", -1234)
+	insertTrendTransaction(t, db, "tx-feb-end", "2026-02-28T23:50:00+11:00", -500)
+	insertTrendTransaction(t, db, "tx-feb", "2026-02-01T00:30:00+11:00", -700)
+	insertTrendTransaction(t, db, "tx-dec", "2025-12-01T00:05:00+10:00", -300)
+
+	trend, err := queryCategoryTrend(db, "groceries", "2026-03-01T00:15:00+11:00")
+	if err != nil {
+		t.Fatalf("queryCategoryTrend() unexpected error: %v", err)
+	}
+
+	want := []categoryTrendMonth{
+		{month: "2025-12", spendCents: 300},
+		{month: "2026-01", spendCents: 0},
+		{month: "2026-02", spendCents: 1200},
+		{month: "2026-03", spendCents: 1234},
+	}
+	if len(trend.months) != len(want) {
+		t.Fatalf("queryCategoryTrend() months = %+v, want %+v", trend.months, want)
+	}
+	for i := range want {
+		if trend.months[i] != want[i] {
+			t.Fatalf("queryCategoryTrend() month %d = %+v, want %+v", i, trend.months[i], want[i])
+		}
+	}
+	if trend.weekCount != 2 || trend.weekSpendCents != 1734 {
+		t.Fatalf("queryCategoryTrend() last 7 days = %d charges, %d cents, want 2, 1734", trend.weekCount, trend.weekSpendCents)
+	}
+}
+
+func openMigratedTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("sql.Open() unexpected error: %v", err)
+	}
+	if err := storage.Migrate(context.Background(), db); err != nil {
+		db.Close()
+		t.Fatalf("storage.Migrate() unexpected error: %v", err)
+	}
+	return db
+}
+
+func insertTrendTransaction(t *testing.T, db *sql.DB, id, createdAt string, cents int64) {
+	t.Helper()
+
+	_, err := db.Exec(
+		`INSERT INTO transactions (
+			id, account_id, status, description, amount_currency_code, amount_value,
+			amount_value_in_base_units, created_at, last_fetched_at, category_id
+		) VALUES (?, 'acc-1', 'SETTLED', 'Grocer', 'AUD', '0.00', ?, ?, ?, 'groceries')`,
+		id, cents, createdAt, createdAt,
+	)
+	if err != nil {
+		t.Fatalf("insert transaction %s: %v", id, err)
+	}
+}
@@ -6,8 +6,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -21,6 +24,30 @@ import (
 type transactionSortOption struct {
 	label   string
 	orderBy string
+	// grouped sorts leave ties to transactionsTableOrderBy so the secondary
+	// date order can be chosen separately.
+	grouped bool
+	keyset  int
+}
+
+// Keyset modes for sort options ordered purely by (created_at, id). Those
+// sorts can page by seeking past the neighbouring page's boundary row
+// instead of scanning and discarding a large OFFSET.
+const (
+	transactionsKeysetNone = iota
+	transactionsKeysetAsc
+	transactionsKeysetDesc
+)
+
+// transactionsPageKey records the boundary rows of a loaded table page so
+// the next or previous page can be fetched by keyset.
+type transactionsPageKey struct {
+	signature      string
+	page           int
+	firstCreatedAt string
+	firstID        string
+	lastCreatedAt  string
+	lastID         string
 }
 
 type transactionQuickRange struct {
@@ -28,14 +55,44 @@ type transactionQuickRange struct {
 	apply func(now time.Time) (time.Time, time.Time)
 }
 
+type transactionsPreviewResult struct {
+	rows          []transactionPreviewRow
+	categorySpend []transactionsCategorySpend
+	timeSeries    []transactionsTimeSeriesPoint
+	lastFetchedAt *time.Time
+	total         int
+	page          int
+	largeCount    int
+	cashbackCents int64
+	net           transactionsNetSummary
+	hasComparison bool
+	pageKey       transactionsPageKey
+	dailyCounts   []int64
+	weeklySpend   []transactionsWeeklySpend
+	heatmap       spendHeatmap
+}
+
 const (
 	txFilterFromDateKey        = "transactions.filter.from_date"
 	txFilterToDateKey          = "transactions.filter.to_date"
 	txFilterModeKey            = "transactions.filter.mode"
 	txFilterQuickIdxKey        = "transactions.filter.quick_idx"
 	txFilterIncludeInternalKey = "transactions.filter.include_internal_transfers"
+	txLargeThresholdKey        = "transactions.large_threshold"
+	txIgnoredMerchantsKey      = "transactions.ignored_merchants"
+	txHiddenCategoriesKey      = "transactions.hidden_categories"
+	txPageSizeKey              = "transactions.page_size"
+	txTimeSeriesGroupKey       = "transactions.time_series.granularity"
+	txViewModeKey              = "transactions.view_mode"
+	txChartGroupingKey         = "transactions.chart.grouping"
+	// txDefaultQuickRangeKey is only read, as a fallback for txDefaultRangeKey.
+	txDefaultQuickRangeKey = "transactions.default_quick_range"
 )
 
+// transactionsDefaultQuickIdx is the quick range ("3m") used on first entry
+// when none is configured.
+const transactionsDefaultQuickIdx = 2
+
 func renderTransactionsTitle() string {
 	// Reuse exact accounts glyphs for shared letters: A, C, O, N, T, S.
 	glyphs := map[rune][3]string{
@@ -80,10 +137,28 @@ func (m model) loadTransactionsPreviewCmd() tea.Cmd {
 	fromDigits := m.transactionsFromDate
 	toDigits := m.transactionsToDate
 	includeInternal := m.transactionsIncludeInternal
+	amountSign := m.transactionsAmountSign
 	sortIdx := m.transactionsSortIdx
+	sortNewestFirst := m.transactionsSortNewestFirst
 	viewMode := m.transactionsViewMode
 	searchQuery := m.transactionsSearchApplied
 	timeSeriesCategory := strings.TrimSpace(m.transactionsTimeSeriesCategory)
+	timeSeriesRoundUps := m.transactionsTimeSeriesRoundUps
+	roundUpsMonthly := timeSeriesRoundUps && m.transactionsRoundUpsMonthly
+	chartSort := transactionsChartSortSpend
+	chartVsPrevious := false
+	chartByMerchant := false
+	chartByParent := false
+	chartParentDrill := ""
+	if viewMode == transactionsViewModeChart {
+		chartSort = m.transactionsChartSort
+		chartVsPrevious = m.transactionsChartVsPrevious
+		chartByMerchant = m.transactionsChartByMerchant
+		chartByParent = m.transactionsChartShowsParents()
+		chartParentDrill = m.transactionsChartParentDrill
+	}
+	pageKey := m.transactionsPageKey
+	aggregates := transactionsAggregatesFor(viewMode)
 	return func() tea.Msg {
 		if m.db == nil {
 			return loadTransactionsPreviewMsg{err: fmt.Errorf("database is not initialized")}
@@ -94,35 +169,82 @@ func (m model) loadTransactionsPreviewCmd() tea.Cmd {
 		if page < 0 {
 			page = 0
 		}
-		sorts := transactionsSortOptions()
-		orderBy := sorts[0].orderBy
+		orderBy := transactionsTableOrderBy(0, false)
+		keyset := transactionsTableKeyset(0)
 		if viewMode == transactionsViewModeTable {
-			if sortIdx < 0 || sortIdx >= len(sorts) {
-				sortIdx = 0
-			}
-			orderBy = sorts[sortIdx].orderBy
+			orderBy = transactionsTableOrderBy(sortIdx, sortNewestFirst)
+			keyset = transactionsTableKeyset(sortIdx)
 		}
-		rows, categorySpend, timeSeries, fetchedAt, total, clampedPage, err := queryTransactionsPreview(
-			m.db,
-			fromDigits,
-			toDigits,
-			includeInternal,
-			searchQuery,
-			timeSeriesCategory,
-			orderBy,
-			page,
-			pageSize,
-		)
+		thresholdRaw, _, err := storage.NewAppConfigRepo(m.db).Get(context.Background(), txLargeThresholdKey)
+		if err != nil {
+			return loadTransactionsPreviewMsg{err: err}
+		}
+		// An unparsable stored threshold just disables the alert rather than breaking the view.
+		largeThreshold, _ := parseLargeThresholdCents(thresholdRaw)
+		ignoredMerchants, err := loadIgnoredMerchants(context.Background(), m.db)
+		if err != nil {
+			return loadTransactionsPreviewMsg{err: err}
+		}
+		hiddenCategories, err := loadHiddenCategories(context.Background(), m.db)
+		if err != nil {
+			return loadTransactionsPreviewMsg{err: err}
+		}
+		aliases, err := loadMerchantAliases(context.Background(), m.db)
+		if err != nil {
+			return loadTransactionsPreviewMsg{err: err}
+		}
+		categoryNames, err := loadCategoryAliases(context.Background(), m.db)
+		if err != nil {
+			return loadTransactionsPreviewMsg{err: err}
+		}
+		result, err := queryTransactionsPreview(m.db, transactionsPreviewQuery{
+			fromDigits:          fromDigits,
+			toDigits:            toDigits,
+			includeInternal:     includeInternal,
+			amountSign:          amountSign,
+			searchQuery:         searchQuery,
+			timeSeriesCategory:  timeSeriesCategory,
+			timeSeriesRoundUps:  timeSeriesRoundUps,
+			roundUpsMonthly:     roundUpsMonthly,
+			orderBy:             orderBy,
+			keyset:              keyset,
+			prevKey:             pageKey,
+			page:                page,
+			pageSize:            pageSize,
+			largeThresholdCents: largeThreshold,
+			chartSort:           chartSort,
+			chartVsPrevious:     chartVsPrevious,
+			chartByMerchant:     chartByMerchant,
+			chartByParent:       chartByParent,
+			chartParentDrill:    chartParentDrill,
+			ignoredMerchants:    ignoredMerchants,
+			hiddenCategories:    hiddenCategories,
+			aggregates:          aggregates,
+		})
 		if err != nil {
 			return loadTransactionsPreviewMsg{err: err}
 		}
 		return loadTransactionsPreviewMsg{
-			rows:          rows,
-			categorySpend: categorySpend,
-			timeSeries:    timeSeries,
-			lastFetchedAt: fetchedAt,
-			totalCount:    total,
-			page:          clampedPage,
+			rows:           result.rows,
+			categorySpend:  result.categorySpend,
+			timeSeries:     result.timeSeries,
+			lastFetchedAt:  result.lastFetchedAt,
+			totalCount:     result.total,
+			page:           result.page,
+			largeThreshold: largeThreshold,
+			largeCount:     result.largeCount,
+			cashbackCents:  result.cashbackCents,
+			net:            result.net,
+			hasComparison:  result.hasComparison,
+			pageKey:        result.pageKey,
+			ignored:        ignoredMerchants,
+			hidden:         hiddenCategories,
+			aliases:        aliases,
+			categoryNames:  categoryNames,
+			dailyCounts:    result.dailyCounts,
+			weeklySpend:    result.weeklySpend,
+			heatmap:        result.heatmap,
+			aggregates:     aggregates,
 		}
 	}
 }
@@ -131,7 +253,10 @@ func (m model) loadCategoryTransactionsCmd(category string, sortIdx int) tea.Cmd
 	fromDigits := m.transactionsFromDate
 	toDigits := m.transactionsToDate
 	includeInternal := m.transactionsIncludeInternal
+	amountSign := m.transactionsAmountSign
 	searchQuery := m.transactionsSearchApplied
+	byMerchant := m.transactionsChartByMerchant
+	byParent := m.transactionsChartShowsParents()
 	sorts := transactionsCategoryTransactionSortOptions()
 	if len(sorts) == 0 {
 		sorts = []transactionSortOption{
@@ -146,14 +271,22 @@ func (m model) loadCategoryTransactionsCmd(category string, sortIdx int) tea.Cmd
 		if m.db == nil {
 			return loadCategoryTransactionsMsg{err: fmt.Errorf("database is not initialized")}
 		}
+		ignoredMerchants, err := loadIgnoredMerchants(context.Background(), m.db)
+		if err != nil {
+			return loadCategoryTransactionsMsg{err: err}
+		}
 		rows, err := queryCategoryTransactions(
 			m.db,
 			fromDigits,
 			toDigits,
 			includeInternal,
+			amountSign,
 			searchQuery,
 			category,
+			byMerchant,
+			byParent,
 			orderBy,
+			ignoredMerchants,
 		)
 		return loadCategoryTransactionsMsg{
 			category: category,
@@ -170,6 +303,9 @@ func (m model) loadTransactionsFiltersCmd() tea.Cmd {
 	defaultMode := m.transactionsFilterMode
 	defaultQuick := m.transactionsQuickIdx
 	defaultIncludeInternal := m.transactionsIncludeInternal
+	defaultGrouping := m.transactionsTimeSeriesGrouping
+	defaultViewMode := m.transactionsViewMode
+	defaultChartGrouping := m.transactionsChartGrouping()
 	return func() tea.Msg {
 		if m.db == nil {
 			return loadTransactionsFiltersMsg{err: fmt.Errorf("database is not initialized")}
@@ -197,6 +333,30 @@ func (m model) loadTransactionsFiltersCmd() tea.Cmd {
 		if err != nil {
 			return loadTransactionsFiltersMsg{err: err}
 		}
+		groupRaw, groupFound, err := repo.Get(ctx, txTimeSeriesGroupKey)
+		if err != nil {
+			return loadTransactionsFiltersMsg{err: err}
+		}
+		viewModeRaw, viewModeFound, err := repo.Get(ctx, txViewModeKey)
+		if err != nil {
+			return loadTransactionsFiltersMsg{err: err}
+		}
+		chartGroupingRaw, chartGroupingFound, err := repo.Get(ctx, txChartGroupingKey)
+		if err != nil {
+			return loadTransactionsFiltersMsg{err: err}
+		}
+		defaultRange, err := loadTransactionsDefaultRange(ctx, repo)
+		if err != nil {
+			return loadTransactionsFiltersMsg{err: err}
+		}
+		cycle, err := loadPayCycleSettings(ctx, repo)
+		if err != nil {
+			return loadTransactionsFiltersMsg{err: err}
+		}
+		historyRaw, _, err := repo.Get(ctx, txSearchHistoryKey)
+		if err != nil {
+			return loadTransactionsFiltersMsg{err: err}
+		}
 
 		mode := defaultMode
 		if modeFound {
@@ -217,17 +377,45 @@ func (m model) loadTransactionsFiltersCmd() tea.Cmd {
 		if !toFound {
 			to = defaultTo
 		}
+		// With no saved range yet, start from the configured default.
+		if !fromFound && !toFound && !modeFound {
+			if defaultRange.isCustom() {
+				mode = transactionsFilterModeCustom
+				from, to = defaultRange.fromDate, defaultRange.toDate
+			} else {
+				mode = transactionsFilterModeQuick
+				quickIdx = defaultRange.quickIdx
+				from, to = transactionsQuickRangeDigits(quickIdx, time.Now(), cycle)
+			}
+		}
 		includeInternal := defaultIncludeInternal
 		if includeFound {
 			v := strings.ToLower(strings.TrimSpace(includeRaw))
 			includeInternal = v == "1" || v == "true" || v == "yes" || v == "on"
 		}
+		grouping := defaultGrouping
+		if groupFound {
+			grouping = parseTimeSeriesGroup(groupRaw)
+		}
+		viewMode := defaultViewMode
+		if viewModeFound {
+			viewMode = parseTransactionsViewMode(viewModeRaw)
+		}
+		chartGrouping := defaultChartGrouping
+		if chartGroupingFound {
+			chartGrouping = parseTransactionsChartGrouping(chartGroupingRaw)
+		}
 		return loadTransactionsFiltersMsg{
 			fromDate:        strings.TrimSpace(from),
 			toDate:          strings.TrimSpace(to),
 			mode:            mode,
 			quickIdx:        quickIdx,
 			includeInternal: includeInternal,
+			granularity:     grouping,
+			viewMode:        viewMode,
+			chartGrouping:   chartGrouping,
+			searchHistory:   parseTransactionsSearchHistory(historyRaw),
+			payCycle:        cycle,
 		}
 	}
 }
@@ -257,89 +445,490 @@ func (m model) saveTransactionsFiltersCmd() tea.Cmd {
 	}
 }
 
-func appendTransactionsSearchClauses(searchQuery string, where *[]string, args *[]any) error {
+var transactionsViewModeNames = []string{"table", "chart", "time_series", "weekly", "heatmap"}
+
+func (m model) saveTransactionsViewModeCmd() tea.Cmd {
+	mode := transactionsViewModeNames[transactionsViewModeTable]
+	if m.transactionsViewMode >= 0 && m.transactionsViewMode < len(transactionsViewModeNames) {
+		mode = transactionsViewModeNames[m.transactionsViewMode]
+	}
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveTransactionsFiltersMsg{err: fmt.Errorf("database is not initialized")}
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txViewModeKey: mode,
+		})
+		return saveTransactionsFiltersMsg{err: err}
+	}
+}
+
+// parseTransactionsViewMode reads a stored view mode, falling back to the
+// table for anything unrecognised.
+func parseTransactionsViewMode(raw string) int {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	for mode, name := range transactionsViewModeNames {
+		if raw == name {
+			return mode
+		}
+	}
+	return transactionsViewModeTable
+}
+
+// Spend chart grouping levels, stored by name under txChartGroupingKey.
+const (
+	transactionsChartGroupCategory = iota
+	transactionsChartGroupParent
+	transactionsChartGroupMerchant
+)
+
+var transactionsChartGroupingNames = []string{"category", "parent", "merchant"}
+
+func (m model) transactionsChartGrouping() int {
+	switch {
+	case m.transactionsChartByMerchant:
+		return transactionsChartGroupMerchant
+	case m.transactionsChartByParent:
+		return transactionsChartGroupParent
+	default:
+		return transactionsChartGroupCategory
+	}
+}
+
+func (m *model) setTransactionsChartGrouping(grouping int) {
+	m.transactionsChartByMerchant = grouping == transactionsChartGroupMerchant
+	m.transactionsChartByParent = grouping == transactionsChartGroupParent
+	m.transactionsChartParentDrill = ""
+}
+
+// parseTransactionsChartGrouping reads a stored chart grouping, falling back
+// to individual categories for anything unrecognised.
+func parseTransactionsChartGrouping(raw string) int {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	for grouping, name := range transactionsChartGroupingNames {
+		if raw == name {
+			return grouping
+		}
+	}
+	return transactionsChartGroupCategory
+}
+
+func (m model) saveTransactionsChartGroupingCmd() tea.Cmd {
+	grouping := transactionsChartGroupingNames[m.transactionsChartGrouping()]
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveTransactionsFiltersMsg{err: fmt.Errorf("database is not initialized")}
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txChartGroupingKey: grouping,
+		})
+		return saveTransactionsFiltersMsg{err: err}
+	}
+}
+
+const (
+	transactionsMinPageSize     = 5
+	transactionsMaxPageSize     = 50
+	transactionsDefaultPageSize = 15
+)
+
+func (m model) loadTransactionsPageSizeCmd() tea.Cmd {
+	return func() tea.Msg {
+		if m.db == nil {
+			return loadTransactionsPageSizeMsg{err: fmt.Errorf("database is not initialized")}
+		}
+		raw, found, err := storage.NewAppConfigRepo(m.db).Get(context.Background(), txPageSizeKey)
+		if err != nil {
+			return loadTransactionsPageSizeMsg{err: err}
+		}
+		pageSize := transactionsDefaultPageSize
+		if found {
+			pageSize = parseTransactionsPageSize(raw)
+		}
+		return loadTransactionsPageSizeMsg{pageSize: pageSize}
+	}
+}
+
+func (m model) saveTransactionsPageSizeCmd() tea.Cmd {
+	pageSize := clampTransactionsPageSize(m.transactionsPageSize)
+	return func() tea.Msg {
+		if m.db == nil {
+			return saveTransactionsFiltersMsg{err: fmt.Errorf("database is not initialized")}
+		}
+		err := storage.NewAppConfigRepo(m.db).UpsertMany(context.Background(), map[string]string{
+			txPageSizeKey: strconv.Itoa(pageSize),
+		})
+		return saveTransactionsFiltersMsg{err: err}
+	}
+}
+
+// parseTransactionsPageSize reads a stored page size, clamping out-of-range
+// values and falling back to the default when the value is not a number.
+func parseTransactionsPageSize(raw string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return transactionsDefaultPageSize
+	}
+	return clampTransactionsPageSize(n)
+}
+
+func clampTransactionsPageSize(n int) int {
+	return min(transactionsMaxPageSize, max(transactionsMinPageSize, n))
+}
+
+// transactionsSearchTerm is one field: value pair from a search query. raw
+// and col locate it in the query for error messages.
+type transactionsSearchTerm struct {
+	field string
+	value string
+	raw   string
+	col   int
+}
+
+// transactionsSearchError says which part of a query could not be read and
+// why. col is 1-based within the query with any leading "/" dropped.
+type transactionsSearchError struct {
+	col    int
+	term   string
+	reason string
+}
+
+func (e *transactionsSearchError) Error() string {
+	if e.term == "" {
+		return "invalid search: " + e.reason
+	}
+	return fmt.Sprintf("invalid search at col %d %q: %s", e.col, e.term, e.reason)
+}
+
+func (t transactionsSearchTerm) syntaxError(reason string) error {
+	return &transactionsSearchError{col: t.col, term: t.raw, reason: reason}
+}
+
+// transactionsSearchFields lists the fields transactionsSearchClause knows.
+var transactionsSearchFields = []string{
+	"merchant", "description", "note", "category", "exclude-category", "tag", "exclude-tag", "account", "exclude-account", "type", "status", "currency", "has", "amount", "date",
+}
+
+// parseTransactionsSearch splits a query into groups joined by "+" (AND),
+// each holding one or more terms joined by "|" (OR). Help and reset queries
+// parse to no groups.
+func parseTransactionsSearch(searchQuery string) ([][]transactionsSearchTerm, error) {
 	if isTransactionsSearchHelpQuery(searchQuery) || isTransactionsSearchResetQuery(searchQuery) {
-		return nil
+		return nil, nil
 	}
 	normalized := normalizeTransactionsSearchQuery(searchQuery)
 	if normalized == "" {
-		return nil
+		return nil, nil
+	}
+	if err := checkTransactionsSearchSeparators(normalized, 0); err != nil {
+		return nil, err
 	}
 
-	parts := splitTransactionsSearchParts(normalized)
+	// locate finds text at or after the previous term, so repeated terms
+	// report their own column.
+	cursor := 0
+	locate := func(text string) int {
+		idx := strings.Index(normalized[cursor:], text)
+		if idx < 0 {
+			return cursor + 1
+		}
+		cursor += idx
+		return cursor + 1
+	}
+
+	groups := make([][]transactionsSearchTerm, 0, 4)
 	lastField := ""
-	for _, rawPart := range parts {
-		part := strings.TrimSpace(rawPart)
-		if part == "" {
-			return fmt.Errorf("invalid search syntax")
+	for _, rawGroup := range splitTransactionsSearchParts(normalized) {
+		group := strings.TrimSpace(rawGroup)
+		groupCol := locate(group)
+		hasOpen := strings.HasPrefix(group, "(")
+		hasClose := strings.HasSuffix(group, ")")
+		if hasOpen != hasClose {
+			return nil, &transactionsSearchError{col: groupCol, term: group, reason: "unbalanced parentheses"}
+		}
+		if hasOpen {
+			group = strings.TrimSpace(group[1 : len(group)-1])
+			if err := checkTransactionsSearchSeparators(group, groupCol); err != nil {
+				return nil, err
+			}
 		}
 
-		field := ""
-		value := ""
-		colon := strings.Index(part, ":")
-		switch {
-		case colon > 0:
-			if colon == len(part)-1 {
-				return fmt.Errorf("invalid search syntax")
+		orParts := splitTransactionsSearchOn(group, '|')
+		terms := make([]transactionsSearchTerm, 0, len(orParts))
+		for _, rawPart := range orParts {
+			part := strings.TrimSpace(rawPart)
+			term := transactionsSearchTerm{raw: part, col: locate(part)}
+			if part == "" {
+				return nil, term.syntaxError("empty term")
 			}
-			field = strings.ToLower(strings.TrimSpace(part[:colon]))
-			value = strings.TrimSpace(part[colon+1:])
-		case colon == -1 && lastField == "exclude-category":
-			// Allow shorthand continuation for exclude-category:
-			//   /exclude-category: uncat + hobb
-			field = lastField
-			value = part
-		default:
-			return fmt.Errorf("invalid search syntax")
-		}
-		if value == "" {
-			return fmt.Errorf("invalid search syntax")
-		}
-
-		switch field {
-		case "merchant":
-			*where = append(*where, `LOWER(COALESCE(
-				NULLIF(t.merchant_norm, ''),
-				NULLIF(t.raw_text_norm, ''),
-				NULLIF(t.description_norm, ''),
-				COALESCE(t.raw_text, t.description, '')
-			)) LIKE ?`)
-			*args = append(*args, "%"+strings.ToLower(value)+"%")
-		case "description":
-			*where = append(*where, `LOWER(COALESCE(
-				NULLIF(t.description_norm, ''),
-				COALESCE(t.description, '')
-			)) LIKE ?`)
-			*args = append(*args, "%"+strings.ToLower(value)+"%")
-		case "category":
-			*where = append(*where, "LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) LIKE ?")
-			*args = append(*args, "%"+strings.ToLower(value)+"%")
-		case "exclude-category":
-			*where = append(*where, "LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) NOT LIKE ?")
-			*args = append(*args, "%"+strings.ToLower(value)+"%")
-		case "type":
-			sign, ok := parseTransactionTypeValue(value)
-			if !ok {
-				return fmt.Errorf("invalid search syntax")
+			if strings.ContainsAny(part, "()") {
+				return nil, term.syntaxError("parentheses can only wrap a whole | group")
 			}
-			if sign > 0 {
-				*where = append(*where, "t.amount_value_in_base_units > 0")
-			} else {
-				*where = append(*where, "t.amount_value_in_base_units < 0")
+
+			colon := strings.Index(part, ":")
+			if eq := strings.Index(part, "="); eq > 0 && (colon == -1 || eq < colon) &&
+				strings.EqualFold(strings.TrimSpace(part[:eq]), "merchant") {
+				// "merchant= uber" is shorthand for "merchant: =uber".
+				part = "merchant: =" + strings.TrimSpace(part[eq+1:])
+				colon = len("merchant")
+			}
+			switch {
+			case colon > 0:
+				term.field = strings.ToLower(strings.TrimSpace(part[:colon]))
+				term.value = strings.TrimSpace(part[colon+1:])
+			case colon == -1 && (lastField == "exclude-category" || lastField == "exclude-tag"):
+				// Allow shorthand continuation for the exclude fields:
+				//   /exclude-category: uncat + hobb
+				term.field = lastField
+				term.value = part
+			default:
+				return nil, term.syntaxError("expected field: value, e.g. merchant: woo")
 			}
-		case "amount":
-			op, cents, ok := parseTransactionAmountValue(value)
-			if !ok {
-				return fmt.Errorf("invalid search syntax")
+			if term.value == "" {
+				return nil, term.syntaxError(term.field + ": missing a value")
 			}
-			*where = append(*where, fmt.Sprintf("ABS(t.amount_value_in_base_units) %s ?", op))
-			*args = append(*args, cents)
-		default:
-			return fmt.Errorf("invalid search syntax")
+			if next := strings.Index(term.value, ":"); next > 0 {
+				words := strings.Fields(term.value[:next])
+				if len(words) > 1 && slices.Contains(transactionsSearchFields, strings.ToLower(words[len(words)-1])) {
+					return nil, term.syntaxError("looks like two terms; join them with ' + ' or ' | '")
+				}
+			}
+			terms = append(terms, term)
+			lastField = term.field
+		}
+		groups = append(groups, terms)
+	}
+	return groups, nil
+}
+
+// appendTransactionsSearchClauses parses the search bar syntax into WHERE
+// clauses. Terms joined by " + " are ANDed; terms joined by " | " form a
+// single parenthesised OR block, so "|" binds tighter than "+". An OR group
+// may optionally be wrapped in parentheses:
+//
+//	type: -ve + (merchant: WOOL | merchant: COLES)
+func appendTransactionsSearchClauses(searchQuery string, where *[]string, args *[]any) error {
+	groups, err := parseTransactionsSearch(searchQuery)
+	if err != nil {
+		return err
+	}
+	for _, terms := range groups {
+		clauses := make([]string, 0, len(terms))
+		for _, term := range terms {
+			clause, clauseArgs, err := transactionsSearchClause(term.field, term.value)
+			if err != nil {
+				return term.syntaxError(err.Error())
+			}
+			clauses = append(clauses, clause)
+			*args = append(*args, clauseArgs...)
+		}
+		if len(clauses) == 1 {
+			*where = append(*where, clauses[0])
+		} else {
+			*where = append(*where, "("+strings.Join(clauses, " OR ")+")")
+		}
+	}
+	return nil
+}
+
+// transactionsAmountSignClause restricts to credits for a positive sign and
+// debits for a negative one; zero adds no clause.
+func transactionsAmountSignClause(sign int) string {
+	switch {
+	case sign > 0:
+		return "t.amount_value_in_base_units > 0"
+	case sign < 0:
+		return "t.amount_value_in_base_units < 0"
+	default:
+		return ""
+	}
+}
+
+// transactionsTagExistsSQL matches transactions carrying an active tag with
+// the bound name, ignoring case.
+const transactionsTagExistsSQL = `EXISTS (
+			SELECT 1 FROM transaction_tags tt
+			WHERE tt.transaction_id = t.id
+			  AND tt.is_active = 1
+			  AND LOWER(tt.tag_id) = ?
+		)`
+
+// transactionsAccountInSQL matches transactions whose account name, local
+// or synced, contains the bound pattern. It uses a subquery rather than the
+// preview's accounts join so every filtered query can share it.
+const transactionsAccountInSQL = `t.account_id IN (
+			SELECT ac.id FROM accounts ac
+			WHERE LOWER(COALESCE(NULLIF(TRIM(ac.display_name_override), ''), ac.display_name)) LIKE ?
+		)`
+
+// transactionsNoteSQL matches Up's note text or your own local note; each
+// side takes the same bound pattern.
+const transactionsNoteSQL = `(LOWER(COALESCE(t.note_text, '')) LIKE ? OR EXISTS (
+			SELECT 1 FROM transaction_notes n
+			WHERE n.transaction_id = t.id AND LOWER(n.note) LIKE ?
+		))`
+
+func transactionsSearchClause(field, value string) (string, []any, error) {
+	var clause string
+	var clauseArgs []any
+	switch field {
+	case "merchant":
+		if exact, ok := strings.CutPrefix(value, "="); ok {
+			exact = strings.TrimSpace(exact)
+			if exact == "" {
+				return "", nil, errors.New("merchant: expected a name after '='")
+			}
+			clause = "LOWER(" + transactionsMerchantSQL + ") = ?"
+			clauseArgs = append(clauseArgs, strings.ToLower(exact))
+			break
+		}
+		clause = `LOWER(COALESCE(
+			NULLIF(t.merchant_norm, ''),
+			NULLIF(t.raw_text_norm, ''),
+			NULLIF(t.description_norm, ''),
+			COALESCE(t.raw_text, t.description, '')
+		)) LIKE ?`
+		clauseArgs = append(clauseArgs, "%"+strings.ToLower(value)+"%")
+	case "description":
+		clause = `LOWER(COALESCE(
+			NULLIF(t.description_norm, ''),
+			COALESCE(t.description, '')
+		)) LIKE ?`
+		clauseArgs = append(clauseArgs, "%"+strings.ToLower(value)+"%")
+	case "note":
+		clause = transactionsNoteSQL
+		pattern := "%" + strings.ToLower(value) + "%"
+		clauseArgs = append(clauseArgs, pattern, pattern)
+	case "category":
+		clause = "LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) LIKE ?"
+		clauseArgs = append(clauseArgs, "%"+strings.ToLower(value)+"%")
+	case "exclude-category":
+		clause = "LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) NOT LIKE ?"
+		clauseArgs = append(clauseArgs, "%"+strings.ToLower(value)+"%")
+	case "tag":
+		clause = transactionsTagExistsSQL
+		clauseArgs = append(clauseArgs, strings.ToLower(value))
+	case "exclude-tag":
+		clause = "NOT " + transactionsTagExistsSQL
+		clauseArgs = append(clauseArgs, strings.ToLower(value))
+	case "account":
+		clause = transactionsAccountInSQL
+		clauseArgs = append(clauseArgs, "%"+strings.ToLower(value)+"%")
+	case "exclude-account":
+		clause = "NOT " + transactionsAccountInSQL
+		clauseArgs = append(clauseArgs, "%"+strings.ToLower(value)+"%")
+	case "type":
+		if isTransactionCashbackType(value) {
+			clause = transactionsCashbackSQL
+			break
+		}
+		sign, ok := parseTransactionTypeValue(value)
+		if !ok {
+			return "", nil, errors.New("type: expected +ve, -ve or cashback")
+		}
+		clause = transactionsAmountSignClause(sign)
+	case "status":
+		status, ok := parseTransactionStatusValue(value)
+		if !ok {
+			return "", nil, errors.New("status: expected held or settled")
+		}
+		clause = "UPPER(TRIM(t.status)) = ?"
+		clauseArgs = append(clauseArgs, status)
+	case "currency":
+		code, ok := parseTransactionCurrencyValue(value)
+		if !ok {
+			return "", nil, errors.New("currency: expected a three-letter code like USD")
+		}
+		clause = transactionsCurrencySQL
+		clauseArgs = append(clauseArgs, code)
+	case "has":
+		if !isTransactionAttachmentValue(value) {
+			return "", nil, errors.New("has: expected attachment")
+		}
+		clause = transactionsAttachmentSQL
+	case "amount":
+		op, cents, highCents, ok := parseTransactionAmountValue(value)
+		if !ok {
+			if strings.Contains(value, "..") {
+				return "", nil, errors.New("amount: expected a number on both sides of '..'")
+			}
+			return "", nil, fmt.Errorf("amount: expected a number%s", transactionsCompareOpSuffix(value))
+		}
+		if op == "BETWEEN" {
+			if cents > highCents {
+				return "", nil, errors.New("amount: range low bound is above the high bound")
+			}
+			clause = "ABS(t.amount_value_in_base_units) BETWEEN ? AND ?"
+			clauseArgs = append(clauseArgs, cents, highCents)
+			break
+		}
+		clause = fmt.Sprintf("ABS(t.amount_value_in_base_units) %s ?", op)
+		clauseArgs = append(clauseArgs, cents)
+	case "date":
+		op, date, ok := parseTransactionDateValue(value)
+		if !ok {
+			return "", nil, fmt.Errorf("date: expected YYYY-MM-DD%s", transactionsCompareOpSuffix(value))
+		}
+		clause = fmt.Sprintf("date(t.created_at) %s date(?)", op)
+		clauseArgs = append(clauseArgs, date)
+	default:
+		return "", nil, fmt.Errorf("unknown field %q; use one of %s", field, strings.Join(transactionsSearchFields, ", "))
+	}
+	return clause, clauseArgs, nil
+}
+
+// transactionsCompareOpSuffix names the comparison operator a value starts
+// with, so "amount: >x" reports "expected a number after '>'".
+func transactionsCompareOpSuffix(value string) string {
+	v := strings.TrimSpace(value)
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(v, op) {
+			return " after '" + op + "'"
+		}
+	}
+	return ""
+}
+
+// checkTransactionsSearchSeparators rejects a query that starts or ends with
+// a "+" or "|" separator, which would otherwise be read as part of a value.
+// offset is the column before query within the full search.
+func checkTransactionsSearchSeparators(query string, offset int) error {
+	trimmed := strings.TrimSpace(query)
+	lead := offset + strings.Index(query, trimmed)
+	for _, sep := range []string{"+", "|"} {
+		switch {
+		case strings.HasPrefix(trimmed, sep):
+			return &transactionsSearchError{col: lead + 1, term: sep, reason: "nothing before " + sep}
+		case strings.HasSuffix(trimmed, sep):
+			return &transactionsSearchError{col: lead + len(trimmed), term: sep, reason: "nothing after " + sep}
 		}
-		lastField = field
 	}
+	return nil
+}
 
+func appendTransactionsDateClauses(fromDigits, toDigits string, where *[]string, args *[]any) error {
+	var fromDate, toDate string
+	if len(strings.TrimSpace(fromDigits)) == 8 {
+		v, err := parseTransactionsDateDigits(fromDigits)
+		if err != nil {
+			return err
+		}
+		fromDate = v
+		*where = append(*where, "date(t.created_at) >= date(?)")
+		*args = append(*args, fromDate)
+	}
+	if len(strings.TrimSpace(toDigits)) == 8 {
+		v, err := parseTransactionsDateDigits(toDigits)
+		if err != nil {
+			return err
+		}
+		toDate = v
+		*where = append(*where, "date(t.created_at) <= date(?)")
+		*args = append(*args, toDate)
+	}
+	if fromDate != "" && toDate != "" && fromDate > toDate {
+		return fmt.Errorf("from date cannot be after to date")
+	}
 	return nil
 }
 
@@ -362,6 +951,12 @@ func isTransactionsSearchResetQuery(searchQuery string) bool {
 }
 
 func splitTransactionsSearchParts(searchQuery string) []string {
+	return splitTransactionsSearchOn(searchQuery, '+')
+}
+
+// splitTransactionsSearchOn splits on sep when it stands alone between
+// whitespace and sits outside any parentheses.
+func splitTransactionsSearchOn(searchQuery string, sep byte) []string {
 	trimmed := strings.TrimSpace(searchQuery)
 	if trimmed == "" {
 		return nil
@@ -369,8 +964,17 @@ func splitTransactionsSearchParts(searchQuery string) []string {
 
 	parts := make([]string, 0, 4)
 	start := 0
+	depth := 0
 	for i := 0; i < len(trimmed); i++ {
-		if trimmed[i] != '+' {
+		switch trimmed[i] {
+		case '(':
+			depth++
+			continue
+		case ')':
+			depth--
+			continue
+		}
+		if trimmed[i] != sep || depth != 0 {
 			continue
 		}
 		if i == 0 || i == len(trimmed)-1 {
@@ -407,10 +1011,83 @@ func parseTransactionTypeValue(value string) (int, bool) {
 	}
 }
 
-func parseTransactionAmountValue(value string) (string, int64, bool) {
+// isTransactionCashbackType reports whether a type search value asks for
+// transactions that earned cashback.
+func isTransactionCashbackType(value string) bool {
+	return strings.EqualFold(strings.TrimSpace(value), "cashback")
+}
+
+// parseTransactionStatusValue maps a status search value to Up's
+// transaction status, HELD or SETTLED.
+func parseTransactionStatusValue(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "held", "pending":
+		return "HELD", true
+	case "settled", "cleared":
+		return "SETTLED", true
+	default:
+		return "", false
+	}
+}
+
+// isHeldTransaction reports whether Up has yet to settle a transaction.
+func isHeldTransaction(status string) bool {
+	return strings.EqualFold(strings.TrimSpace(status), "HELD")
+}
+
+// parseTransactionAmountValue reads an amount with an optional comparison
+// operator, or an inclusive "low..high" range, which returns the BETWEEN
+// operator and both bounds in cents. Bounds are not checked for order here.
+func parseTransactionAmountValue(value string) (string, int64, int64, bool) {
+	if low, high, isRange := strings.Cut(strings.TrimSpace(value), ".."); isRange {
+		lowCents, ok := parseTransactionAmountCents(low)
+		if !ok {
+			return "", 0, 0, false
+		}
+		highCents, ok := parseTransactionAmountCents(high)
+		if !ok {
+			return "", 0, 0, false
+		}
+		return "BETWEEN", lowCents, highCents, true
+	}
+
+	op, v, ok := splitTransactionCompareOp(value)
+	if !ok {
+		return "", 0, 0, false
+	}
+	cents, ok := parseTransactionAmountCents(v)
+	if !ok {
+		return "", 0, 0, false
+	}
+	return op, cents, 0, true
+}
+
+func parseTransactionAmountCents(raw string) (int64, bool) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		return 0, false
+	}
+	return int64(math.Round(math.Abs(n) * 100)), true
+}
+
+// parseTransactionDateValue accepts a YYYY-MM-DD date with the same optional
+// comparison operators as amount:.
+func parseTransactionDateValue(value string) (string, string, bool) {
+	op, v, ok := splitTransactionCompareOp(value)
+	if !ok {
+		return "", "", false
+	}
+	d, err := time.Parse("2006-01-02", v)
+	if err != nil {
+		return "", "", false
+	}
+	return op, d.Format("2006-01-02"), true
+}
+
+func splitTransactionCompareOp(value string) (string, string, bool) {
 	v := strings.TrimSpace(value)
 	if v == "" {
-		return "", 0, false
+		return "", "", false
 	}
 
 	op := "="
@@ -422,71 +1099,107 @@ func parseTransactionAmountValue(value string) (string, int64, bool) {
 		}
 	}
 	if v == "" {
-		return "", 0, false
+		return "", "", false
 	}
+	return op, v, true
+}
 
-	n, err := strconv.ParseFloat(v, 64)
-	if err != nil {
-		return "", 0, false
+// transactionsPreviewQuery holds the filters, paging and view state that
+// decide what queryTransactionsPreview loads.
+type transactionsPreviewQuery struct {
+	fromDigits          string
+	toDigits            string
+	includeInternal     bool
+	amountSign          int
+	searchQuery         string
+	timeSeriesCategory  string
+	timeSeriesRoundUps  bool
+	roundUpsMonthly     bool
+	orderBy             string
+	keyset              int
+	prevKey             transactionsPageKey
+	page                int
+	pageSize            int
+	largeThresholdCents int64
+	chartSort           int
+	chartVsPrevious     bool
+	chartByMerchant     bool
+	chartByParent       bool
+	chartParentDrill    string
+	ignoredMerchants    []string
+	hiddenCategories    []string
+	aggregates          transactionsAggregates
+}
+
+// transactionsAggregates selects the summary queries a load runs. Each one
+// scans the whole period, so only the ones the view shows are worth paying for.
+type transactionsAggregates struct {
+	spend       bool
+	timeSeries  bool
+	dailyCounts bool
+	weekly      bool
+	heatmap     bool
+	netSummary  bool
+}
+
+// transactionsAggregatesFor reports the aggregates viewMode renders. The time
+// series needs the category spend too, to validate its selected category.
+func transactionsAggregatesFor(viewMode int) transactionsAggregates {
+	switch viewMode {
+	case transactionsViewModeTable:
+		return transactionsAggregates{dailyCounts: true, netSummary: true}
+	case transactionsViewModeChart:
+		return transactionsAggregates{spend: true, netSummary: true}
+	case transactionsViewModeTimeSeries:
+		return transactionsAggregates{spend: true, timeSeries: true}
+	case transactionsViewModeWeekly:
+		return transactionsAggregates{weekly: true}
+	case transactionsViewModeHeatmap:
+		return transactionsAggregates{heatmap: true}
 	}
-	cents := int64(math.Round(math.Abs(n) * 100))
-	return op, cents, true
+	return transactionsAggregates{}
 }
 
-func queryTransactionsPreview(
-	db *sql.DB,
-	fromDigits string,
-	toDigits string,
-	includeInternal bool,
-	searchQuery string,
-	timeSeriesCategory string,
-	orderBy string,
-	page int,
-	pageSize int,
-) ([]transactionPreviewRow, []transactionsCategorySpend, []transactionsTimeSeriesPoint, *time.Time, int, int, error) {
+func queryTransactionsPreview(db *sql.DB, opts transactionsPreviewQuery) (transactionsPreviewResult, error) {
 	where := []string{"t.is_active = 1"}
 	args := make([]any, 0, 8)
-	if !includeInternal {
+	if !opts.includeInternal {
 		where = append(where, "t.transfer_account_id IS NULL")
 	}
-	if err := appendTransactionsSearchClauses(strings.TrimSpace(searchQuery), &where, &args); err != nil {
-		return nil, nil, nil, nil, 0, 0, err
+	if clause := transactionsAmountSignClause(opts.amountSign); clause != "" {
+		where = append(where, clause)
 	}
-
-	if len(strings.TrimSpace(fromDigits)) == 8 {
-		fromDate, err := parseTransactionsDateDigits(fromDigits)
-		if err != nil {
-			return nil, nil, nil, nil, 0, 0, err
-		}
-		where = append(where, "date(t.created_at) >= date(?)")
-		args = append(args, fromDate)
+	if err := appendTransactionsSearchClauses(strings.TrimSpace(opts.searchQuery), &where, &args); err != nil {
+		return transactionsPreviewResult{}, err
 	}
-	if len(strings.TrimSpace(toDigits)) == 8 {
-		toDate, err := parseTransactionsDateDigits(toDigits)
-		if err != nil {
-			return nil, nil, nil, nil, 0, 0, err
-		}
-		where = append(where, "date(t.created_at) <= date(?)")
-		args = append(args, toDate)
+	// A drilled-into parent narrows the chart to its child categories.
+	if drill := strings.ToLower(strings.TrimSpace(opts.chartParentDrill)); drill != "" {
+		where = append(where, "LOWER("+transactionsParentCategorySQL+") = ?")
+		args = append(args, drill)
 	}
-	if len(strings.TrimSpace(fromDigits)) == 8 && len(strings.TrimSpace(toDigits)) == 8 {
-		fromDate, _ := parseTransactionsDateDigits(fromDigits)
-		toDate, _ := parseTransactionsDateDigits(toDigits)
-		if fromDate > toDate {
-			return nil, nil, nil, nil, 0, 0, fmt.Errorf("from date cannot be after to date")
-		}
+	// Filters without the date window, reused to aggregate the comparison period.
+	baseWhere := append([]string{}, where...)
+	baseArgs := append([]any{}, args...)
+	appendIgnoredMerchantsClause(opts.ignoredMerchants, &baseWhere, &baseArgs)
+	appendHiddenCategoriesClause(opts.hiddenCategories, &baseWhere, &baseArgs)
+
+	if err := appendTransactionsDateClauses(opts.fromDigits, opts.toDigits, &where, &args); err != nil {
+		return transactionsPreviewResult{}, err
 	}
 
 	whereSQL := strings.Join(where, " AND ")
+	// With no search or date bounds ("all time") this is a plain count over
+	// is_active, answered from idx_transactions_active_created_at.
 	var total int
 	if err := db.QueryRowContext(
 		context.Background(),
 		fmt.Sprintf("SELECT COUNT(*) FROM transactions t WHERE %s", whereSQL),
 		args...,
 	).Scan(&total); err != nil {
-		return nil, nil, nil, nil, 0, 0, err
+		return transactionsPreviewResult{}, err
 	}
 
+	page, pageSize := opts.page, opts.pageSize
 	if pageSize <= 0 {
 		pageSize = 12
 	}
@@ -502,6 +1215,23 @@ func queryTransactionsPreview(
 	}
 	offset := page * pageSize
 
+	signature := fmt.Sprint(whereSQL, args, opts.orderBy, pageSize)
+	pageWhere := whereSQL
+	pageOrder := opts.orderBy
+	pageArgs := append([]any{}, args...)
+	reverse := false
+	if opts.prevKey.signature == signature {
+		if seek, ok := transactionsKeysetSeek(opts.keyset, opts.prevKey, page); ok {
+			pageWhere += " AND " + seek.where
+			pageArgs = append(pageArgs, seek.args...)
+			if seek.orderBy != "" {
+				pageOrder = seek.orderBy
+			}
+			reverse = seek.reverse
+			offset = 0
+		}
+	}
+
 	q := fmt.Sprintf(
 		`SELECT
 			t.created_at,
@@ -509,6 +1239,7 @@ func queryTransactionsPreview(
 			COALESCE(NULLIF(t.raw_text_norm, ''), COALESCE(t.raw_text, '')),
 			COALESCE(NULLIF(t.description_norm, ''), COALESCE(t.description, '')),
 			t.amount_value,
+			t.amount_value_in_base_units,
 			COALESCE(
 				NULLIF(t.merchant_norm, ''),
 				COALESCE(
@@ -522,19 +1253,32 @@ func queryTransactionsPreview(
 			COALESCE(t.category_id, ''),
 			COALESCE(t.card_purchase_method_method, ''),
 			COALESCE(t.note_text, ''),
-			COALESCE(a.display_name, '')
+			COALESCE((SELECT n.note FROM transaction_notes n WHERE n.transaction_id = t.id), ''),
+			t.attachment_id IS NOT NULL,
+			COALESCE(t.foreign_amount_value, ''),
+			COALESCE(t.foreign_amount_currency_code, ''),
+			COALESCE(a.display_name, ''),
+			COALESCE((
+				SELECT GROUP_CONCAT(tag_id, ', ')
+				FROM (
+					SELECT tt.tag_id
+					FROM transaction_tags tt
+					WHERE tt.transaction_id = t.id AND tt.is_active = 1
+					ORDER BY LOWER(tt.tag_id)
+				)
+			), '')
 		 FROM transactions t
 		 LEFT JOIN accounts a ON a.id = t.account_id
 		 WHERE %s
 		 ORDER BY %s
 		 LIMIT ? OFFSET ?`,
-		whereSQL,
-		orderBy,
+		pageWhere,
+		pageOrder,
 	)
-	pageArgs := append(append([]any{}, args...), pageSize, offset)
+	pageArgs = append(pageArgs, pageSize, offset)
 	rows, err := db.QueryContext(context.Background(), q, pageArgs...)
 	if err != nil {
-		return nil, nil, nil, nil, 0, 0, err
+		return transactionsPreviewResult{}, err
 	}
 	defer rows.Close()
 
@@ -547,44 +1291,247 @@ func queryTransactionsPreview(
 			&r.rawText,
 			&r.description,
 			&r.amountValue,
+			&r.amountCents,
 			&r.merchant,
 			&r.status,
 			&r.message,
 			&r.categoryID,
 			&r.cardMethod,
 			&r.noteText,
+			&r.localNote,
+			&r.hasAttachment,
+			&r.foreignAmount,
+			&r.foreignCurrency,
 			&r.accountName,
+			&r.tags,
 		); err != nil {
-			return nil, nil, nil, nil, 0, 0, err
+			return transactionsPreviewResult{}, err
 		}
 		out = append(out, r)
 	}
 	if err := rows.Err(); err != nil {
-		return nil, nil, nil, nil, 0, 0, err
+		return transactionsPreviewResult{}, err
+	}
+	if reverse {
+		for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+			out[i], out[j] = out[j], out[i]
+		}
+	}
+	pageKey := transactionsPageKey{signature: signature, page: page}
+	if len(out) > 0 {
+		pageKey.firstCreatedAt = out[0].createdAt
+		pageKey.firstID = out[0].id
+		pageKey.lastCreatedAt = out[len(out)-1].createdAt
+		pageKey.lastID = out[len(out)-1].id
+	}
+
+	// Ignored merchants and hidden categories drop out of the spend
+	// aggregates but stay in the table.
+	aggWhere := append([]string{}, where...)
+	aggArgs := append([]any{}, args...)
+	appendIgnoredMerchantsClause(opts.ignoredMerchants, &aggWhere, &aggArgs)
+	appendHiddenCategoriesClause(opts.hiddenCategories, &aggWhere, &aggArgs)
+	aggWhereSQL := strings.Join(aggWhere, " AND ")
+
+	want := opts.aggregates
+	var categorySpend []transactionsCategorySpend
+	hasComparison := false
+	if want.spend {
+		spendQuery := queryCategorySpend
+		if opts.chartByMerchant {
+			spendQuery = queryMerchantSpend
+		} else if opts.chartByParent {
+			spendQuery = queryParentCategorySpend
+		}
+		categorySpend, err = spendQuery(context.Background(), db, aggWhereSQL, aggArgs)
+		if err != nil {
+			return transactionsPreviewResult{}, err
+		}
+		if opts.chartSort == transactionsChartSortChange || opts.chartVsPrevious {
+			categorySpend, hasComparison, err = applyCategorySpendComparison(
+				context.Background(),
+				db,
+				baseWhere,
+				baseArgs,
+				opts.fromDigits,
+				opts.toDigits,
+				categorySpend,
+				spendQuery,
+			)
+			if err != nil {
+				return transactionsPreviewResult{}, err
+			}
+			if hasComparison && opts.chartSort == transactionsChartSortChange {
+				sortCategorySpendByChange(categorySpend)
+			}
+		}
 	}
 
-	categorySpend, err := queryCategorySpend(context.Background(), db, whereSQL, args)
-	if err != nil {
-		return nil, nil, nil, nil, 0, 0, err
+	var timeSeries []transactionsTimeSeriesPoint
+	if want.timeSeries {
+		if opts.roundUpsMonthly {
+			timeSeries, err = queryRoundUpsByMonth(context.Background(), db, aggWhereSQL, aggArgs)
+		} else {
+			timeSeries, err = querySpendTimeSeries(context.Background(), db, aggWhereSQL, aggArgs, opts.fromDigits, opts.toDigits, opts.timeSeriesCategory, opts.timeSeriesRoundUps)
+		}
+		if err != nil {
+			return transactionsPreviewResult{}, err
+		}
 	}
 
-	timeSeries, err := querySpendTimeSeries(context.Background(), db, whereSQL, args, fromDigits, toDigits, timeSeriesCategory)
-	if err != nil {
-		return nil, nil, nil, nil, 0, 0, err
+	var dailyCounts []int64
+	if want.dailyCounts {
+		if dailyCounts, err = queryDailyTransactionCounts(context.Background(), db, whereSQL, args); err != nil {
+			return transactionsPreviewResult{}, err
+		}
+	}
+	var weeklySpend []transactionsWeeklySpend
+	if want.weekly {
+		if weeklySpend, err = queryWeeklySpend(context.Background(), db, aggWhereSQL, aggArgs); err != nil {
+			return transactionsPreviewResult{}, err
+		}
+	}
+	var heatmap spendHeatmap
+	if want.heatmap {
+		if heatmap, err = querySpendHeatmap(context.Background(), db, aggWhereSQL, aggArgs); err != nil {
+			return transactionsPreviewResult{}, err
+		}
+	}
+
+	// The large-transaction alert shows in every view.
+	largeCount := 0
+	if opts.largeThresholdCents > 0 {
+		q, qArgs := largeTransactionsCountQuery(whereSQL, args, opts.largeThresholdCents)
+		if err := db.QueryRowContext(context.Background(), q, qArgs...).Scan(&largeCount); err != nil {
+			return transactionsPreviewResult{}, err
+		}
+	}
+	var cashbackCents int64
+	var net transactionsNetSummary
+	if want.netSummary {
+		if cashbackCents, err = queryCashbackTotal(context.Background(), db, whereSQL, args); err != nil {
+			return transactionsPreviewResult{}, err
+		}
+		if net, err = queryNetSummary(context.Background(), db, whereSQL, args); err != nil {
+			return transactionsPreviewResult{}, err
+		}
 	}
 
 	var lastSuccess *time.Time
 	stateRepo := storage.NewSyncStateRepo(db)
 	state, found, err := stateRepo.Get(context.Background(), syncer.CollectionTransactions)
 	if err != nil {
-		return nil, nil, nil, nil, 0, 0, err
+		return transactionsPreviewResult{}, err
 	}
 	if found && state.LastSuccess != nil {
 		t := state.LastSuccess.UTC()
 		lastSuccess = &t
 	}
 
-	return out, categorySpend, timeSeries, lastSuccess, total, page, nil
+	return transactionsPreviewResult{
+		rows:          out,
+		categorySpend: categorySpend,
+		timeSeries:    timeSeries,
+		lastFetchedAt: lastSuccess,
+		total:         total,
+		page:          page,
+		largeCount:    largeCount,
+		cashbackCents: cashbackCents,
+		net:           net,
+		hasComparison: hasComparison,
+		pageKey:       pageKey,
+		dailyCounts:   dailyCounts,
+		weeklySpend:   weeklySpend,
+		heatmap:       heatmap,
+	}, nil
+}
+
+type transactionsKeysetSeekClause struct {
+	where   string
+	args    []any
+	orderBy string
+	reverse bool
+}
+
+// transactionsKeysetSeek builds the (created_at, id) seek predicate for
+// stepping one page away from prevKey. It reports false when the sort or the
+// requested page can't be reached by keyset, in which case the caller keeps
+// paging by OFFSET.
+func transactionsKeysetSeek(keyset int, prevKey transactionsPageKey, page int) (transactionsKeysetSeekClause, bool) {
+	if keyset == transactionsKeysetNone || prevKey.lastID == "" {
+		return transactionsKeysetSeekClause{}, false
+	}
+	desc := keyset == transactionsKeysetDesc
+	switch page {
+	case prevKey.page + 1:
+		op := ">"
+		if desc {
+			op = "<"
+		}
+		return transactionsKeysetSeekClause{
+			where: fmt.Sprintf("(t.created_at %s ? OR (t.created_at = ? AND t.id %s ?))", op, op),
+			args:  []any{prevKey.lastCreatedAt, prevKey.lastCreatedAt, prevKey.lastID},
+		}, true
+	case prevKey.page - 1:
+		// Walk backwards from the first row of the loaded page, then
+		// restore the display order.
+		op := "<"
+		orderBy := "t.created_at DESC, t.id DESC"
+		if desc {
+			op = ">"
+			orderBy = "t.created_at ASC, t.id ASC"
+		}
+		return transactionsKeysetSeekClause{
+			where:   fmt.Sprintf("(t.created_at %s ? OR (t.created_at = ? AND t.id %s ?))", op, op),
+			args:    []any{prevKey.firstCreatedAt, prevKey.firstCreatedAt, prevKey.firstID},
+			orderBy: orderBy,
+			reverse: true,
+		}, true
+	}
+	return transactionsKeysetSeekClause{}, false
+}
+
+// parseTransactionsJumpPage reads a 1-based page number typed into the jump
+// prompt and returns the 0-based page, clamped to the last page.
+func parseTransactionsJumpPage(raw string, total, pageSize int) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || n < 1 {
+		return 0, errors.New("page must be a number from 1")
+	}
+	return min(n-1, transactionsLastPage(total, pageSize)), nil
+}
+
+// transactionsLastPage is the 0-based index of the final table page.
+func transactionsLastPage(total, pageSize int) int {
+	if pageSize <= 0 || total <= 0 {
+		return 0
+	}
+	return (total - 1) / pageSize
+}
+
+// transactionsTableFooterPosition describes where the table page sits in the
+// result set. Keyset-paged sorts step page by page from a cursor, so the page
+// count is dropped in favour of the row range.
+func transactionsTableFooterPosition(start, end, total, page, totalPages, keyset int) string {
+	if keyset != transactionsKeysetNone {
+		return fmt.Sprintf("showing %d-%d of %d", start, end, total)
+	}
+	return fmt.Sprintf("showing %d-%d/%d  |  page %d/%d", start, end, total, page+1, max(1, totalPages))
+}
+
+// appendChartBarClause narrows to one chart bar: a merchant group, a parent
+// category or a category. Merchant groups still need filterMerchantGroupRows.
+func appendChartBarClause(category string, byMerchant, byParent bool, where *[]string, args *[]any) {
+	switch {
+	case byMerchant:
+		appendMerchantGroupClause(category, where, args)
+	case byParent:
+		*where = append(*where, "LOWER("+transactionsParentCategorySQL+") = ?")
+		*args = append(*args, strings.ToLower(strings.TrimSpace(category)))
+	default:
+		*where = append(*where, "LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) = ?")
+		*args = append(*args, strings.ToLower(strings.TrimSpace(category)))
+	}
 }
 
 func queryCategoryTransactions(
@@ -592,37 +1539,30 @@ func queryCategoryTransactions(
 	fromDigits string,
 	toDigits string,
 	includeInternal bool,
+	amountSign int,
 	searchQuery string,
 	category string,
+	byMerchant bool,
+	byParent bool,
 	orderBy string,
+	ignoredMerchants []string,
 ) ([]categoryTransactionRow, error) {
 	where := []string{"t.is_active = 1"}
 	args := make([]any, 0, 10)
 	if !includeInternal {
 		where = append(where, "t.transfer_account_id IS NULL")
 	}
+	if clause := transactionsAmountSignClause(amountSign); clause != "" {
+		where = append(where, clause)
+	}
 	if err := appendTransactionsSearchClauses(strings.TrimSpace(searchQuery), &where, &args); err != nil {
 		return nil, err
 	}
-	if len(strings.TrimSpace(fromDigits)) == 8 {
-		fromDate, err := parseTransactionsDateDigits(fromDigits)
-		if err != nil {
-			return nil, err
-		}
-		where = append(where, "date(t.created_at) >= date(?)")
-		args = append(args, fromDate)
-	}
-	if len(strings.TrimSpace(toDigits)) == 8 {
-		toDate, err := parseTransactionsDateDigits(toDigits)
-		if err != nil {
-			return nil, err
-		}
-		where = append(where, "date(t.created_at) <= date(?)")
-		args = append(args, toDate)
+	if err := appendTransactionsDateClauses(fromDigits, toDigits, &where, &args); err != nil {
+		return nil, err
 	}
-	categoryNorm := strings.ToLower(strings.TrimSpace(category))
-	where = append(where, "LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) = ?")
-	args = append(args, categoryNorm)
+	appendChartBarClause(category, byMerchant, byParent, &where, &args)
+	appendIgnoredMerchantsClause(ignoredMerchants, &where, &args)
 
 	whereSQL := strings.Join(where, " AND ")
 	if strings.TrimSpace(orderBy) == "" {
@@ -654,6 +1594,8 @@ func queryCategoryTransactions(
 			COALESCE(t.category_id, ''),
 			COALESCE(t.card_purchase_method_method, ''),
 			COALESCE(t.note_text, ''),
+			COALESCE((SELECT n.note FROM transaction_notes n WHERE n.transaction_id = t.id), ''),
+			t.attachment_id IS NOT NULL,
 			COALESCE(a.display_name, '')
 		 FROM transactions t
 		 LEFT JOIN accounts a ON a.id = t.account_id
@@ -684,6 +1626,8 @@ func queryCategoryTransactions(
 			&r.categoryID,
 			&r.cardMethod,
 			&r.noteText,
+			&r.localNote,
+			&r.hasAttachment,
 			&r.accountName,
 		); err != nil {
 			return nil, err
@@ -693,19 +1637,36 @@ func queryCategoryTransactions(
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
+	if byMerchant {
+		out = filterMerchantGroupRows(out, category)
+	}
 	return out, nil
 }
 
+// transactionsParentCategorySQL buckets a transaction under its parent
+// category, falling back to the category itself for top-level ones.
+const transactionsParentCategorySQL = "COALESCE(NULLIF(TRIM(t.parent_category_id), ''), NULLIF(TRIM(t.category_id), ''), 'uncategorized')"
+
 func queryCategorySpend(ctx context.Context, db *sql.DB, whereSQL string, args []any) ([]transactionsCategorySpend, error) {
+	return queryCategorySpendBy(ctx, db, "COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')", whereSQL, args)
+}
+
+// queryParentCategorySpend is queryCategorySpend with broader buckets.
+func queryParentCategorySpend(ctx context.Context, db *sql.DB, whereSQL string, args []any) ([]transactionsCategorySpend, error) {
+	return queryCategorySpendBy(ctx, db, transactionsParentCategorySQL, whereSQL, args)
+}
+
+func queryCategorySpendBy(ctx context.Context, db *sql.DB, categorySQL string, whereSQL string, args []any) ([]transactionsCategorySpend, error) {
 	q := fmt.Sprintf(
 		`SELECT
-			COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized') AS category,
+			%s AS category,
 			SUM(CASE WHEN t.amount_value_in_base_units < 0 THEN -t.amount_value_in_base_units ELSE 0 END) AS spend_cents
 		 FROM transactions t
 		 WHERE %s
 		 GROUP BY category
 		 HAVING spend_cents > 0
 		 ORDER BY spend_cents DESC, category ASC`,
+		categorySQL,
 		whereSQL,
 	)
 	rows, err := db.QueryContext(ctx, q, args...)
@@ -736,6 +1697,125 @@ func queryCategorySpend(ctx context.Context, db *sql.DB, whereSQL string, args [
 	return out, nil
 }
 
+// transactionsComparisonWindow returns the period of equal length immediately
+// before the selected date range. An open-ended range ("all time" or "until")
+// has no comparison period.
+func transactionsComparisonWindow(fromDigits, toDigits string, now time.Time) (string, string, bool) {
+	if len(strings.TrimSpace(fromDigits)) != 8 {
+		return "", "", false
+	}
+	fromRaw, err := parseTransactionsDateDigits(fromDigits)
+	if err != nil {
+		return "", "", false
+	}
+	from, err := time.ParseInLocation("2006-01-02", fromRaw, time.Local)
+	if err != nil {
+		return "", "", false
+	}
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if len(strings.TrimSpace(toDigits)) == 8 {
+		toRaw, err := parseTransactionsDateDigits(toDigits)
+		if err != nil {
+			return "", "", false
+		}
+		to, err = time.ParseInLocation("2006-01-02", toRaw, time.Local)
+		if err != nil {
+			return "", "", false
+		}
+	}
+	if to.Before(from) {
+		return "", "", false
+	}
+	days := int(math.Round(to.Sub(from).Hours()/24)) + 1
+	prevTo := from.AddDate(0, 0, -1)
+	prevFrom := from.AddDate(0, 0, -days)
+	return prevFrom.Format("2006-01-02"), prevTo.Format("2006-01-02"), true
+}
+
+// applyCategorySpendComparison fills in spend for the comparison period,
+// joined to the current spend by category. Categories that only had spend in the comparison period are included with
+// zero current spend so drops show up alongside increases.
+func applyCategorySpendComparison(
+	ctx context.Context,
+	db *sql.DB,
+	baseWhere []string,
+	baseArgs []any,
+	fromDigits string,
+	toDigits string,
+	current []transactionsCategorySpend,
+	spendQuery func(context.Context, *sql.DB, string, []any) ([]transactionsCategorySpend, error),
+) ([]transactionsCategorySpend, bool, error) {
+	prevFrom, prevTo, ok := transactionsComparisonWindow(fromDigits, toDigits, time.Now().In(time.Local))
+	if !ok {
+		return current, false, nil
+	}
+	where := append(append([]string{}, baseWhere...), "date(t.created_at) >= date(?)", "date(t.created_at) <= date(?)")
+	args := append(append([]any{}, baseArgs...), prevFrom, prevTo)
+	previous, err := spendQuery(ctx, db, strings.Join(where, " AND "), args)
+	if err != nil {
+		return nil, false, err
+	}
+
+	out := append([]transactionsCategorySpend{}, current...)
+	indexByCategory := make(map[string]int, len(out))
+	for i := range out {
+		indexByCategory[out[i].category] = i
+	}
+	for _, prev := range previous {
+		idx, ok := indexByCategory[prev.category]
+		if !ok {
+			out = append(out, transactionsCategorySpend{category: prev.category})
+			idx = len(out) - 1
+			indexByCategory[prev.category] = idx
+		}
+		out[idx].previousCents = prev.spendCents
+	}
+	for i := range out {
+		out[i].deltaCents = out[i].spendCents - out[i].previousCents
+	}
+	return out, true, nil
+}
+
+// sortCategorySpendByChange orders categories by the size of their change,
+// biggest movers first.
+func sortCategorySpendByChange(out []transactionsCategorySpend) {
+	sort.SliceStable(out, func(i, j int) bool {
+		di := out[i].deltaCents
+		dj := out[j].deltaCents
+		if di < 0 {
+			di = -di
+		}
+		if dj < 0 {
+			dj = -dj
+		}
+		if di != dj {
+			return di > dj
+		}
+		return out[i].category < out[j].category
+	})
+}
+
+// formatSpendChangePct renders spend against the previous period as a
+// whole percentage. Categories with no previous spend read "new"; ones that
+// only had previous spend read "-100%".
+func formatSpendChangePct(currentCents, previousCents int64) string {
+	switch {
+	case previousCents <= 0 && currentCents <= 0:
+		return "-"
+	case previousCents <= 0:
+		return "new"
+	}
+	pct := math.Round(float64(currentCents-previousCents) / float64(previousCents) * 100.0)
+	if pct == 0 {
+		return "0%"
+	}
+	return fmt.Sprintf("%+.0f%%", pct)
+}
+
+func transactionsChartSortLabels() []string {
+	return []string{"spend ↓", "change ↕"}
+}
+
 func querySpendTimeSeries(
 	ctx context.Context,
 	db *sql.DB,
@@ -744,14 +1824,22 @@ func querySpendTimeSeries(
 	fromDigits string,
 	toDigits string,
 	timeSeriesCategory string,
+	roundUps bool,
 ) ([]transactionsTimeSeriesPoint, error) {
 	_ = fromDigits
 	_ = toDigits
 
 	timeSeriesWhere := whereSQL
 	timeSeriesArgs := append([]any{}, args...)
-	timeSeriesWhere += " AND t.amount_value_in_base_units < 0"
-	if strings.TrimSpace(timeSeriesCategory) != "" {
+	spendSQL := "COALESCE(-t.amount_value_in_base_units, 0)"
+	if roundUps {
+		// Up records round-ups as negative amounts leaving the spending account.
+		spendSQL = "ABS(t.round_up_amount_value_in_base_units)"
+		timeSeriesWhere += " AND COALESCE(t.round_up_amount_value_in_base_units, 0) != 0"
+	} else {
+		timeSeriesWhere += " AND t.amount_value_in_base_units < 0"
+	}
+	if !roundUps && strings.TrimSpace(timeSeriesCategory) != "" {
 		timeSeriesWhere += " AND LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) = ?"
 		timeSeriesArgs = append(timeSeriesArgs, strings.ToLower(strings.TrimSpace(timeSeriesCategory)))
 	}
@@ -771,17 +1859,20 @@ func querySpendTimeSeries(
 			COALESCE(NULLIF(t.raw_text_norm, ''), COALESCE(t.raw_text, '')) AS raw_text,
 			COALESCE(NULLIF(t.description_norm, ''), COALESCE(t.description, '')) AS description,
 			t.amount_value,
-			COALESCE(-t.amount_value_in_base_units, 0) AS spend_cents,
+			%s AS spend_cents,
 			COALESCE(t.status, ''),
 			COALESCE(t.message, ''),
 			COALESCE(t.category_id, ''),
 			COALESCE(t.card_purchase_method_method, ''),
 			COALESCE(t.note_text, ''),
+			COALESCE((SELECT n.note FROM transaction_notes n WHERE n.transaction_id = t.id), ''),
+			t.attachment_id IS NOT NULL,
 			COALESCE(a.display_name, '')
 		 FROM transactions t
 		 LEFT JOIN accounts a ON a.id = t.account_id
 		 WHERE %s
 		 ORDER BY t.created_at ASC, t.id ASC`,
+		spendSQL,
 		timeSeriesWhere,
 	)
 	rows, err := db.QueryContext(ctx, q, timeSeriesArgs...)
@@ -808,6 +1899,8 @@ func querySpendTimeSeries(
 			&p.categoryID,
 			&p.cardMethod,
 			&p.noteText,
+			&p.localNote,
+			&p.hasAttachment,
 			&p.accountName,
 		); err != nil {
 			return nil, err
@@ -823,14 +1916,49 @@ func querySpendTimeSeries(
 	return raw, nil
 }
 
+// chartFooterHelpText is the footer hint for a transactions view. It keeps
+// to the keys used most so it fits on one line; "?" lists the rest.
 func chartFooterHelpText(mode int) string {
-	if mode == transactionsViewModeTable {
-		return "/ search  f filters  s sort"
+	switch mode {
+	case transactionsViewModeTable:
+		return "/ search  f filters  s sort  d detail fields  e export  ? more keys"
+	case transactionsViewModeTimeSeries:
+		return "↑/↓ category  ←/→ node/pan  +/- zoom  g granularity  enter details  ? more keys"
+	case transactionsViewModeWeekly:
+		return "↑/↓ scroll weeks  / search  f filters  ? more keys"
+	case transactionsViewModeHeatmap:
+		return "/ search  f filters  darker cells mean more spend  ? more keys"
+	}
+	return "/ search  f filters  s sort  m merchants  esc up a level  ? more keys"
+}
+
+// transactionsMoreKeysHelpText holds the keys a view's footer leaves out.
+func transactionsMoreKeysHelpText(mode int) string {
+	switch mode {
+	case transactionsViewModeTable:
+		return "+/- credits/debits  b balance  S tie order  T tag filtered  I ignore merchant  : jump to page  home/end first/last  y copy details  u open in Up  n my note  a merchant alias  H hours  J raw json"
+	case transactionsViewModeTimeSeries:
+		return "0 reset zoom  r round-ups/by month  d detail fields  y copy details  u open in Up  n my note  a merchant alias  f filters"
+	case transactionsViewModeWeekly:
+		return "+/- credits/debits  H hours"
+	case transactionsViewModeHeatmap:
+		return "H hours"
 	}
-	if mode == transactionsViewModeTimeSeries {
-		return "↑/↓ category  ←/→ node/pan  +/- zoom  enter details  f filters"
+	return "+/- credits/debits  p parent categories  e export bar  B budget  A category name  x hide category  % vs budget  v vs last period  H hours  J raw json"
+}
+
+// transactionsKeyBindings lists every key for a view, one binding per entry,
+// for the "?" overlay.
+func transactionsKeyBindings(mode int) []string {
+	out := make([]string, 0, 24)
+	for _, hint := range []string{chartFooterHelpText(mode), transactionsMoreKeysHelpText(mode)} {
+		for _, binding := range strings.Split(hint, "  ") {
+			if binding = strings.TrimSpace(binding); binding != "" && binding != "? more keys" {
+				out = append(out, binding)
+			}
+		}
 	}
-	return "/ search  f filters"
+	return out
 }
 
 func (m model) syncTransactionsCmd(sessionID int, force bool) tea.Cmd {
@@ -838,71 +1966,116 @@ func (m model) syncTransactionsCmd(sessionID int, force bool) tea.Cmd {
 		if m.db == nil {
 			return syncTransactionsDoneMsg{sessionID: sessionID, err: errors.New("database is not initialized")}
 		}
-		err := syncTransactionsIntoDB(m.db, force)
+		err := ignoreSyncSkipped(syncTransactionsIntoDB(m.db, force, time.Time{}))
 		return syncTransactionsDoneMsg{sessionID: sessionID, err: err}
 	}
 }
 
-func syncTransactionsIntoDB(sqlDB *sql.DB, force bool) error {
-	pat, err := auth.LoadPAT()
-	if err != nil {
-		return err
-	}
-	client := upapi.New(pat)
-	service, err := syncer.NewTransactionsService(sqlDB, client)
-	if err != nil {
-		return err
-	}
+// SyncTransactions forces a transactions sync and waits for it to finish. It
+// is the headless counterpart of refreshing the transactions screen. It
+// returns a *SyncSkippedError when the minimum sync interval has not passed.
+func SyncTransactions(db *sql.DB) error {
+	return syncTransactionsIntoDB(db, true, time.Time{})
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
-	defer service.LeaveView()
+// SyncTransactionsSince forces a sync of only the transactions created at or
+// after since, which is far quicker than a full refresh on a long history.
+func SyncTransactionsSince(db *sql.DB, since time.Time) error {
+	return syncTransactionsIntoDB(db, true, since)
+}
 
-	repo := storage.NewSyncStateRepo(sqlDB)
-	txRepo := storage.NewTransactionsRepo(sqlDB)
-	hasCached, err := txRepo.HasAny(ctx)
-	if err != nil {
-		return err
-	}
+func syncTransactionsIntoDB(sqlDB *sql.DB, force bool, since time.Time) error {
+	params := fmt.Sprintf("force=%t since=%s", force, since.UTC().Format(time.RFC3339))
+	return runExclusiveSync(syncer.CollectionTransactions, params, func() error {
+		pat, err := auth.LoadPAT()
+		if err != nil {
+			return err
+		}
+		minInterval, err := loadMinSyncInterval(context.Background(), sqlDB)
+		if err != nil {
+			return err
+		}
+		maxDays, err := loadSyncMaxDays(context.Background(), sqlDB)
+		if err != nil {
+			return err
+		}
+		readAhead, err := loadSyncReadAhead(context.Background(), sqlDB)
+		if err != nil {
+			return err
+		}
+		client := upapi.New(pat)
+		service, err := syncer.NewTransactionsService(sqlDB, client, minInterval, maxDays, readAhead)
+		if err != nil {
+			return err
+		}
+		if !since.IsZero() {
+			if err := service.SetTransactionsSince(since); err != nil {
+				return err
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		defer service.LeaveView()
+
+		repo := storage.NewSyncStateRepo(sqlDB)
+		txRepo := storage.NewTransactionsRepo(sqlDB)
+		hasCached, err := txRepo.HasAny(ctx)
+		if err != nil {
+			return err
+		}
 
-	var prevAttempt *time.Time
-	var prevSuccess *time.Time
-	if state, found, err := repo.Get(ctx, syncer.CollectionTransactions); err == nil && found {
-		if state.LastAttempt != nil {
-			t := state.LastAttempt.UTC()
-			prevAttempt = &t
+		var prevAttempt *time.Time
+		var prevSuccess *time.Time
+		var prevErr string
+		if state, found, err := repo.Get(ctx, syncer.CollectionTransactions); err == nil && found {
+			if state.LastAttempt != nil {
+				t := state.LastAttempt.UTC()
+				prevAttempt = &t
+			}
+			if state.LastSuccess != nil {
+				t := state.LastSuccess.UTC()
+				prevSuccess = &t
+			}
+			prevErr = strings.TrimSpace(state.LastErrorMsg)
 		}
-		if state.LastSuccess != nil {
-			t := state.LastSuccess.UTC()
-			prevSuccess = &t
+
+		// Never hit the API more often than the configured minimum, even when forced.
+		if skip, err := syncIntervalGuard(syncer.CollectionTransactions, prevAttempt, prevErr, hasCached, minInterval, time.Now()); skip {
+			return err
+		}
+		isStale := prevSuccess == nil || time.Since(prevSuccess.UTC()) > minInterval
+		if !force && hasCached && !isStale {
+			return nil
 		}
-	}
 
-	if err := service.EnterTransactionsView(ctx); err != nil {
-		return err
-	}
-	if force {
-		if err := service.RefreshTransactions(); err != nil {
+		// Past the interval guard the cache is always stale, so entering the
+		// view runs the sync.
+		if err := service.EnterTransactionsView(ctx); err != nil {
 			return err
 		}
-		return waitForTransactionsSyncResult(ctx, repo, prevAttempt, prevSuccess)
-	}
-	isStale := prevSuccess == nil || time.Since(prevSuccess.UTC()) > 30*time.Second
-	if hasCached && !isStale {
-		return nil
-	}
-	return waitForTransactionsSyncResult(ctx, repo, prevAttempt, prevSuccess)
+		return waitForTransactionsSyncResult(ctx, repo, prevAttempt, prevSuccess, service.TransactionsFetched)
+	})
 }
 
+// transactionsSyncProgress is how many transactions the running sync has
+// stored so far. The poll loop below publishes it and the screen's reload
+// tick reads it into the model.
+var transactionsSyncProgress atomic.Int64
+
 func waitForTransactionsSyncResult(
 	ctx context.Context,
 	repo *storage.SyncStateRepo,
 	previousAttempt *time.Time,
 	previousSuccess *time.Time,
+	fetched func() int,
 ) error {
+	transactionsSyncProgress.Store(0)
+	defer transactionsSyncProgress.Store(0)
 	ticker := time.NewTicker(200 * time.Millisecond)
 	defer ticker.Stop()
 	for {
+		transactionsSyncProgress.Store(int64(fetched()))
 		state, found, err := repo.Get(ctx, syncer.CollectionTransactions)
 		if err == nil && found && state.LastAttempt != nil {
 			attemptChanged := previousAttempt == nil || state.LastAttempt.After(*previousAttempt)
@@ -911,7 +2084,7 @@ func waitForTransactionsSyncResult(
 				successChanged = previousSuccess == nil || state.LastSuccess.After(*previousSuccess)
 			}
 			if attemptChanged && strings.TrimSpace(state.LastErrorMsg) != "" {
-				return errors.New(state.LastErrorMsg)
+				return syncStateError("transactions", state.LastErrorMsg)
 			}
 			if successChanged {
 				return nil
@@ -925,6 +2098,13 @@ func waitForTransactionsSyncResult(
 	}
 }
 
+func formatTransactionsSyncStatus(fetched int) string {
+	if fetched <= 0 {
+		return "syncing..."
+	}
+	return fmt.Sprintf("syncing... %d fetched", fetched)
+}
+
 func renderTransactionsViewModeSelector(mode int) string {
 	item := func(label string, active bool) string {
 		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
@@ -938,7 +2118,11 @@ func renderTransactionsViewModeSelector(mode int) string {
 		"  | " +
 		item("chart [2]", mode == transactionsViewModeChart) +
 		"  | " +
-		item("time series [3]", mode == transactionsViewModeTimeSeries)
+		item("time series [3]", mode == transactionsViewModeTimeSeries) +
+		"  | " +
+		item("weekly [4]", mode == transactionsViewModeWeekly) +
+		"  | " +
+		item("heatmap [5]", mode == transactionsViewModeHeatmap)
 }
 
 func renderTransactionsBodyLines(
@@ -949,19 +2133,37 @@ func renderTransactionsBodyLines(
 	timeSeriesCategory string,
 	timeSeriesColor lipgloss.Color,
 	timeSeriesSelected int,
+	timeSeriesRoundUps bool,
+	roundUpsMonthly bool,
 	cursor int,
 	merchantW int,
 	contentWidth int,
+	tableVisibleRows int,
 	chartCursor int,
 	chartShowAmount bool,
+	chartShowChange bool,
+	chartShowChangePct bool,
+	chartByMerchant bool,
+	chartByParent bool,
+	chartParentDrill string,
+	chartBudgets map[string]int64,
+	chartBudgetPct bool,
+	largeThreshold int64,
+	weeklySpend []transactionsWeeklySpend,
+	heatmap spendHeatmap,
+	balances []string,
 ) []string {
 	switch mode {
 	case transactionsViewModeChart:
-		return renderTransactionsChartLines(categorySpend, contentWidth, chartCursor, chartShowAmount)
+		return renderTransactionsChartLines(categorySpend, contentWidth, chartCursor, chartShowAmount, chartShowChange, chartShowChangePct, chartByMerchant, chartByParent, chartParentDrill, chartBudgets, chartBudgetPct)
 	case transactionsViewModeTimeSeries:
-		return renderTransactionsTimeSeriesLines(timeSeries, contentWidth, timeSeriesCategory, timeSeriesColor, timeSeriesSelected)
+		return renderTransactionsTimeSeriesLines(timeSeries, contentWidth, timeSeriesCategory, timeSeriesColor, timeSeriesSelected, timeSeriesRoundUps, roundUpsMonthly)
+	case transactionsViewModeWeekly:
+		return renderTransactionsWeeklyLines(weeklySpend, contentWidth)
+	case transactionsViewModeHeatmap:
+		return renderTransactionsHeatmapLines(heatmap, contentWidth)
 	default:
-		return renderTransactionsTableLines(rows, cursor, merchantW)
+		return renderTransactionsTableLines(rows, cursor, merchantW, contentWidth, tableVisibleRows, largeThreshold, balances)
 	}
 }
 
@@ -1000,34 +2202,175 @@ func transactionsCategoryColor(rank int) lipgloss.Color {
 	return palette[rank%len(palette)]
 }
 
-func renderTransactionsTableLines(rows []transactionPreviewRow, cursor int, merchantW int) []string {
-	header := fmt.Sprintf("  %-10s  %-"+strconv.Itoa(merchantW)+"s  %10s", "date", "merchant", "amount")
-	out := []string{
-		lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render(header),
+// renderTransactionsTableLines draws the table card. balances holds one
+// formatted running balance per row; when it is nil the column is omitted.
+// renderTransactionsTableLines pins the column header above the visible
+// window of rows. Rows are clipped to contentWidth and to visibleRows, so a
+// long row or an oversized page can never push the header off the card.
+func renderTransactionsTableLines(
+	rows []transactionPreviewRow,
+	cursor int,
+	merchantW int,
+	contentWidth int,
+	visibleRows int,
+	largeThreshold int64,
+	balances []string,
+) []string {
+	showBalance := len(balances) == len(rows) && len(rows) > 0
+	showStatus := slices.ContainsFunc(rows, func(r transactionPreviewRow) bool { return isHeldTransaction(r.status) })
+	if showStatus {
+		merchantW = max(4, merchantW-2)
 	}
+	out := []string{renderTransactionsTableHeader(merchantW, showBalance, showStatus, contentWidth)}
 	if len(rows) == 0 {
 		return append(out, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("no transactions found"))
 	}
-	for i, row := range rows {
+	return append(out, renderTransactionsTableRows(rows, cursor, merchantW, contentWidth, visibleRows, largeThreshold, balances, showStatus)...)
+}
+
+// renderTransactionsSkeletonLines draws the table header over dotted
+// placeholder rows in the date, merchant and amount columns.
+func renderTransactionsSkeletonLines(merchantW, contentWidth, visibleRows int) []string {
+	out := []string{renderTransactionsTableHeader(merchantW, false, false, contentWidth)}
+	style := lipgloss.NewStyle().Foreground(lipgloss.Color("#777777"))
+	for i := 0; i < max(1, visibleRows); i++ {
+		// Vary the merchant width so the rows read as a table, not a block.
+		merchant := strings.Repeat("·", max(3, merchantW-(i*7)%max(1, merchantW/2)))
+		line := fmt.Sprintf("  %-10s  %-"+strconv.Itoa(merchantW)+"s  %10s", strings.Repeat("·", 10), merchant, strings.Repeat("·", 6))
+		out = append(out, style.Render(truncateDisplayWidth(line, max(8, contentWidth))))
+	}
+	return out
+}
+
+// transactionsHeldGlyph marks held transactions in the status column, which
+// only appears when the page has one.
+const transactionsHeldGlyph = "◷"
+
+func renderTransactionsTableHeader(merchantW int, showBalance, showStatus bool, contentWidth int) string {
+	status := ""
+	if showStatus {
+		status = "  "
+	}
+	header := fmt.Sprintf("  %s%-10s  %-"+strconv.Itoa(merchantW)+"s  %10s", status, "date", "merchant", "amount")
+	if showBalance {
+		header += fmt.Sprintf("  %11s", "balance")
+	}
+	header = truncateDisplayWidth(header, max(8, contentWidth))
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render(header)
+}
+
+// renderTransactionsTableRows renders the scrollable body: at most
+// visibleRows single-line rows, windowed so the cursor row stays in view.
+func renderTransactionsTableRows(
+	rows []transactionPreviewRow,
+	cursor int,
+	merchantW int,
+	contentWidth int,
+	visibleRows int,
+	largeThreshold int64,
+	balances []string,
+	showStatus bool,
+) []string {
+	showBalance := len(balances) == len(rows) && len(rows) > 0
+	start, end := 0, len(rows)
+	if visibleRows > 0 && len(rows) > visibleRows {
+		start = min(max(0, cursor-visibleRows+1), len(rows)-visibleRows)
+		end = start + visibleRows
+	}
+	out := make([]string, 0, end-start)
+	for i := start; i < end; i++ {
+		row := rows[i]
 		prefix := "  "
 		if i == cursor {
 			prefix = "› "
 		}
+		if showStatus {
+			if isHeldTransaction(row.status) {
+				prefix += transactionsHeldGlyph + " "
+			} else {
+				prefix += "  "
+			}
+		}
 		date := formatTransactionDate(row.createdAt)
 		merchant := truncateDisplayWidth(strings.TrimSpace(row.merchant), merchantW)
 		line := fmt.Sprintf("%s%-10s  %-"+strconv.Itoa(merchantW)+"s  %10s", prefix, date, merchant, row.amountValue)
+		if showBalance {
+			line += fmt.Sprintf("  %11s", balances[i])
+		}
 		style := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB"))
+		if isLargeTransaction(row.amountCents, largeThreshold) {
+			line += " !"
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB74D"))
+		}
+		if code := strings.TrimSpace(row.foreignCurrency); code != "" {
+			line += " " + strings.ToUpper(code)
+		}
+		if row.hasAttachment {
+			line += " " + transactionsAttachmentGlyph
+		}
+		if isHeldTransaction(row.status) {
+			style = style.Italic(true)
+		}
 		if i == cursor {
-			style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+			style = style.Foreground(lipgloss.Color("#FFFFFF")).Bold(true)
+			if isLargeTransaction(row.amountCents, largeThreshold) {
+				style = style.Foreground(lipgloss.Color("#FFD54A"))
+			}
 		}
-		out = append(out, style.Render(line))
+		out = append(out, style.Render(truncateDisplayWidth(line, max(8, contentWidth))))
 	}
 	return out
 }
 
-func renderTransactionsChartLines(categorySpend []transactionsCategorySpend, contentWidth int, chartCursor int, showAmount bool) []string {
+// isLargeTransaction reports whether a debit meets the configured alert
+// threshold. Credits such as salary or refunds are never flagged, and a
+// threshold of 0 disables the alert.
+func isLargeTransaction(amountCents int64, threshold int64) bool {
+	return threshold > 0 && amountCents < 0 && -amountCents >= threshold
+}
+
+// largeTransactionsCountQuery counts the debits in whereSQL that
+// isLargeTransaction would flag.
+func largeTransactionsCountQuery(whereSQL string, args []any, threshold int64) (string, []any) {
+	q := fmt.Sprintf("SELECT COUNT(*) FROM transactions t WHERE %s AND t.amount_value_in_base_units <= ?", whereSQL)
+	return q, append(append([]any{}, args...), -threshold)
+}
+
+// renderTransactionsChartLines draws one bar per category. Categories whose
+// spend exceeds their entry in budgets are drawn in red with "(over)"; with
+// budgetPct, the percent column shows spend against budget for categories
+// that have one. showChangePct adds each category's change against the
+// previous period as a percentage.
+func renderTransactionsChartLines(
+	categorySpend []transactionsCategorySpend,
+	contentWidth int,
+	chartCursor int,
+	showAmount bool,
+	showChange bool,
+	showChangePct bool,
+	byMerchant bool,
+	byParent bool,
+	parentDrill string,
+	budgets map[string]int64,
+	budgetPct bool,
+) []string {
+	title := "spend by category"
+	if byMerchant {
+		title = "spend by merchant"
+	} else if byParent {
+		title = "spend by parent category"
+	} else if drill := strings.TrimSpace(parentDrill); drill != "" {
+		title = "spend by category in " + drill
+	}
+	if showChange {
+		title += " (change vs previous period)"
+	} else if showChangePct {
+		title += " (vs previous period)"
+	} else if budgetPct && len(budgets) > 0 {
+		title += " (% of budget where set)"
+	}
 	out := []string{
-		lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render("spend by category"),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render(title),
 	}
 	if len(categorySpend) == 0 {
 		return append(out, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("no transactions found"))
@@ -1047,6 +2390,15 @@ func renderTransactionsChartLines(categorySpend []transactionsCategorySpend, con
 	if showAmount {
 		fixed = 22 // adds 9.2f amount column and spacing
 	}
+	if showChange {
+		fixed += 11 // adds signed change column and spacing
+	}
+	if showChangePct {
+		fixed += 7 // adds signed percent change column and spacing
+	}
+	if len(budgets) > 0 {
+		fixed += 7 // room for the " (over)" marker
+	}
 	const rightSlack = 5
 	available := max(6, contentWidth-fixed-rightSlack)
 	labelWidth := min(32, max(6, int(math.Round(float64(available)*0.58))))
@@ -1055,24 +2407,50 @@ func renderTransactionsChartLines(categorySpend []transactionsCategorySpend, con
 	for i, row := range rows {
 		dollars := float64(row.spendCents) / 100.0
 		barLen := int(math.Round((float64(row.spendCents) / float64(maxSpendCents)) * float64(barWidth)))
-		barLen = max(1, barLen)
+		if row.spendCents > 0 {
+			barLen = max(1, barLen)
+		}
 		if barWidth > 1 {
 			barLen = min(barLen, barWidth-1)
 		}
 		bar := strings.Repeat("█", barLen)
-		label := truncateDisplayWidth(strings.TrimSpace(row.category), labelWidth)
+		name := row.category
+		if row.label != "" {
+			name = row.label
+		}
+		label := truncateDisplayWidth(strings.TrimSpace(name), labelWidth)
 		prefix := "  "
 		if i == chartCursor {
 			prefix = "› "
 		}
-		line := fmt.Sprintf("%s%-"+strconv.Itoa(labelWidth)+"s  %s  %5.1f%%", prefix, label, bar, row.percentOfSpend)
+		percent := row.percentOfSpend
+		budget, hasBudget := budgets[strings.TrimSpace(row.category)]
+		hasBudget = hasBudget && budget > 0
+		if hasBudget && budgetPct {
+			percent = (float64(row.spendCents) / float64(budget)) * 100.0
+		}
+		line := fmt.Sprintf("%s%-"+strconv.Itoa(labelWidth)+"s  %s  %5.1f%%", prefix, label, bar, percent)
 		if showAmount {
-			line = fmt.Sprintf("%s%-"+strconv.Itoa(labelWidth)+"s  %9.2f  %s  %5.1f%%", prefix, label, dollars, bar, row.percentOfSpend)
+			line = fmt.Sprintf("%s%-"+strconv.Itoa(labelWidth)+"s  %9.2f  %s  %5.1f%%", prefix, label, dollars, bar, percent)
+		}
+		if showChange {
+			line += fmt.Sprintf("  %+9.2f", float64(row.deltaCents)/100.0)
+		}
+		if showChangePct {
+			line += fmt.Sprintf("  %5s", formatSpendChangePct(row.spendCents, row.previousCents))
+		}
+		overBudget := hasBudget && row.spendCents > budget
+		if overBudget {
+			line += " (over)"
 		}
 		line = truncateDisplayWidth(line, max(8, contentWidth))
-		style := lipgloss.NewStyle().Foreground(transactionsCategoryColor(i))
+		color := transactionsCategoryColor(i)
+		if overBudget {
+			color = lipgloss.Color("#F15B5B")
+		}
+		style := lipgloss.NewStyle().Foreground(color)
 		if i == chartCursor {
-			style = lipgloss.NewStyle().Foreground(transactionsCategoryColor(i)).Bold(true)
+			style = style.Bold(true)
 		}
 		out = append(out, style.Render(line))
 	}
@@ -1085,6 +2463,8 @@ func renderTransactionsTimeSeriesLines(
 	categoryLabel string,
 	seriesColor lipgloss.Color,
 	selectedPoint int,
+	roundUps bool,
+	roundUpsMonthly bool,
 ) []string {
 	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true)
 	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
@@ -1101,7 +2481,16 @@ func renderTransactionsTimeSeriesLines(
 		seriesName = strings.TrimSpace(categoryLabel)
 	}
 	out = append(out, seriesLabelStyle.Render("category: "+seriesName))
+	if roundUps {
+		out = []string{titleStyle.Render("round-up savings over time"), seriesLabelStyle.Render("cumulative round-ups")}
+		if roundUpsMonthly {
+			out = []string{titleStyle.Render("round-up savings by month"), seriesLabelStyle.Render("round-ups per month")}
+		}
+	}
 	if len(points) == 0 {
+		if roundUps {
+			return append(out, labelStyle.Render("no round-ups found"))
+		}
 		return append(out, labelStyle.Render("no transactions found"))
 	}
 	if selectedPoint < 0 || selectedPoint >= len(points) {
@@ -1260,7 +2649,15 @@ func renderTransactionsTimeSeriesLines(
 	xAxisLabel := lipgloss.NewStyle().Width(graphWidth).Align(lipgloss.Center).Render("date")
 	out = append(out, labelStyle.Render(truncateDisplayWidth(axisPrefix+xAxisLabel, innerWidth)))
 
-	out = append(out, labelStyle.Render(truncateDisplayWidth(fmt.Sprintf("total spend: %s", formatTimeSeriesDollar(totalSpend)), innerWidth)))
+	summary := fmt.Sprintf("total spend: %s", formatTimeSeriesDollar(totalSpend))
+	switch {
+	case roundUps && roundUpsMonthly:
+		summary = fmt.Sprintf("saved by round-ups: %s", formatTimeSeriesDollar(roundUpsTotalCents(points)))
+	case roundUps:
+		// Points are running totals, so the last one is the amount saved.
+		summary = fmt.Sprintf("saved by round-ups: %s", formatTimeSeriesDollar(points[len(points)-1].spendCents))
+	}
+	out = append(out, labelStyle.Render(truncateDisplayWidth(summary, innerWidth)))
 	return out
 }
 
@@ -1405,12 +2802,22 @@ func timeSeriesDateSpanDays(points []transactionsTimeSeriesPoint) int {
 		}
 		return 0
 	}
-	start, okStart := parseTimeSeriesDate(points[0].date)
-	end, okEnd := parseTimeSeriesDate(points[len(points)-1].date)
-	if !okStart || !okEnd || end.Before(start) {
-		return len(points)
+	// Scan every point: rows are ordered by the raw created_at text, so a
+	// future-dated or differently offset row need not sit at either end.
+	var start, end time.Time
+	for _, p := range points {
+		d, ok := parseTimeSeriesDate(p.date)
+		if !ok {
+			return len(points)
+		}
+		if start.IsZero() || d.Before(start) {
+			start = d
+		}
+		if end.IsZero() || d.After(end) {
+			end = d
+		}
 	}
-	return int(end.Sub(start).Hours()/24) + 1
+	return int(math.Round(end.Sub(start).Hours()/24)) + 1
 }
 
 func parseTimeSeriesDate(raw string) (time.Time, bool) {
@@ -1580,11 +2987,7 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 	title := renderTransactionsTitle()
 	title = lipgloss.PlaceHorizontal(layoutWidth, lipgloss.Center, title)
 
-	sorts := transactionsSortOptions()
-	sortLabel := sorts[0].label
-	if m.transactionsSortIdx >= 0 && m.transactionsSortIdx < len(sorts) {
-		sortLabel = sorts[m.transactionsSortIdx].label
-	}
+	sortLabel := transactionsTableSortLabel(m.transactionsSortIdx, m.transactionsSortNewestFirst)
 	rangeLabel := transactionsRangeLabel(m.transactionsFromDate, m.transactionsToDate)
 
 	tableBorder := lipgloss.Color("#FFFFFF")
@@ -1671,6 +3074,14 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 		tableContentWidth = min(tableContentWidth, maxMainWidth)
 	}
 	merchantW := max(6, tableContentWidth-fixedColumnsWidth)
+	var balanceColumn []string
+	showBalance := false
+	if m.transactionsShowBalance && m.transactionsViewMode == transactionsViewModeTable {
+		balanceColumn, showBalance = runningBalanceColumn(m.transactionsRows, m.transactionsBalances)
+		if showBalance {
+			merchantW = max(6, merchantW-transactionsBalanceWidth)
+		}
+	}
 	chartSpendForCard := m.transactionsCategorySpend
 	chartCursorInWindow := m.transactionsChartCursor
 	if m.transactionsViewMode == transactionsViewModeChart {
@@ -1692,7 +3103,11 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 		if endIdx < startIdx {
 			endIdx = startIdx
 		}
-		timeSeriesForCard = m.transactionsTimeSeries[startIdx:endIdx]
+		series := m.transactionsTimeSeries
+		if m.transactionsTimeSeriesRoundUps && !m.transactionsRoundUpsMonthly {
+			series = cumulativeTimeSeriesPoints(series)
+		}
+		timeSeriesForCard = series[startIdx:endIdx]
 		if len(m.transactionsTimeSeries) > 0 && len(timeSeriesForCard) > 0 {
 			selectedAbs := m.transactionsTimeSeriesSelection
 			if selectedAbs < 0 || selectedAbs >= len(m.transactionsTimeSeries) {
@@ -1718,20 +3133,58 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 			}
 		}
 	}
+	tableRowsForCard := m.transactionsRows
+	tableCursorInWindow := m.transactionsCursor
+	if m.transactionsViewMode == transactionsViewModeTable {
+		startIdx := max(0, min(m.transactionsOffset, max(0, len(m.transactionsRows)-1)))
+		endIdx := min(len(m.transactionsRows), startIdx+m.transactionsVisibleRows())
+		if endIdx < startIdx {
+			endIdx = startIdx
+		}
+		tableRowsForCard = m.transactionsRows[startIdx:endIdx]
+		tableCursorInWindow = m.transactionsCursor - startIdx
+		if showBalance {
+			balanceColumn = balanceColumn[startIdx:endIdx]
+		}
+	}
+	weekStart, weekEnd := transactionsWeeklyWindow(len(m.transactionsWeeklySpend), m.transactionsWeeklyScroll, m.transactionsWeeklyVisibleRows())
+	weeklySpendForCard := m.transactionsWeeklySpend[weekStart:weekEnd]
 	tableLines := renderTransactionsBodyLines(
 		m.transactionsViewMode,
-		m.transactionsRows,
-		chartSpendForCard,
+		m.aliasedTransactionRows(tableRowsForCard),
+		m.aliasedChartSpend(chartSpendForCard),
 		timeSeriesForCard,
 		timeSeriesCategoryLabel,
 		timeSeriesColor,
 		timeSeriesSelectedLocal,
-		m.transactionsCursor,
+		m.transactionsTimeSeriesRoundUps,
+		m.transactionsTimeSeriesRoundUps && m.transactionsRoundUpsMonthly,
+		tableCursorInWindow,
 		merchantW,
 		tableContentWidth,
+		m.transactionsVisibleRows(),
 		chartCursorInWindow,
 		chartShowAmount,
+		m.transactionsChartSort == transactionsChartSortChange && m.transactionsChartCompare,
+		m.transactionsChartVsPrevious && m.transactionsChartCompare,
+		m.transactionsChartByMerchant,
+		m.transactionsChartShowsParents(),
+		m.transactionsChartParentDrill,
+		m.chartCategoryBudgets(),
+		m.transactionsChartBudgetPct,
+		m.transactionsLargeThreshold,
+		weeklySpendForCard,
+		m.transactionsHeatmap,
+		balanceColumn,
 	)
+	// Until the first sync lands there is nothing cached to show, so draw
+	// placeholder rows rather than "no transactions found".
+	if m.transactionsViewMode == transactionsViewModeTable &&
+		m.transactionsSyncing &&
+		m.transactionsFetched == nil &&
+		len(m.transactionsRows) == 0 {
+		tableLines = renderTransactionsSkeletonLines(merchantW, tableContentWidth, m.transactionsVisibleRows())
+	}
 	timeSeriesCardExtraHeight := 0
 	if m.transactionsViewMode == transactionsViewModeTimeSeries {
 		// Match chart-view card+search combined height using measured search box height.
@@ -1762,6 +3215,45 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 	if m.transactionsViewMode == transactionsViewModeTable {
 		sortLineLabel = "sort: " + sortLabel + "  |  " + sortLineLabel
 	}
+	if m.transactionsViewMode == transactionsViewModeChart {
+		chartSorts := transactionsChartSortLabels()
+		chartSortLabel := chartSorts[0]
+		if m.transactionsChartSort >= 0 && m.transactionsChartSort < len(chartSorts) {
+			chartSortLabel = chartSorts[m.transactionsChartSort]
+		}
+		if m.transactionsChartSort == transactionsChartSortChange && !m.transactionsChartCompare {
+			chartSortLabel += " (needs a start date)"
+		} else if m.transactionsChartVsPrevious && !m.transactionsChartCompare {
+			chartSortLabel += "  |  vs previous period needs a start date"
+		}
+		sortLineLabel = "sort: " + chartSortLabel + "  |  " + sortLineLabel
+	}
+	if m.transactionsViewMode == transactionsViewModeTimeSeries {
+		sortLineLabel = "granularity: " + timeSeriesGroupLabel(m.transactionsTimeSeriesGrouping) + "  |  " + sortLineLabel
+	}
+	if m.transactionsShowBalance && m.transactionsViewMode == transactionsViewModeTable && !showBalance {
+		sortLineLabel += "  |  balance unavailable"
+	}
+	switch {
+	case m.transactionsAmountSign > 0:
+		sortLineLabel += "  |  credits only"
+	case m.transactionsAmountSign < 0:
+		sortLineLabel += "  |  debits only"
+	}
+	if n := len(m.transactionsIgnoredMerchants); n > 0 && m.transactionsViewMode != transactionsViewModeTable {
+		noun := "merchants"
+		if n == 1 {
+			noun = "merchant"
+		}
+		sortLineLabel += fmt.Sprintf("  |  %d %s ignored", n, noun)
+	}
+	if n := len(m.transactionsHiddenCategories); n > 0 && m.transactionsViewMode != transactionsViewModeTable {
+		noun := "categories"
+		if n == 1 {
+			noun = "category"
+		}
+		sortLineLabel += fmt.Sprintf("  |  %d %s hidden (X show all)", n, noun)
+	}
 	sortLine := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#9CA3AF")).
 		Width(tableOuterWidth).
@@ -1787,21 +3279,37 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("Search format: field: value + field: value"),
 			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("Example 1: merchant: WOOL + amount: >60"),
 			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("Example 2: category: groceries + type: -ve"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("Example 3: type: -ve + (merchant: WOOL | merchant: COLES)"),
 			"",
-			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("merchant: case-insensitive match on merchant text"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("merchant: case-insensitive match on merchant text; merchant: =UBER for the exact name"),
 			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("description: case-insensitive match on description"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("note: case-insensitive match on your Up note or your own note"),
 			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("category: case-insensitive match on category id"),
 			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("exclude-category: exclude matches (repeat key or append + term)"),
-			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("amount: numeric compare, e.g. >60, <=12.50, =25"),
-			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("type: +ve (credits) or -ve (debits)"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("tag: / exclude-tag: case-insensitive exact tag name"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("account: / exclude-account: case-insensitive match on account name"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("amount: numeric compare, e.g. >60, <=12.50, =25, or a range 10..50"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("date: YYYY-MM-DD compare, e.g. >2024-01-01, <=2024-03-15"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("type: +ve (credits), -ve (debits) or cashback"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("status: held (pending, marked ◷) or settled"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("currency: foreign currency code, e.g. USD (tagged after the amount)"),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("has: attachment for transactions with a receipt attached in Up (marked " + transactionsAttachmentGlyph + ")"),
+			"",
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(tableOuterWidth).Render("↑/↓ in an empty search box recalls earlier searches"),
 		}
 	} else {
 		if m.transactionsViewMode == transactionsViewModeTable {
+			position := transactionsTableFooterPosition(start, end, m.transactionsTotal, m.transactionsPage, totalPages, transactionsTableKeyset(m.transactionsSortIdx))
+			// Daily counts across the filtered range, so clusters of
+			// activity show without switching to the time series.
+			if spark := renderSparkline(m.transactionsDailyCounts, min(30, tableOuterWidth-lipgloss.Width(position)-6)); strings.TrimSpace(spark) != "" {
+				position += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("#6CBFE6")).Render(spark)
+			}
 			footer = []string{
 				lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).
 					Width(tableOuterWidth).
 					Align(lipgloss.Center).
-					Render(fmt.Sprintf("showing %d-%d/%d  |  page %d/%d", start, end, m.transactionsTotal, m.transactionsPage+1, max(1, totalPages))),
+					Render(position),
 				lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).
 					Width(tableOuterWidth).
 					Align(lipgloss.Center).
@@ -1814,6 +3322,22 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 					Align(lipgloss.Center).
 					Render(chartFooterHelpText(m.transactionsViewMode)),
 			}
+			if m.transactionsViewMode == transactionsViewModeWeekly && len(weeklySpendForCard) > 0 {
+				noun := "weeks"
+				if len(weeklySpendForCard) == 1 {
+					noun = "week"
+				}
+				average := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).
+					Width(tableOuterWidth).
+					Align(lipgloss.Center).
+					Render(fmt.Sprintf(
+						"average weekly spend: %s across %d %s",
+						formatTimeSeriesDollar(averageWeeklySpendCents(weeklySpendForCard)),
+						len(weeklySpendForCard),
+						noun,
+					))
+				footer = append([]string{average}, footer...)
+			}
 		}
 	}
 
@@ -1821,7 +3345,7 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 	if m.transactionsSyncing {
 		statusLines = append(statusLines, lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#9CA3AF")).
-			Render("syncing..."))
+			Render(formatTransactionsSyncStatus(m.transactionsSyncFetched)))
 	}
 	if m.transactionsFetched != nil {
 		age := time.Since(m.transactionsFetched.UTC()).Round(time.Second)
@@ -1832,6 +3356,27 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 			Foreground(lipgloss.Color("#9CA3AF")).
 			Render(fmt.Sprintf("last updated %s ago", age.String())))
 	}
+	if m.transactionsLargeThreshold > 0 && m.transactionsLargeCount > 0 {
+		noun := "transactions"
+		if m.transactionsLargeCount == 1 {
+			noun = "transaction"
+		}
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#FFB74D")).
+			Render(fmt.Sprintf("! %d large %s this period (%s or more)", m.transactionsLargeCount, noun, formatTimeSeriesDollar(m.transactionsLargeThreshold))))
+	}
+	if m.transactionsCashbackCents > 0 &&
+		(m.transactionsViewMode == transactionsViewModeTable || m.transactionsViewMode == transactionsViewModeChart) {
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#7CB342")).
+			Render("cashback received this period: "+formatTimeSeriesDollar(m.transactionsCashbackCents)))
+	}
+	if m.transactionsTotal > 0 &&
+		(m.transactionsViewMode == transactionsViewModeTable || m.transactionsViewMode == transactionsViewModeChart) {
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render(formatNetSummary(m.transactionsNet)))
+	}
 	if strings.TrimSpace(m.transactionsDateErr) != "" {
 		statusLines = append(statusLines, lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#F15B5B")).
@@ -1847,6 +3392,62 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 			Foreground(lipgloss.Color("#F15B5B")).
 			Render(m.transactionsSearchErr))
 	}
+	if strings.TrimSpace(m.transactionsTagErr) != "" {
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F15B5B")).
+			Render(m.transactionsTagErr))
+	}
+	if strings.TrimSpace(m.transactionsBudgetErr) != "" {
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F15B5B")).
+			Render(m.transactionsBudgetErr))
+	}
+	if m.transactionsBudgetActive {
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render(transactionsBudgetHelpText))
+	}
+	if strings.TrimSpace(m.transactionsJumpErr) != "" {
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#F15B5B")).
+			Render(m.transactionsJumpErr))
+	}
+	if m.transactionsJumpActive {
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render(transactionsJumpHelpText))
+	}
+	if m.transactionsTagActive {
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render(fmt.Sprintf("enter tags all %d filtered transactions  esc cancel", m.transactionsTotal)))
+	}
+	if m.transactionsAliasActive {
+		aliasInput := m.transactionsAliasInput
+		aliasInput.Width = max(6, tableContentWidth-lipgloss.Width(aliasInput.Prompt)-1)
+		statusLines = append(statusLines, aliasInput.View(), lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render(transactionsAliasHelpText))
+	}
+	if m.transactionsCategoryAliasActive {
+		categoryAliasInput := m.transactionsCategoryAliasInput
+		categoryAliasInput.Width = max(6, tableContentWidth-lipgloss.Width(categoryAliasInput.Prompt)-1)
+		statusLines = append(statusLines, categoryAliasInput.View(), lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render(categoryAliasHelpText))
+	}
+	if m.transactionsNoteActive {
+		noteInput := m.transactionsNoteInput
+		noteInput.Width = max(6, tableContentWidth-lipgloss.Width(noteInput.Prompt)-1)
+		statusLines = append(statusLines, noteInput.View(), lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render(transactionsNoteHelpText))
+	}
+	if progress := strings.TrimSpace(m.transactionsTagProgressText()); progress != "" {
+		statusLines = append(statusLines, lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#9CA3AF")).
+			Render(progress))
+	}
 
 	showSearchBar := m.transactionsViewMode != transactionsViewModeTimeSeries
 	searchInput := m.transactionsSearchInput
@@ -1859,12 +3460,35 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 	if m.transactionsSearchActive {
 		searchBorder = lipgloss.Color("#FFD54A")
 	}
+	searchView := searchInput.View()
+	if m.transactionsTagActive {
+		tagInput := m.transactionsTagInput
+		tagInput.Width = max(6, tableContentWidth-lipgloss.Width(tagInput.Prompt)-1)
+		searchView = tagInput.View()
+		searchBorder = lipgloss.Color("#FFD54A")
+	}
+	if m.transactionsJumpActive {
+		jumpInput := m.transactionsJumpInput
+		jumpInput.Width = max(6, tableContentWidth-lipgloss.Width(jumpInput.Prompt)-1)
+		searchView = jumpInput.View()
+		searchBorder = lipgloss.Color("#FFD54A")
+	}
 	searchBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(searchBorder).
 		Padding(0, 1).
 		Width(tableContentWidth).
-		Render(searchInput.View())
+		Render(searchView)
+	if m.transactionsSearchActive && !m.transactionsTagActive && showSearchBar && !hasChartPane {
+		explainStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Width(lipgloss.Width(searchBox))
+		explain, err := explainTransactionsSearch(m.transactionsSearchInput.Value())
+		switch {
+		case err != nil:
+			searchBox += "\n" + explainStyle.Foreground(lipgloss.Color("#F15B5B")).Render(err.Error())
+		case explain != "":
+			searchBox += "\n" + explainStyle.Render("means: "+explain)
+		}
+	}
 
 	headerBlock := strings.Join([]string{viewModeHeader, sortHeader}, "\n")
 	leftTop := table
@@ -1915,22 +3539,32 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 			} else {
 				selected := m.transactionsChartPaneRows[selectedIdx]
 				valueWidth := max(10, paneWidth-16)
-				paneLines = append(paneLines, renderDetailLines("account", selected.accountName, valueWidth, labelStyle, valueStyle)...)
-				paneLines = append(paneLines, renderDetailLines("time", formatTransactionTime(selected.createdAt), valueWidth, labelStyle, valueStyle)...)
-				paneLines = append(paneLines, renderDetailLines("category", selected.categoryID, valueWidth, labelStyle, valueStyle)...)
-				paneLines = append(paneLines, renderDetailLines("raw text", selected.rawText, valueWidth, labelStyle, valueStyle)...)
-				paneLines = append(paneLines, renderDetailLines("status", selected.status, valueWidth, labelStyle, valueStyle)...)
-				paneLines = append(paneLines, renderDetailLines("message", selected.message, valueWidth, labelStyle, valueStyle)...)
-				paneLines = append(paneLines, renderDetailLines("description", selected.description, valueWidth, labelStyle, valueStyle)...)
-				paneLines = append(paneLines, renderDetailLines("merchant", selected.merchant, valueWidth, labelStyle, valueStyle)...)
-				paneLines = append(paneLines, renderDetailLines("card method", selected.cardMethod, valueWidth, labelStyle, valueStyle)...)
-				paneLines = append(paneLines, renderDetailLines("note text", selected.noteText, valueWidth, labelStyle, valueStyle)...)
+				compact := transactionsDetailsCompact(m.transactionsDetailsToggled, paneInnerHeight)
+				paneLines = append(paneLines, renderTransactionDetailFields(transactionDetailFields{
+					account:     selected.accountName,
+					createdAt:   selected.createdAt,
+					amount:      selected.amountValue,
+					category:    displayCategory(m.transactionsCategoryAliases, selected.categoryID),
+					rawText:     selected.rawText,
+					status:      selected.status,
+					message:     selected.message,
+					description: selected.description,
+					merchant:    selected.merchant,
+					cardMethod:  selected.cardMethod,
+					noteText:    selected.noteText,
+					localNote:   selected.localNote,
+					receipt:     selected.hasAttachment,
+				}, compact, valueWidth, labelStyle, valueStyle)...)
 			}
 			paneLines = padTransactionsBodyLines(paneLines, paneInnerHeight)
 		} else {
 			paneLines = make([]string, paneInnerHeight)
 			if paneInnerHeight > 0 {
-				paneLines[0] = titleStyle.Render("category transactions")
+				paneTitle := "category transactions"
+				if m.transactionsChartByMerchant {
+					paneTitle = "merchant transactions"
+				}
+				paneLines[0] = titleStyle.Render(paneTitle)
 			}
 			if paneInnerHeight > 1 {
 				paneLines[1] = labelStyle.Render(fmt.Sprintf("  %-"+strconv.Itoa(amountWidth)+"s %-"+strconv.Itoa(merchantWidth)+"s", "amount", "merchant"))
@@ -1940,6 +3574,17 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 				paneLines[sortRow] = labelStyle.Render("sort: " + chartPaneSortLabel)
 			}
 
+			// The row above sort shows the category's budget, or its input while editing.
+			if budgetRow := sortRow - 1; budgetRow > 1 && !m.transactionsChartByMerchant && !m.transactionsChartShowsParents() {
+				if m.transactionsBudgetActive {
+					input := m.transactionsBudgetInput
+					input.Width = max(4, paneWidth-lipgloss.Width(input.Prompt)-2)
+					paneLines[budgetRow] = input.View()
+				} else if cents, ok := m.transactionsCategoryBudgets[strings.TrimSpace(m.transactionsChartPaneTitle)]; ok {
+					paneLines[budgetRow] = labelStyle.Render("budget: " + formatCategoryBudget(cents) + " a month")
+				}
+			}
+
 			listStartRow := 2
 			listEndRow := max(listStartRow, sortRow-1) // keep one blank row above sort at the bottom
 			availableRows := max(0, listEndRow-listStartRow)
@@ -1956,7 +3601,7 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 						if i == m.transactionsChartPaneCursor {
 							prefix = "›"
 						}
-						merchant := strings.TrimSpace(row.merchant)
+						merchant := strings.TrimSpace(displayMerchant(m.transactionsMerchantAliases, row.merchant))
 						if merchant == "" {
 							merchant = strings.TrimSpace(row.description)
 						}
@@ -2014,17 +3659,35 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
 		paneLines := []string{lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render("transaction details")}
 		valueWidth := max(10, paneWidth-16)
-		paneLines = append(paneLines, renderDetailLines("account", selected.accountName, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("time", formatTransactionTime(selected.createdAt), valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("category", selected.categoryID, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("raw text", selected.rawText, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("status", selected.status, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("message", selected.message, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("description", selected.description, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("merchant", selected.merchant, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("card method", selected.cardMethod, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("note text", selected.noteText, valueWidth, labelStyle, valueStyle)...)
 		paneInnerHeight := max(1, lipgloss.Height(leftBeforeFooter)-2)
+		compact := transactionsDetailsCompact(m.transactionsDetailsToggled, paneInnerHeight)
+		paneLines = append(paneLines, renderTransactionDetailFields(transactionDetailFields{
+			account:     selected.accountName,
+			createdAt:   selected.createdAt,
+			amount:      selected.amountValue,
+			category:    displayCategory(m.transactionsCategoryAliases, selected.categoryID),
+			rawText:     selected.rawText,
+			status:      selected.status,
+			message:     selected.message,
+			description: selected.description,
+			merchant:    selected.merchant,
+			cardMethod:  selected.cardMethod,
+			noteText:    selected.noteText,
+			localNote:   selected.localNote,
+			receipt:     selected.hasAttachment,
+			foreign:     formatForeignAmount(selected.foreignAmount, selected.foreignCurrency),
+		}, compact, valueWidth, labelStyle, valueStyle)...)
+		if !compact {
+			paneLines = append(paneLines, renderWrappedDetailLines("tags", selected.tags, valueWidth, labelStyle, valueStyle)...)
+		}
+		if m.transactionsTrendTxID == selected.id {
+			paneLines = append(paneLines, "")
+			if m.transactionsTrendErr != "" {
+				paneLines = append(paneLines, labelStyle.Render(truncateDisplayWidth("trend unavailable: "+m.transactionsTrendErr, paneWidth)))
+			} else {
+				paneLines = append(paneLines, renderCategoryTrendLines(m.transactionsTrend, paneWidth, labelStyle, valueStyle)...)
+			}
+		}
 		paneLines = padTransactionsBodyLines(paneLines, paneInnerHeight)
 
 		pane = lipgloss.NewStyle().
@@ -2042,19 +3705,34 @@ func (m model) renderTransactionsScreen(layoutWidth int) string {
 		selected := m.transactionsTimeSeries[selectedIdx]
 		labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
 		valueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true)
-		paneLines := []string{lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render("transaction details")}
-		valueWidth := max(10, paneWidth-16)
-		paneLines = append(paneLines, renderDetailLines("account", selected.accountName, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("time", formatTransactionTime(selected.createdAt), valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("category", selected.categoryID, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("raw text", selected.rawText, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("status", selected.status, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("message", selected.message, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("description", selected.description, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("merchant", selected.merchant, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("card method", selected.cardMethod, valueWidth, labelStyle, valueStyle)...)
-		paneLines = append(paneLines, renderDetailLines("note text", selected.noteText, valueWidth, labelStyle, valueStyle)...)
 		paneInnerHeight := max(1, lipgloss.Height(leftBeforeFooter)-2)
+		var paneLines []string
+		if m.transactionsTimeSeriesRoundUps && m.transactionsRoundUpsMonthly {
+			paneLines = []string{lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render("round-up details")}
+			paneLines = append(paneLines, renderRoundUpMonthLines(selected, paneWidth, labelStyle, valueStyle)...)
+		} else if m.transactionsTimeSeriesGrouping != timeSeriesGroupTransaction {
+			paneLines = []string{lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render(timeSeriesGroupLabel(m.transactionsTimeSeriesGrouping) + " details")}
+			paneLines = append(paneLines, renderTimeSeriesBucketLines(selected, m.transactionsTimeSeriesGrouping, paneWidth, paneInnerHeight-1, labelStyle, valueStyle)...)
+		} else {
+			paneLines = []string{lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render("transaction details")}
+			valueWidth := max(10, paneWidth-16)
+			compact := transactionsDetailsCompact(m.transactionsDetailsToggled, paneInnerHeight)
+			paneLines = append(paneLines, renderTransactionDetailFields(transactionDetailFields{
+				account:     selected.accountName,
+				createdAt:   selected.createdAt,
+				amount:      selected.amountValue,
+				category:    displayCategory(m.transactionsCategoryAliases, selected.categoryID),
+				rawText:     selected.rawText,
+				status:      selected.status,
+				message:     selected.message,
+				description: selected.description,
+				merchant:    selected.merchant,
+				cardMethod:  selected.cardMethod,
+				noteText:    selected.noteText,
+				localNote:   selected.localNote,
+				receipt:     selected.hasAttachment,
+			}, compact, valueWidth, labelStyle, valueStyle)...)
+		}
 		paneLines = padTransactionsBodyLines(paneLines, paneInnerHeight)
 
 		pane = lipgloss.NewStyle().
@@ -2108,6 +3786,7 @@ func (m model) renderTransactionsFiltersScreen(layoutWidth int) string {
 	toBorder := dateBorderBase
 	quickBorder := quickBorderBase
 	includeBorder := lipgloss.Color("#FFFFFF")
+	pageSizeBorder := lipgloss.Color("#FFFFFF")
 	if m.transactionsFocus == transactionsFocusFromDate {
 		fromBorder = lipgloss.Color("#FFD54A")
 	}
@@ -2120,6 +3799,9 @@ func (m model) renderTransactionsFiltersScreen(layoutWidth int) string {
 	if m.transactionsFocus == transactionsFocusIncludeInternal {
 		includeBorder = lipgloss.Color("#FFD54A")
 	}
+	if m.transactionsFocus == transactionsFocusPageSize {
+		pageSizeBorder = lipgloss.Color("#FFD54A")
+	}
 
 	fromField := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(fromBorder).Padding(0, 1).Render(renderDateMask(m.transactionsFromDate))
 	toField := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(toBorder).Padding(0, 1).Render(renderDateMask(m.transactionsToDate))
@@ -2159,6 +3841,15 @@ func (m model) renderTransactionsFiltersScreen(layoutWidth int) string {
 		Padding(0, 1).
 		Render(switchOff + "  |  " + switchOn)
 
+	arrowStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF"))
+	pageSizeField := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(pageSizeBorder).
+		Padding(0, 1).
+		Render(arrowStyle.Render("‹ ") +
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")).Bold(true).Render(fmt.Sprintf("%2d", m.transactionsPageSize)) +
+			arrowStyle.Render(" ›"))
+
 	dateLabel := lipgloss.NewStyle().Foreground(dateLabelColor).Bold(true).Render("custom range")
 	dateFields := lipgloss.JoinHorizontal(
 		lipgloss.Center,
@@ -2187,8 +3878,13 @@ func (m model) renderTransactionsFiltersScreen(layoutWidth int) string {
 		lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true).Render("include internal transfers"),
 		includeSwitch,
 		"",
-		lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("tab switch field  ←/→ change value"),
-		lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("type date or c calendar  enter save/apply  esc back"),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#D1D5DB")).Bold(true).Render(
+			fmt.Sprintf("rows per page (%d-%d)", transactionsMinPageSize, transactionsMaxPageSize),
+		),
+		pageSizeField,
+		"",
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(transactionsFiltersHelpText),
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(transactionsFiltersSaveText),
 	}
 	if strings.TrimSpace(m.transactionsDateErr) != "" {
 		lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color("#F15B5B")).Render(m.transactionsDateErr))
@@ -2210,13 +3906,53 @@ func (m model) renderTransactionsFiltersScreen(layoutWidth int) string {
 
 func transactionsSortOptions() []transactionSortOption {
 	return []transactionSortOption{
-		{label: "date ↓", orderBy: "t.created_at DESC, t.id DESC"},
-		{label: "date ↑", orderBy: "t.created_at ASC, t.id ASC"},
-		{label: "merchant A-Z", orderBy: "COALESCE(t.merchant_norm, COALESCE(t.raw_text_norm, t.description_norm, t.raw_text, t.description, '')) ASC, t.created_at DESC, t.id DESC"},
-		{label: "merchant Z-A", orderBy: "COALESCE(t.merchant_norm, COALESCE(t.raw_text_norm, t.description_norm, t.raw_text, t.description, '')) DESC, t.created_at DESC, t.id DESC"},
-		{label: "amount ↓", orderBy: "t.amount_value_in_base_units DESC, t.created_at DESC, t.id DESC"},
-		{label: "amount ↑", orderBy: "t.amount_value_in_base_units ASC, t.created_at DESC, t.id DESC"},
+		{label: "date ↓", orderBy: "t.created_at DESC, t.id DESC", keyset: transactionsKeysetDesc},
+		{label: "date ↑", orderBy: "t.created_at ASC, t.id ASC", keyset: transactionsKeysetAsc},
+		{label: "merchant A-Z", orderBy: "COALESCE(t.merchant_norm, COALESCE(t.raw_text_norm, t.description_norm, t.raw_text, t.description, '')) ASC", grouped: true},
+		{label: "merchant Z-A", orderBy: "COALESCE(t.merchant_norm, COALESCE(t.raw_text_norm, t.description_norm, t.raw_text, t.description, '')) DESC", grouped: true},
+		{label: "amount ↓", orderBy: "t.amount_value_in_base_units DESC", grouped: true},
+		{label: "amount ↑", orderBy: "t.amount_value_in_base_units ASC", grouped: true},
+	}
+}
+
+// transactionsTableOrderBy resolves the table ORDER BY for a sort option.
+// Grouped sorts tie-break by date, oldest first unless newestFirst is set.
+func transactionsTableOrderBy(sortIdx int, newestFirst bool) string {
+	sorts := transactionsSortOptions()
+	if sortIdx < 0 || sortIdx >= len(sorts) {
+		sortIdx = 0
+	}
+	opt := sorts[sortIdx]
+	if !opt.grouped {
+		return opt.orderBy
+	}
+	if newestFirst {
+		return opt.orderBy + ", t.created_at DESC, t.id DESC"
+	}
+	return opt.orderBy + ", t.created_at ASC, t.id ASC"
+}
+
+func transactionsTableKeyset(sortIdx int) int {
+	sorts := transactionsSortOptions()
+	if sortIdx < 0 || sortIdx >= len(sorts) {
+		sortIdx = 0
+	}
+	return sorts[sortIdx].keyset
+}
+
+func transactionsTableSortLabel(sortIdx int, newestFirst bool) string {
+	sorts := transactionsSortOptions()
+	if sortIdx < 0 || sortIdx >= len(sorts) {
+		sortIdx = 0
 	}
+	opt := sorts[sortIdx]
+	if !opt.grouped {
+		return opt.label
+	}
+	if newestFirst {
+		return opt.label + ", then date ↓"
+	}
+	return opt.label + ", then date ↑"
 }
 
 func transactionsCategoryTransactionSortOptions() []transactionSortOption {
@@ -2230,7 +3966,13 @@ func transactionsCategoryTransactionSortOptions() []transactionSortOption {
 	}
 }
 
+// transactionsQuickRanges lists the quick ranges for their labels and
+// count. Use transactionsQuickRangesFor to apply the pay cycle range.
 func transactionsQuickRanges() []transactionQuickRange {
+	return transactionsQuickRangesFor(payCycleSettings{})
+}
+
+func transactionsQuickRangesFor(cycle payCycleSettings) []transactionQuickRange {
 	return []transactionQuickRange{
 		{
 			label: "today",
@@ -2263,25 +4005,44 @@ func transactionsQuickRanges() []transactionQuickRange {
 			label: "all",
 			apply: func(now time.Time) (time.Time, time.Time) { return time.Time{}, time.Time{} },
 		},
+		lastPayCycleQuickRange(cycle),
 	}
 }
 
-func (m *model) applyTransactionsQuickRange(idx int) {
-	ranges := transactionsQuickRanges()
+// transactionsQuickRangeIndex finds a quick range by its label, e.g. "6m".
+func transactionsQuickRangeIndex(label string) (int, bool) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	for i, r := range transactionsQuickRanges() {
+		if label != "" && r.label == label {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// transactionsQuickRangeDigits returns the quick range's from and to dates
+// as YYYYMMDD digits, both empty for "all".
+func transactionsQuickRangeDigits(idx int, now time.Time, cycle payCycleSettings) (string, string) {
+	ranges := transactionsQuickRangesFor(cycle)
 	if idx < 0 || idx >= len(ranges) {
 		idx = 0
 	}
-	m.transactionsQuickIdx = idx
-	now := time.Now().In(time.Local)
+	now = now.In(time.Local)
 	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
 	from, to := ranges[idx].apply(today)
 	if from.IsZero() && to.IsZero() {
-		m.transactionsFromDate = ""
-		m.transactionsToDate = ""
-		return
+		return "", ""
 	}
-	m.transactionsFromDate = fmt.Sprintf("%04d%02d%02d", from.Year(), int(from.Month()), from.Day())
-	m.transactionsToDate = fmt.Sprintf("%04d%02d%02d", to.Year(), int(to.Month()), to.Day())
+	return fmt.Sprintf("%04d%02d%02d", from.Year(), int(from.Month()), from.Day()),
+		fmt.Sprintf("%04d%02d%02d", to.Year(), int(to.Month()), to.Day())
+}
+
+func (m *model) applyTransactionsQuickRange(idx int) {
+	if idx < 0 || idx >= len(transactionsQuickRanges()) {
+		idx = 0
+	}
+	m.transactionsQuickIdx = idx
+	m.transactionsFromDate, m.transactionsToDate = transactionsQuickRangeDigits(idx, time.Now(), m.transactionsPayCycle)
 }
 
 func appendDateDigit(raw string, d rune) string {
@@ -2429,8 +4190,13 @@ func emptyDash(v string) string {
 }
 
 func renderDetailLines(label string, value string, width int, labelStyle lipgloss.Style, valueStyle lipgloss.Style) []string {
-	v := truncateRunes(emptyDash(value), 50)
-	segments := wrapRunes(v, width)
+	return renderWrappedDetailLines(label, truncateRunes(emptyDash(value), 50), width, labelStyle, valueStyle)
+}
+
+// renderWrappedDetailLines wraps the whole value across as many lines as it
+// needs instead of truncating it.
+func renderWrappedDetailLines(label string, value string, width int, labelStyle lipgloss.Style, valueStyle lipgloss.Style) []string {
+	segments := wrapRunes(emptyDash(value), width)
 	if len(segments) == 0 {
 		segments = []string{"-"}
 	}
@@ -2494,6 +4260,12 @@ func renderTransactionsCalendarOverlay(month time.Time, selected time.Time, isFr
 	if isFrom {
 		title = "calendar (from)"
 	}
+	return renderCalendarOverlay(title, month, selected)
+}
+
+// renderCalendarOverlay draws a six-week month grid with selected
+// highlighted, shared by every date picker.
+func renderCalendarOverlay(title string, month time.Time, selected time.Time) string {
 	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true)
 	header := titleStyle.Render(title + "  " + month.Format("January 2006"))
 
@@ -2524,8 +4296,8 @@ func renderTransactionsCalendarOverlay(month time.Time, selected time.Time, isFr
 		}
 		lines = append(lines, strings.Join(cells, " "))
 	}
-	lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("←/→/↑/↓ move  enter select  esc close"))
-	lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("shift+←/→ month  shift+↑/↓ year"))
+	lines = append(lines, "", lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(transactionsCalendarHelp))
+	lines = append(lines, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render(transactionsCalendarJump))
 	return lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("#FFFFFF")).
@@ -2579,6 +4351,37 @@ func calendarAnchorFromPartial(raw string) (time.Time, bool) {
 	return t, true
 }
 
+// moveCalendarCursor applies a date picker movement key: arrows move by
+// day or week, shift+←/→ by month and shift+↑/↓ by year. ok is false for
+// any other key.
+func moveCalendarCursor(cursor time.Time, key string) (time.Time, bool) {
+	switch key {
+	case "shift+left":
+		return shiftCalendarByMonths(cursor, -1), true
+	case "shift+right":
+		return shiftCalendarByMonths(cursor, 1), true
+	case "shift+up":
+		return shiftCalendarByYears(cursor, -1), true
+	case "shift+down":
+		return shiftCalendarByYears(cursor, 1), true
+	case "left":
+		return cursor.AddDate(0, 0, -1), true
+	case "right":
+		return cursor.AddDate(0, 0, 1), true
+	case "up":
+		return cursor.AddDate(0, 0, -7), true
+	case "down":
+		return cursor.AddDate(0, 0, 7), true
+	default:
+		return cursor, false
+	}
+}
+
+// calendarMonthOf is the first of the month a calendar cursor sits in.
+func calendarMonthOf(cursor time.Time) time.Time {
+	return time.Date(cursor.Year(), cursor.Month(), 1, 0, 0, 0, 0, time.Local)
+}
+
 func shiftCalendarByMonths(current time.Time, delta int) time.Time {
 	y, m, d := current.Date()
 	target := time.Date(y, m, 1, 0, 0, 0, 0, time.Local).AddDate(0, delta, 0)
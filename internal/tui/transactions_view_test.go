@@ -0,0 +1,2051 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/lachiem1/giddyUp/internal/storage"
+)
+
+func TestAppendTransactionsSearchClausesDate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query     string
+		wantWhere string
+		wantArg   string
+	}{
+		{query: "date: >2024-01-01", wantWhere: "date(t.created_at) > date(?)", wantArg: "2024-01-01"},
+		{query: "date: >=2024-01-01", wantWhere: "date(t.created_at) >= date(?)", wantArg: "2024-01-01"},
+		{query: "date: <2024-03-15", wantWhere: "date(t.created_at) < date(?)", wantArg: "2024-03-15"},
+		{query: "date: <=2024-03-15", wantWhere: "date(t.created_at) <= date(?)", wantArg: "2024-03-15"},
+		{query: "date: =2024-02-10", wantWhere: "date(t.created_at) = date(?)", wantArg: "2024-02-10"},
+		{query: "/date: 2024-02-10", wantWhere: "date(t.created_at) = date(?)", wantArg: "2024-02-10"},
+	}
+	for _, tt := range tests {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(tt.query, &where, &args); err != nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", tt.query, err)
+		}
+		if !reflect.DeepEqual(where, []string{tt.wantWhere}) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) where = %q, want %q", tt.query, where, tt.wantWhere)
+		}
+		if !reflect.DeepEqual(args, []any{tt.wantArg}) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) args = %v, want [%s]", tt.query, args, tt.wantArg)
+		}
+	}
+}
+
+func TestAppendTransactionsSearchClausesStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query   string
+		wantArg string
+	}{
+		{query: "status: held", wantArg: "HELD"},
+		{query: "status: HELD", wantArg: "HELD"},
+		{query: "status: pending", wantArg: "HELD"},
+		{query: "status: Settled", wantArg: "SETTLED"},
+	}
+	for _, tt := range tests {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(tt.query, &where, &args); err != nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", tt.query, err)
+		}
+		if !reflect.DeepEqual(where, []string{"UPPER(TRIM(t.status)) = ?"}) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) where = %q, want status clause", tt.query, where)
+		}
+		if !reflect.DeepEqual(args, []any{tt.wantArg}) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) args = %v, want [%s]", tt.query, args, tt.wantArg)
+		}
+	}
+	where := []string{}
+	args := []any{}
+	if err := appendTransactionsSearchClauses("status: frozen", &where, &args); err == nil {
+		t.Fatal("appendTransactionsSearchClauses(status: frozen) error = nil, want error")
+	}
+}
+
+func TestAppendTransactionsSearchClausesCurrency(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{"currency: USD", "currency: usd", "currency:  Usd "} {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(query, &where, &args); err != nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", query, err)
+		}
+		if !reflect.DeepEqual(where, []string{transactionsCurrencySQL}) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) where = %q, want currency clause", query, where)
+		}
+		if !reflect.DeepEqual(args, []any{"USD"}) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) args = %v, want [USD]", query, args)
+		}
+	}
+	for _, query := range []string{"currency: US", "currency: dollars", "currency: U5D"} {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(query, &where, &args); err == nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) error = nil, want error", query)
+		}
+	}
+}
+
+func TestAppendTransactionsSearchClausesAccount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query     string
+		wantWhere []string
+		wantArgs  []any
+	}{
+		{
+			query:     "account: Spending",
+			wantWhere: []string{transactionsAccountInSQL},
+			wantArgs:  []any{"%spending%"},
+		},
+		{
+			query:     "exclude-account: Bills",
+			wantWhere: []string{"NOT " + transactionsAccountInSQL},
+			wantArgs:  []any{"%bills%"},
+		},
+		{
+			query:     "account: spending + merchant: =WOOL",
+			wantWhere: []string{transactionsAccountInSQL, "LOWER(" + transactionsMerchantSQL + ") = ?"},
+			wantArgs:  []any{"%spending%", "wool"},
+		},
+	}
+	for _, tt := range tests {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(tt.query, &where, &args); err != nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", tt.query, err)
+		}
+		if !reflect.DeepEqual(where, tt.wantWhere) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) where = %q, want %q", tt.query, where, tt.wantWhere)
+		}
+		if !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) args = %v, want %v", tt.query, args, tt.wantArgs)
+		}
+	}
+}
+
+func TestFormatForeignAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value string
+		code  string
+		want  string
+	}{
+		{value: "-12.50", code: "usd", want: "-12.50 USD"},
+		{value: "", code: "JPY", want: "JPY"},
+		{value: "-12.50", code: "", want: ""},
+	}
+	for _, tt := range tests {
+		if got := formatForeignAmount(tt.value, tt.code); got != tt.want {
+			t.Fatalf("formatForeignAmount(%q, %q) = %q, want %q", tt.value, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestRenderTransactionsTableMarksHeldRows(t *testing.T) {
+	t.Parallel()
+
+	rows := []transactionPreviewRow{
+		{createdAt: "2026-10-01T09:00:00Z", merchant: "Cafe", amountValue: "-4.50", status: "HELD"},
+		{createdAt: "2026-10-01T08:00:00Z", merchant: "Rent", amountValue: "-500.00", status: "SETTLED"},
+	}
+	lines := renderTransactionsTableLines(rows, 0, 20, 60, 5, 0, nil)
+	if !strings.Contains(lines[1], transactionsHeldGlyph) || strings.Contains(lines[2], transactionsHeldGlyph) {
+		t.Fatalf("table rows = %q, want only the held row marked", lines[1:])
+	}
+	rows[0].status = "SETTLED"
+	if lines := renderTransactionsTableLines(rows, 0, 20, 60, 5, 0, nil); strings.Contains(strings.Join(lines, "\n"), transactionsHeldGlyph) {
+		t.Fatalf("table = %q, want no status column without held rows", lines)
+	}
+}
+
+func TestAppendTransactionsSearchClausesDateComposes(t *testing.T) {
+	t.Parallel()
+
+	where := []string{"t.is_active = 1"}
+	args := []any{}
+	if err := appendTransactionsSearchClauses("merchant: wool + date: >=2024-01-01", &where, &args); err != nil {
+		t.Fatalf("appendTransactionsSearchClauses() unexpected error: %v", err)
+	}
+	if err := appendTransactionsDateClauses("20240101", "20240331", &where, &args); err != nil {
+		t.Fatalf("appendTransactionsDateClauses() unexpected error: %v", err)
+	}
+	if len(where) != 5 {
+		t.Fatalf("len(where) = %d, want 5: %q", len(where), where)
+	}
+	if got := args[1]; got != "2024-01-01" {
+		t.Fatalf("args[1] = %v, want 2024-01-01", got)
+	}
+}
+
+func TestAppendTransactionsSearchClausesRejectsMalformedDate(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{
+		"date: 2024-13-01",
+		"date: >2024/01/01",
+		"date: >=",
+		"date: yesterday",
+	} {
+		if err := validateTransactionsSearchSyntax(query); err == nil {
+			t.Fatalf("validateTransactionsSearchSyntax(%q) error = nil, want error", query)
+		}
+	}
+}
+
+func TestAppendTransactionsSearchClausesOrGroups(t *testing.T) {
+	t.Parallel()
+
+	merchantLike := `LOWER(COALESCE(
+			NULLIF(t.merchant_norm, ''),
+			NULLIF(t.raw_text_norm, ''),
+			NULLIF(t.description_norm, ''),
+			COALESCE(t.raw_text, t.description, '')
+		)) LIKE ?`
+	tests := []struct {
+		query     string
+		wantWhere []string
+		wantArgs  []any
+	}{
+		{
+			query:     "merchant: WOOL | merchant: COLES",
+			wantWhere: []string{"(" + merchantLike + " OR " + merchantLike + ")"},
+			wantArgs:  []any{"%wool%", "%coles%"},
+		},
+		{
+			query: "type: -ve + (merchant: WOOL | merchant: COLES)",
+			wantWhere: []string{
+				"t.amount_value_in_base_units < 0",
+				"(" + merchantLike + " OR " + merchantLike + ")",
+			},
+			wantArgs: []any{"%wool%", "%coles%"},
+		},
+		{
+			query: "(amount: >60 | date: <2024-01-01) + category: groceries",
+			wantWhere: []string{
+				"(ABS(t.amount_value_in_base_units) > ? OR date(t.created_at) < date(?))",
+				"LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) LIKE ?",
+			},
+			wantArgs: []any{int64(6000), "2024-01-01", "%groceries%"},
+		},
+		{
+			query:     "(category: groceries)",
+			wantWhere: []string{"LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) LIKE ?"},
+			wantArgs:  []any{"%groceries%"},
+		},
+	}
+	for _, tt := range tests {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(tt.query, &where, &args); err != nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", tt.query, err)
+		}
+		if !reflect.DeepEqual(where, tt.wantWhere) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) where = %q, want %q", tt.query, where, tt.wantWhere)
+		}
+		if !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) args = %v, want %v", tt.query, args, tt.wantArgs)
+		}
+	}
+}
+
+func TestAppendTransactionsSearchClausesExactMerchant(t *testing.T) {
+	t.Parallel()
+
+	merchantExact := "LOWER(" + transactionsMerchantSQL + ") = ?"
+	categoryNotLike := "LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) NOT LIKE ?"
+	tests := []struct {
+		query     string
+		wantWhere []string
+		wantArgs  []any
+	}{
+		{
+			query:     "merchant: =UBER",
+			wantWhere: []string{merchantExact},
+			wantArgs:  []any{"uber"},
+		},
+		{
+			query:     "merchant= Uber",
+			wantWhere: []string{merchantExact},
+			wantArgs:  []any{"uber"},
+		},
+		{
+			// The exact form is a new term, not a continuation of the
+			// exclude field before it.
+			query:     "exclude-category: uncat + hobb + merchant= uber",
+			wantWhere: []string{categoryNotLike, categoryNotLike, merchantExact},
+			wantArgs:  []any{"%uncat%", "%hobb%", "uber"},
+		},
+		{
+			query:     "merchant= uber | merchant: =uber eats",
+			wantWhere: []string{"(" + merchantExact + " OR " + merchantExact + ")"},
+			wantArgs:  []any{"uber", "uber eats"},
+		},
+	}
+	for _, tt := range tests {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(tt.query, &where, &args); err != nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", tt.query, err)
+		}
+		if !reflect.DeepEqual(where, tt.wantWhere) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) where = %q, want %q", tt.query, where, tt.wantWhere)
+		}
+		if !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) args = %v, want %v", tt.query, args, tt.wantArgs)
+		}
+	}
+	// Merchant terms never continue, so a bare word after one is an error.
+	for _, query := range []string{"merchant: =", "merchant=   ", "merchant= uber + eats"} {
+		if err := appendTransactionsSearchClauses(query, &[]string{}, &[]any{}); err == nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) error = nil, want error", query)
+		}
+	}
+}
+
+func TestAppendTransactionsSearchClausesAmountRange(t *testing.T) {
+	t.Parallel()
+
+	between := "ABS(t.amount_value_in_base_units) BETWEEN ? AND ?"
+	tests := []struct {
+		query    string
+		wantArgs []any
+	}{
+		{query: "amount: 10..50", wantArgs: []any{int64(1000), int64(5000)}},
+		{query: "amount: 10.05..49.99", wantArgs: []any{int64(1005), int64(4999)}},
+		{query: "amount: 0.1 .. 0.2", wantArgs: []any{int64(10), int64(20)}},
+		// SQL BETWEEN is inclusive, so equal bounds match that exact amount.
+		{query: "amount: 25..25", wantArgs: []any{int64(2500), int64(2500)}},
+	}
+	for _, tt := range tests {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(tt.query, &where, &args); err != nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", tt.query, err)
+		}
+		if !reflect.DeepEqual(where, []string{between}) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) where = %q, want %q", tt.query, where, between)
+		}
+		if !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) args = %v, want %v", tt.query, args, tt.wantArgs)
+		}
+	}
+
+	errs := []struct {
+		query string
+		want  string
+	}{
+		{query: "amount: 50..10", want: `invalid search at col 1 "amount: 50..10": amount: range low bound is above the high bound`},
+		{query: "amount: 10..", want: `invalid search at col 1 "amount: 10..": amount: expected a number on both sides of '..'`},
+		{query: "amount: >10..50", want: `invalid search at col 1 "amount: >10..50": amount: expected a number on both sides of '..'`},
+	}
+	for _, tt := range errs {
+		err := appendTransactionsSearchClauses(tt.query, &[]string{}, &[]any{})
+		if err == nil || err.Error() != tt.want {
+			t.Fatalf("appendTransactionsSearchClauses(%q) error = %v, want %q", tt.query, err, tt.want)
+		}
+	}
+}
+
+func TestAppendTransactionsSearchClausesRejectsDanglingSeparators(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{
+		"merchant: WOOL |",
+		"| merchant: WOOL",
+		"merchant: WOOL + ",
+		"type: -ve + (merchant: WOOL |)",
+		"type: -ve + (merchant: WOOL | merchant: COLES",
+		"merchant: WOOL | | merchant: COLES",
+	} {
+		if err := validateTransactionsSearchSyntax(query); err == nil {
+			t.Fatalf("validateTransactionsSearchSyntax(%q) error = nil, want error", query)
+		}
+	}
+}
+
+func TestTransactionsKeysetSeek(t *testing.T) {
+	t.Parallel()
+
+	prev := transactionsPageKey{
+		page:           3,
+		firstCreatedAt: "2024-03-10T09:00:00+11:00",
+		firstID:        "tx-first",
+		lastCreatedAt:  "2024-03-01T09:00:00+11:00",
+		lastID:         "tx-last",
+	}
+
+	next, ok := transactionsKeysetSeek(transactionsKeysetDesc, prev, 4)
+	if !ok {
+		t.Fatal("transactionsKeysetSeek(desc, next) ok = false, want true")
+	}
+	if want := "(t.created_at < ? OR (t.created_at = ? AND t.id < ?))"; next.where != want {
+		t.Fatalf("transactionsKeysetSeek(desc, next) where = %q, want %q", next.where, want)
+	}
+	if want := []any{prev.lastCreatedAt, prev.lastCreatedAt, prev.lastID}; !reflect.DeepEqual(next.args, want) {
+		t.Fatalf("transactionsKeysetSeek(desc, next) args = %v, want %v", next.args, want)
+	}
+	if next.reverse || next.orderBy != "" {
+		t.Fatalf("transactionsKeysetSeek(desc, next) = %+v, want display order", next)
+	}
+
+	back, ok := transactionsKeysetSeek(transactionsKeysetDesc, prev, 2)
+	if !ok {
+		t.Fatal("transactionsKeysetSeek(desc, prev) ok = false, want true")
+	}
+	if want := "(t.created_at > ? OR (t.created_at = ? AND t.id > ?))"; back.where != want {
+		t.Fatalf("transactionsKeysetSeek(desc, prev) where = %q, want %q", back.where, want)
+	}
+	if want := []any{prev.firstCreatedAt, prev.firstCreatedAt, prev.firstID}; !reflect.DeepEqual(back.args, want) {
+		t.Fatalf("transactionsKeysetSeek(desc, prev) args = %v, want %v", back.args, want)
+	}
+	if !back.reverse || back.orderBy != "t.created_at ASC, t.id ASC" {
+		t.Fatalf("transactionsKeysetSeek(desc, prev) = %+v, want reversed ascending walk", back)
+	}
+
+	asc, ok := transactionsKeysetSeek(transactionsKeysetAsc, prev, 4)
+	if !ok || asc.where != "(t.created_at > ? OR (t.created_at = ? AND t.id > ?))" {
+		t.Fatalf("transactionsKeysetSeek(asc, next) = %+v, %v", asc, ok)
+	}
+
+	for _, tt := range []struct {
+		keyset int
+		prev   transactionsPageKey
+		page   int
+	}{
+		{keyset: transactionsKeysetNone, prev: prev, page: 4},
+		{keyset: transactionsKeysetDesc, prev: prev, page: 7},
+		{keyset: transactionsKeysetDesc, prev: prev, page: 3},
+		{keyset: transactionsKeysetDesc, prev: transactionsPageKey{page: 3}, page: 4},
+	} {
+		if _, ok := transactionsKeysetSeek(tt.keyset, tt.prev, tt.page); ok {
+			t.Fatalf("transactionsKeysetSeek(%d, page %d) ok = true, want OFFSET fallback", tt.keyset, tt.page)
+		}
+	}
+}
+
+func TestParseTransactionsDate(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseTransactionsDate("2024-02-29")
+	if err != nil {
+		t.Fatalf("ParseTransactionsDate() unexpected error: %v", err)
+	}
+	if got != "20240229" {
+		t.Fatalf("ParseTransactionsDate() = %q, want %q", got, "20240229")
+	}
+
+	for _, value := range []string{"20240229", "2023-02-29", "2024-13-01", "2024/01/01", ""} {
+		if _, err := ParseTransactionsDate(value); err == nil {
+			t.Fatalf("ParseTransactionsDate(%q) error = nil, want error", value)
+		}
+	}
+}
+
+func TestHourlySpendBarLines(t *testing.T) {
+	t.Parallel()
+
+	var spend [24]int64
+	spend[0] = 100
+	spend[12] = 1600
+	spend[23] = 800
+	lines := hourlySpendBarLines(spend, 2, 2)
+	if len(lines) != 2 {
+		t.Fatalf("len(hourlySpendBarLines()) = %d, want 2", len(lines))
+	}
+	top := []rune(lines[0])
+	bottom := []rune(lines[1])
+	if got := string(top[24]); got != "█" {
+		t.Fatalf("peak hour top cell = %q, want full block", got)
+	}
+	if got := string(bottom[46]); got != "█" {
+		t.Fatalf("hour 23 bottom cell = %q, want full block", got)
+	}
+	if len(top) > 46 {
+		t.Fatalf("hour 23 top cell = %q, want empty", string(top[46]))
+	}
+	if got := string(bottom[0]); got != "▁" {
+		t.Fatalf("hour 0 bottom cell = %q, want a sliver", got)
+	}
+	if got := string(bottom[2]); got != " " {
+		t.Fatalf("hour 1 bottom cell = %q, want empty", got)
+	}
+}
+
+func TestRenderTransactionsHeatmapLines(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		cents, peak int64
+		want        string
+	}{
+		{cents: 0, peak: 1000, want: "·"},
+		{cents: 1, peak: 1000, want: "░"},
+		{cents: 500, peak: 1000, want: "▒"},
+		{cents: 751, peak: 1000, want: "█"},
+		{cents: 1000, peak: 1000, want: "█"},
+	} {
+		if got := heatmapShade(tt.cents, tt.peak); got != tt.want {
+			t.Fatalf("heatmapShade(%d, %d) = %q, want %q", tt.cents, tt.peak, got, tt.want)
+		}
+	}
+
+	var grid spendHeatmap
+	grid[5][18] = 4000 // Friday 6pm
+	grid[1][9] = 1000  // Monday 9am
+	lines := renderTransactionsHeatmapLines(grid, 80)
+	// Title, seven weekday rows Monday first, axis, blank, legend, busiest.
+	if len(lines) != 12 {
+		t.Fatalf("len(renderTransactionsHeatmapLines()) = %d, want 12", len(lines))
+	}
+	monday := []rune(lines[1])
+	if !strings.HasPrefix(lines[1], "Mon ") || string(monday[4+9*3]) != "░" {
+		t.Fatalf("Monday row = %q, want a light cell at 09:00", lines[1])
+	}
+	friday := []rune(lines[5])
+	if !strings.HasPrefix(lines[5], "Fri ") || string(friday[4+18*3]) != "█" {
+		t.Fatalf("Friday row = %q, want the darkest cell at 18:00", lines[5])
+	}
+	if !strings.Contains(lines[11], "Fri 18:00-18:59") {
+		t.Fatalf("busiest line = %q, want Friday 18:00", lines[11])
+	}
+
+	if empty := renderTransactionsHeatmapLines(spendHeatmap{}, 80); len(empty) != 2 || !strings.Contains(empty[1], "no transactions") {
+		t.Fatalf("empty heatmap = %q, want the no transactions line", empty)
+	}
+}
+
+func TestToggleIgnoredMerchant(t *testing.T) {
+	t.Parallel()
+
+	list, ignored := toggleIgnoredMerchant(nil, "  Woolworths,  Sydney ")
+	if !ignored || !reflect.DeepEqual(list, []string{"Woolworths, Sydney"}) {
+		t.Fatalf("toggleIgnoredMerchant() = %q, %v, want [Woolworths, Sydney], true", list, ignored)
+	}
+	list, ignored = toggleIgnoredMerchant(list, "woolworths, sydney")
+	if ignored || len(list) != 0 {
+		t.Fatalf("toggleIgnoredMerchant() = %q, %v, want [], false", list, ignored)
+	}
+
+	raw := formatIgnoredMerchants([]string{"Partner", "Woolworths, Sydney", "partner", " "})
+	if got := parseIgnoredMerchants(raw); !reflect.DeepEqual(got, []string{"Partner", "Woolworths, Sydney"}) {
+		t.Fatalf("parseIgnoredMerchants(formatIgnoredMerchants()) = %q", got)
+	}
+}
+
+func TestAppendIgnoredMerchantsClause(t *testing.T) {
+	t.Parallel()
+
+	where := []string{"t.is_active = 1"}
+	args := []any{}
+	appendIgnoredMerchantsClause(nil, &where, &args)
+	if len(where) != 1 || len(args) != 0 {
+		t.Fatalf("appendIgnoredMerchantsClause(nil) where = %q, args = %v, want unchanged", where, args)
+	}
+
+	appendIgnoredMerchantsClause([]string{"Partner", "Vanguard"}, &where, &args)
+	if len(where) != 2 || !strings.HasSuffix(where[1], "NOT IN (?, ?)") {
+		t.Fatalf("appendIgnoredMerchantsClause() where = %q, want NOT IN (?, ?)", where)
+	}
+	if !reflect.DeepEqual(args, []any{"partner", "vanguard"}) {
+		t.Fatalf("appendIgnoredMerchantsClause() args = %v, want [partner vanguard]", args)
+	}
+}
+
+func TestToggleHiddenCategory(t *testing.T) {
+	t.Parallel()
+
+	list, hidden := toggleHiddenCategory(nil, " Investments ")
+	if !hidden || !reflect.DeepEqual(list, []string{"investments"}) {
+		t.Fatalf("toggleHiddenCategory() = %q, %v, want [investments], true", list, hidden)
+	}
+	list, hidden = toggleHiddenCategory(list, "investments")
+	if hidden || len(list) != 0 {
+		t.Fatalf("toggleHiddenCategory() = %q, %v, want [], false", list, hidden)
+	}
+
+	if got := parseHiddenCategories("investments\n\nUncategorized\ninvestments"); !reflect.DeepEqual(got, []string{"investments", "uncategorized"}) {
+		t.Fatalf("parseHiddenCategories() = %q, want [investments uncategorized]", got)
+	}
+}
+
+func TestAppendHiddenCategoriesClause(t *testing.T) {
+	t.Parallel()
+
+	where := []string{"t.is_active = 1"}
+	args := []any{}
+	appendHiddenCategoriesClause(nil, &where, &args)
+	if len(where) != 1 || len(args) != 0 {
+		t.Fatalf("appendHiddenCategoriesClause(nil) where = %q, args = %v, want unchanged", where, args)
+	}
+
+	appendHiddenCategoriesClause([]string{"investments", "uncategorized"}, &where, &args)
+	if len(where) != 2 || !strings.Contains(where[1], "t.category_id") || !strings.HasSuffix(where[1], "NOT IN (?, ?)") {
+		t.Fatalf("appendHiddenCategoriesClause() where = %q, want category_id NOT IN (?, ?)", where)
+	}
+	if !reflect.DeepEqual(args, []any{"investments", "uncategorized"}) {
+		t.Fatalf("appendHiddenCategoriesClause() args = %v, want [investments uncategorized]", args)
+	}
+}
+
+func TestParseTransactionsPageSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw  string
+		want int
+	}{
+		{raw: "20", want: 20},
+		{raw: " 5 ", want: 5},
+		{raw: "50", want: 50},
+		{raw: "2", want: transactionsMinPageSize},
+		{raw: "-3", want: transactionsMinPageSize},
+		{raw: "500", want: transactionsMaxPageSize},
+		{raw: "", want: transactionsDefaultPageSize},
+		{raw: "lots", want: transactionsDefaultPageSize},
+	}
+	for _, tt := range tests {
+		if got := parseTransactionsPageSize(tt.raw); got != tt.want {
+			t.Fatalf("parseTransactionsPageSize(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestAggregateTimeSeriesPoints(t *testing.T) {
+	t.Parallel()
+
+	points := []transactionsTimeSeriesPoint{
+		{date: "2026-01-30", id: "a", spendCents: 500},
+		{date: "2026-02-01", id: "b", spendCents: 250},
+		{date: "2026-01-30", id: "c", spendCents: 100},
+		{date: "2026-02-14", id: "d", spendCents: 1000},
+	}
+
+	if got := aggregateTimeSeriesPoints(points, timeSeriesGroupTransaction); len(got) != len(points) {
+		t.Fatalf("aggregateTimeSeriesPoints(transaction) len = %d, want %d", len(got), len(points))
+	}
+
+	tests := []struct {
+		grouping int
+		dates    []string
+		spend    []int64
+		members  []int
+	}{
+		{
+			grouping: timeSeriesGroupDay,
+			dates:    []string{"2026-01-30", "2026-02-01", "2026-02-14"},
+			spend:    []int64{600, 250, 1000},
+			members:  []int{2, 1, 1},
+		},
+		{
+			grouping: timeSeriesGroupMonth,
+			dates:    []string{"2026-01-01", "2026-02-01"},
+			spend:    []int64{600, 1250},
+			members:  []int{2, 2},
+		},
+	}
+	for _, tt := range tests {
+		got := aggregateTimeSeriesPoints(points, tt.grouping)
+		if len(got) != len(tt.dates) {
+			t.Fatalf("aggregateTimeSeriesPoints(%s) len = %d, want %d", timeSeriesGroupLabel(tt.grouping), len(got), len(tt.dates))
+		}
+		for i, p := range got {
+			if p.date != tt.dates[i] || p.spendCents != tt.spend[i] || len(p.members) != tt.members[i] {
+				t.Fatalf("aggregateTimeSeriesPoints(%s)[%d] = {%s %d %d members}, want {%s %d %d members}",
+					timeSeriesGroupLabel(tt.grouping), i, p.date, p.spendCents, len(p.members), tt.dates[i], tt.spend[i], tt.members[i])
+			}
+		}
+	}
+}
+
+func TestParseTimeSeriesGroup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw  string
+		want int
+	}{
+		{raw: "month", want: timeSeriesGroupMonth},
+		{raw: " Day ", want: timeSeriesGroupDay},
+		{raw: "transaction", want: timeSeriesGroupTransaction},
+		{raw: "weekly", want: timeSeriesGroupTransaction},
+		{raw: "", want: timeSeriesGroupTransaction},
+	}
+	for _, tt := range tests {
+		if got := parseTimeSeriesGroup(tt.raw); got != tt.want {
+			t.Fatalf("parseTimeSeriesGroup(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestRenderSparkline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		values []int64
+		width  int
+		want   string
+	}{
+		{values: nil, width: 10, want: ""},
+		{values: []int64{1, 2}, width: 0, want: ""},
+		{values: []int64{0, 1, 7, 0}, width: 10, want: " ▂█ "},
+		{values: []int64{1, 1, 0, 0, 4, 4}, width: 3, want: "▂ █"},
+		{values: []int64{0, 0}, width: 4, want: "  "},
+	}
+	for _, tt := range tests {
+		if got := renderSparkline(tt.values, tt.width); got != tt.want {
+			t.Fatalf("renderSparkline(%v, %d) = %q, want %q", tt.values, tt.width, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionsWeeklyWindow(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		total, scroll, visible int
+		wantStart, wantEnd     int
+	}{
+		{total: 4, scroll: 0, visible: 15, wantStart: 0, wantEnd: 4},
+		{total: 20, scroll: 0, visible: 15, wantStart: 5, wantEnd: 20},
+		{total: 20, scroll: 3, visible: 15, wantStart: 2, wantEnd: 17},
+		{total: 20, scroll: 99, visible: 15, wantStart: 0, wantEnd: 15},
+		{total: 0, scroll: 2, visible: 15, wantStart: 0, wantEnd: 0},
+	}
+	for _, tt := range tests {
+		start, end := transactionsWeeklyWindow(tt.total, tt.scroll, tt.visible)
+		if start != tt.wantStart || end != tt.wantEnd {
+			t.Fatalf("transactionsWeeklyWindow(%d, %d, %d) = %d, %d, want %d, %d",
+				tt.total, tt.scroll, tt.visible, start, end, tt.wantStart, tt.wantEnd)
+		}
+	}
+
+	weeks := []transactionsWeeklySpend{{week: "2024-W06", spendCents: 10000}, {week: "2024-W07", spendCents: 25001}}
+	if got := averageWeeklySpendCents(weeks); got != 17501 {
+		t.Fatalf("averageWeeklySpendCents() = %d, want 17501", got)
+	}
+}
+
+func TestRenderKeybindingsMarkdownCoversCatalogAndFooters(t *testing.T) {
+	t.Parallel()
+
+	got := renderKeybindingsMarkdown()
+	for _, cmd := range commandCatalog() {
+		if !strings.Contains(got, "`"+cmd.name+"`") {
+			t.Fatalf("renderKeybindingsMarkdown() missing command %q", cmd.name)
+		}
+	}
+	for _, want := range []string{"- g granularity", "- ↑/↓ scroll weeks", "- I ignore merchant", "## Pay cycle burndown"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("renderKeybindingsMarkdown() missing %q", want)
+		}
+	}
+}
+
+func TestParseTransactionsViewMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw  string
+		want int
+	}{
+		{raw: "table", want: transactionsViewModeTable},
+		{raw: "chart", want: transactionsViewModeChart},
+		{raw: " TIME_SERIES ", want: transactionsViewModeTimeSeries},
+		{raw: "weekly", want: transactionsViewModeWeekly},
+		{raw: "heatmap", want: transactionsViewModeHeatmap},
+		{raw: "pie", want: transactionsViewModeTable},
+		{raw: "", want: transactionsViewModeTable},
+	}
+	for _, tt := range tests {
+		if got := parseTransactionsViewMode(tt.raw); got != tt.want {
+			t.Fatalf("parseTransactionsViewMode(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestParseTransactionsChartGrouping(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw  string
+		want int
+	}{
+		{raw: "category", want: transactionsChartGroupCategory},
+		{raw: " Parent ", want: transactionsChartGroupParent},
+		{raw: "merchant", want: transactionsChartGroupMerchant},
+		{raw: "account", want: transactionsChartGroupCategory},
+		{raw: "", want: transactionsChartGroupCategory},
+	}
+	for _, tt := range tests {
+		if got := parseTransactionsChartGrouping(tt.raw); got != tt.want {
+			t.Fatalf("parseTransactionsChartGrouping(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+
+	m := model{transactionsChartParentDrill: "good-life"}
+	m.setTransactionsChartGrouping(transactionsChartGroupParent)
+	if !m.transactionsChartByParent || m.transactionsChartByMerchant || m.transactionsChartParentDrill != "" {
+		t.Fatalf("setTransactionsChartGrouping(parent) = parent %v merchant %v drill %q, want parents with no drill",
+			m.transactionsChartByParent, m.transactionsChartByMerchant, m.transactionsChartParentDrill)
+	}
+	if got := m.transactionsChartGrouping(); got != transactionsChartGroupParent {
+		t.Fatalf("transactionsChartGrouping() = %d, want %d", got, transactionsChartGroupParent)
+	}
+}
+
+func TestAppendTransactionsSearchClausesTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query     string
+		wantWhere []string
+		wantArgs  []any
+	}{
+		{
+			query:     "tag: Holiday",
+			wantWhere: []string{transactionsTagExistsSQL},
+			wantArgs:  []any{"holiday"},
+		},
+		{
+			query:     "exclude-tag: Work",
+			wantWhere: []string{"NOT " + transactionsTagExistsSQL},
+			wantArgs:  []any{"work"},
+		},
+		{
+			query:     "exclude-tag: work + reimbursed",
+			wantWhere: []string{"NOT " + transactionsTagExistsSQL, "NOT " + transactionsTagExistsSQL},
+			wantArgs:  []any{"work", "reimbursed"},
+		},
+		{
+			query:     "type: -ve + (tag: holiday | tag: travel)",
+			wantWhere: []string{"t.amount_value_in_base_units < 0", "(" + transactionsTagExistsSQL + " OR " + transactionsTagExistsSQL + ")"},
+			wantArgs:  []any{"holiday", "travel"},
+		},
+	}
+	for _, tt := range tests {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(tt.query, &where, &args); err != nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", tt.query, err)
+		}
+		if !reflect.DeepEqual(where, tt.wantWhere) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) where = %q, want %q", tt.query, where, tt.wantWhere)
+		}
+		if !reflect.DeepEqual(args, tt.wantArgs) {
+			t.Fatalf("appendTransactionsSearchClauses(%q) args = %v, want %v", tt.query, args, tt.wantArgs)
+		}
+	}
+	if !strings.Contains(transactionsTagExistsSQL, "tt.transaction_id = t.id") {
+		t.Fatalf("transactionsTagExistsSQL does not correlate on t.id: %s", transactionsTagExistsSQL)
+	}
+}
+
+func TestTransactionsFilterWhereAmountSign(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sign int
+		want string
+	}{
+		{sign: 1, want: "t.amount_value_in_base_units > 0"},
+		{sign: -1, want: "t.amount_value_in_base_units < 0"},
+		{sign: 0, want: ""},
+	}
+	for _, tt := range tests {
+		where, _, err := transactionsFilterWhere(TransactionsFilter{IncludeInternal: true, AmountSign: tt.sign})
+		if err != nil {
+			t.Fatalf("transactionsFilterWhere(sign %d) unexpected error: %v", tt.sign, err)
+		}
+		want := []string{"t.is_active = 1"}
+		if tt.want != "" {
+			want = append(want, tt.want)
+		}
+		if !reflect.DeepEqual(where, want) {
+			t.Fatalf("transactionsFilterWhere(sign %d) where = %q, want %q", tt.sign, where, want)
+		}
+	}
+}
+
+func TestRenderWrappedDetailLinesKeepsLongValues(t *testing.T) {
+	t.Parallel()
+
+	plain := lipgloss.NewStyle()
+	tags := "groceries, holiday-2026, reimbursable, shared-with-flatmates, work-travel"
+	lines := renderWrappedDetailLines("tags", tags, 20, plain, plain)
+	if len(lines) < 4 {
+		t.Fatalf("renderWrappedDetailLines() = %d lines, want at least 4", len(lines))
+	}
+	var joined strings.Builder
+	for i, line := range lines {
+		if i == 0 {
+			line = strings.TrimPrefix(line, "tags: ")
+		}
+		joined.WriteString(strings.TrimLeft(line, " "))
+	}
+	if joined.String() != tags {
+		t.Fatalf("renderWrappedDetailLines() lost text: %q", joined.String())
+	}
+	if got := renderWrappedDetailLines("tags", "", 20, plain, plain); len(got) != 1 || got[0] != "tags: -" {
+		t.Fatalf("renderWrappedDetailLines(empty) = %q, want [\"tags: -\"]", got)
+	}
+}
+
+func TestRunningBalanceColumn(t *testing.T) {
+	t.Parallel()
+
+	rows := []transactionPreviewRow{{id: "t2"}, {id: "t1"}}
+	got, ok := runningBalanceColumn(rows, map[string]int64{"t1": 80000, "t2": -1250})
+	if !ok {
+		t.Fatalf("runningBalanceColumn() ok = false, want true")
+	}
+	if want := []string{"-12.50", "800.00"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("runningBalanceColumn() = %q, want %q", got, want)
+	}
+	if _, ok := runningBalanceColumn(rows, map[string]int64{"t2": 100}); ok {
+		t.Fatalf("runningBalanceColumn(missing row) ok = true, want false")
+	}
+	if _, ok := runningBalanceColumn(rows, nil); ok {
+		t.Fatalf("runningBalanceColumn(nil) ok = true, want false")
+	}
+}
+
+func TestMerchantGroupLabelFoldsStoreNumbers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "WOOLWORTHS 1234", want: "WOOLWORTHS"},
+		{in: "WOOLWORTHS  5678 SYDNEY", want: "WOOLWORTHS SYDNEY"},
+		{in: "7-Eleven #0421", want: "7-Eleven"},
+		{in: "1234", want: "1234"},
+		{in: "  ", want: unknownMerchantLabel},
+	}
+	for _, tt := range tests {
+		if got := merchantGroupLabel(tt.in); got != tt.want {
+			t.Fatalf("merchantGroupLabel(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFilterMerchantGroupRowsKeepsOnlyTheGroup(t *testing.T) {
+	t.Parallel()
+
+	rows := []categoryTransactionRow{
+		{id: "a", merchant: "WOOLWORTHS 1234"},
+		{id: "b", merchant: "Woolworths 5678"},
+		{id: "c", merchant: "WOOLWORTHS METRO 12"},
+	}
+	got := filterMerchantGroupRows(rows, "WOOLWORTHS")
+	if len(got) != 2 || got[0].id != "a" || got[1].id != "b" {
+		t.Fatalf("filterMerchantGroupRows() = %+v, want rows a and b", got)
+	}
+}
+
+func TestExplainTransactionsSearch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "", want: ""},
+		{query: "/help", want: ""},
+		{query: "merchant: Woo + amount: >60 + category: groceries", want: "merchant contains 'woo' AND amount > $60.00 AND category contains 'groceries'"},
+		{query: "(tag: work | tag: travel) + type: -ve", want: "(tagged 'work' OR tagged 'travel') AND is a debit"},
+		{query: "exclude-category: uncat + hobb", want: "category does not contain 'uncat' AND category does not contain 'hobb'"},
+		{query: "date: 2024-03-01", want: "on 2024-03-01"},
+		{query: "note: Split With Sam", want: "note contains 'split with sam'"},
+		{query: "merchant= UBER", want: "merchant is 'uber'"},
+		{query: "amount: 10..50", want: "amount between $10.00 and $50.00"},
+		{query: "status: Pending", want: "is held"},
+		{query: "currency: usd", want: "paid in USD"},
+		{query: "exclude-account: Bills", want: "account does not contain 'bills'"},
+		{query: "type: Cashback", want: "earned cashback"},
+	}
+	for _, tt := range tests {
+		got, err := explainTransactionsSearch(tt.query)
+		if err != nil {
+			t.Fatalf("explainTransactionsSearch(%q) unexpected error: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Fatalf("explainTransactionsSearch(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+	for _, query := range []string{"merchant: woo +", "amount: lots", "colour: red"} {
+		if _, err := explainTransactionsSearch(query); err == nil {
+			t.Fatalf("explainTransactionsSearch(%q) error = nil, want error", query)
+		}
+	}
+}
+
+func TestTransactionsSearchErrorsNameTheTerm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{query: "merchant: woo + amount: >x", want: `invalid search at col 17 "amount: >x": amount: expected a number after '>'`},
+		{query: "/date: 2024-13-01", want: `invalid search at col 1 "date: 2024-13-01": date: expected YYYY-MM-DD`},
+		{query: "merchant: woo amount: >60", want: `invalid search at col 1 "merchant: woo amount: >60": looks like two terms; join them with ' + ' or ' | '`},
+		{query: "colour: red", want: `invalid search at col 1 "colour: red": unknown field "colour"; use one of merchant, description, note, category, exclude-category, tag, exclude-tag, account, exclude-account, type, status, currency, has, amount, date`},
+		{query: "merchant: woo +", want: `invalid search at col 15 "+": nothing after +`},
+		{query: "(type: -ve | type: +ve", want: `invalid search at col 1 "(type: -ve | type: +ve": unbalanced parentheses`},
+		{query: "type: -ve + type: sideways", want: `invalid search at col 13 "type: sideways": type: expected +ve, -ve or cashback`},
+	}
+	for _, tt := range tests {
+		err := validateTransactionsSearchSyntax(tt.query)
+		if err == nil {
+			t.Fatalf("validateTransactionsSearchSyntax(%q) error = nil, want %q", tt.query, tt.want)
+		}
+		if err.Error() != tt.want {
+			t.Fatalf("validateTransactionsSearchSyntax(%q) = %q, want %q", tt.query, err.Error(), tt.want)
+		}
+	}
+}
+
+func TestFormatTransactionsSyncStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		fetched int
+		want    string
+	}{
+		{fetched: 0, want: "syncing..."},
+		{fetched: 1, want: "syncing... 1 fetched"},
+		{fetched: 250, want: "syncing... 250 fetched"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTransactionsSyncStatus(tt.fetched); got != tt.want {
+			t.Fatalf("formatTransactionsSyncStatus(%d) = %q, want %q", tt.fetched, got, tt.want)
+		}
+	}
+}
+
+func TestCategoryBudgetsForRange(t *testing.T) {
+	t.Parallel()
+
+	monthly := map[string]int64{"groceries": 60000}
+	now := time.Date(2026, time.October, 16, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		from, to string
+		want     int64
+		ok       bool
+	}{
+		{from: "20261001", to: "", want: 60000, ok: true},
+		{from: "20261001", to: "20261031", want: 60000, ok: true},
+		{from: "20260717", to: "20261016", want: 181355, ok: true},
+		{from: "", to: "20261016", ok: false},
+		{from: "20261020", to: "20261010", ok: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := categoryBudgetsForRange(monthly, tt.from, tt.to, now)["groceries"]
+		if ok != tt.ok || got != tt.want {
+			t.Fatalf("categoryBudgetsForRange(%q, %q) = %d, %v, want %d, %v", tt.from, tt.to, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseCategoryBudgets(t *testing.T) {
+	t.Parallel()
+
+	got := parseCategoryBudgets(map[string]string{
+		"budget.category.groceries":             "600",
+		"budget.category.restaurants-and-cafes": "150.50",
+		"budget.category.takeaway":              "",
+		"budget.category.games":                 "lots",
+	})
+	want := map[string]int64{"groceries": 60000, "restaurants-and-cafes": 15050}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseCategoryBudgets() = %v, want %v", got, want)
+	}
+}
+
+func TestRenderTransactionsChartLinesBudgets(t *testing.T) {
+	t.Parallel()
+
+	spend := []transactionsCategorySpend{
+		{category: "groceries", spendCents: 70000, percentOfSpend: 70},
+		{category: "takeaway", spendCents: 30000, percentOfSpend: 30},
+	}
+	budgets := map[string]int64{"groceries": 60000, "takeaway": 60000}
+
+	lines := renderTransactionsChartLines(spend, 100, -1, false, false, false, false, false, "", budgets, false)
+	if !strings.Contains(lines[1], "70.0%") || !strings.Contains(lines[1], "(over)") {
+		t.Fatalf("over budget row = %q, want share of spend and (over)", lines[1])
+	}
+	if strings.Contains(lines[2], "(over)") {
+		t.Fatalf("under budget row = %q, want no (over)", lines[2])
+	}
+
+	lines = renderTransactionsChartLines(spend, 100, -1, false, false, false, false, false, "", budgets, true)
+	if !strings.Contains(lines[1], "116.7%") || !strings.Contains(lines[2], "50.0%") {
+		t.Fatalf("budget percent rows = %q, %q, want 116.7%% and 50.0%%", lines[1], lines[2])
+	}
+}
+
+func TestFormatSpendChangePct(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		current  int64
+		previous int64
+		want     string
+	}{
+		{current: 11200, previous: 10000, want: "+12%"},
+		{current: 9200, previous: 10000, want: "-8%"},
+		{current: 10000, previous: 10000, want: "0%"},
+		{current: 5000, previous: 0, want: "new"},
+		{current: 0, previous: 4000, want: "-100%"},
+		{current: 0, previous: 0, want: "-"},
+	}
+	for _, tt := range tests {
+		if got := formatSpendChangePct(tt.current, tt.previous); got != tt.want {
+			t.Fatalf("formatSpendChangePct(%d, %d) = %q, want %q", tt.current, tt.previous, got, tt.want)
+		}
+	}
+}
+
+func TestRenderTransactionsChartLinesChangePct(t *testing.T) {
+	t.Parallel()
+
+	spend := []transactionsCategorySpend{
+		{category: "groceries", spendCents: 11200, previousCents: 10000, percentOfSpend: 100},
+		{category: "takeaway", previousCents: 3000},
+	}
+	lines := renderTransactionsChartLines(spend, 100, -1, false, false, true, false, false, "", nil, false)
+	if !strings.Contains(lines[0], "vs previous period") {
+		t.Fatalf("title = %q, want vs previous period", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "+12%") || !strings.HasSuffix(lines[2], "-100%") {
+		t.Fatalf("change rows = %q, %q, want +12%% and -100%%", lines[1], lines[2])
+	}
+}
+
+func TestRenderTransactionsTableLinesPinsHeader(t *testing.T) {
+	t.Parallel()
+
+	rows := make([]transactionPreviewRow, 20)
+	for i := range rows {
+		rows[i] = transactionPreviewRow{
+			createdAt:   "2026-10-01T09:00:00+10:00",
+			merchant:    strings.Repeat("M", 60),
+			amountValue: "-12.00",
+		}
+	}
+	rows[12].merchant = "CURSOR ROW"
+
+	lines := renderTransactionsTableLines(rows, 12, 20, 40, 5, 0, nil)
+	if len(lines) != 6 {
+		t.Fatalf("renderTransactionsTableLines() returned %d lines, want header + 5 rows", len(lines))
+	}
+	if !strings.Contains(lines[0], "date") || !strings.Contains(lines[0], "merchant") {
+		t.Fatalf("first line = %q, want the column header", lines[0])
+	}
+	if !strings.Contains(lines[5], "CURSOR ROW") {
+		t.Fatalf("last line = %q, want the cursor row kept in view", lines[5])
+	}
+	for _, line := range lines {
+		if w := lipgloss.Width(line); w > 40 {
+			t.Fatalf("line %q is %d wide, want at most 40", line, w)
+		}
+	}
+}
+
+func TestParseTransactionsJumpPage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw     string
+		total   int
+		want    int
+		wantErr bool
+	}{
+		{raw: "1", total: 95, want: 0},
+		{raw: " 3 ", total: 95, want: 2},
+		{raw: "10", total: 95, want: 9},
+		{raw: "99", total: 95, want: 9},
+		{raw: "4", total: 0, want: 0},
+		{raw: "0", total: 95, wantErr: true},
+		{raw: "", total: 95, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseTransactionsJumpPage(tt.raw, tt.total, 10)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseTransactionsJumpPage(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+		}
+		if err == nil && got != tt.want {
+			t.Fatalf("parseTransactionsJumpPage(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestTransactionsLastPage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		total, pageSize, want int
+	}{
+		{total: 0, pageSize: 10, want: 0},
+		{total: 10, pageSize: 10, want: 0},
+		{total: 11, pageSize: 10, want: 1},
+		{total: 95, pageSize: 10, want: 9},
+		{total: 95, pageSize: 0, want: 0},
+	}
+	for _, tt := range tests {
+		if got := transactionsLastPage(tt.total, tt.pageSize); got != tt.want {
+			t.Fatalf("transactionsLastPage(%d, %d) = %d, want %d", tt.total, tt.pageSize, got, tt.want)
+		}
+	}
+}
+
+func TestCumulativeTimeSeriesPoints(t *testing.T) {
+	t.Parallel()
+
+	points := []transactionsTimeSeriesPoint{
+		{date: "2026-03-01", spendCents: 45},
+		{date: "2026-03-02", spendCents: 80},
+		{date: "2026-03-04", spendCents: 5},
+	}
+	got := cumulativeTimeSeriesPoints(points)
+	want := []int64{45, 125, 130}
+	for i, cents := range want {
+		if got[i].spendCents != cents {
+			t.Fatalf("cumulativeTimeSeriesPoints()[%d] = %d, want %d", i, got[i].spendCents, cents)
+		}
+	}
+	if points[1].spendCents != 80 {
+		t.Fatalf("cumulativeTimeSeriesPoints() modified its input: %d", points[1].spendCents)
+	}
+}
+
+func TestResetTransactionsTimeSeriesZoom(t *testing.T) {
+	t.Parallel()
+
+	m := model{transactionsTimeSeries: make([]transactionsTimeSeriesPoint, 40)}
+	m.transactionsTimeSeriesSelection = 30
+	for i := 0; i < 6; i++ {
+		m.zoomTransactionsTimeSeries(true)
+	}
+	m.panTransactionsTimeSeries(-1)
+	if m.transactionsTimeSeriesZoomWindow >= 40 {
+		t.Fatalf("zoom window = %d after zooming in, want < 40", m.transactionsTimeSeriesZoomWindow)
+	}
+	if !m.resetTransactionsTimeSeriesZoom() {
+		t.Fatal("resetTransactionsTimeSeriesZoom() = false, want true when zoomed")
+	}
+	if m.transactionsTimeSeriesZoomStart != 0 || m.transactionsTimeSeriesZoomWindow != 40 {
+		t.Fatalf("zoom = start %d window %d, want start 0 window 40", m.transactionsTimeSeriesZoomStart, m.transactionsTimeSeriesZoomWindow)
+	}
+	if m.transactionsTimeSeriesSelection != 30 {
+		t.Fatalf("selection = %d, want 30 kept", m.transactionsTimeSeriesSelection)
+	}
+	if m.resetTransactionsTimeSeriesZoom() {
+		t.Fatal("resetTransactionsTimeSeriesZoom() = true at full view, want false")
+	}
+}
+
+func TestParentCategoryChartDropsBudgets(t *testing.T) {
+	t.Parallel()
+
+	m := model{
+		transactionsCategoryBudgets: map[string]int64{"groceries": 60000},
+		transactionsFromDate:        "20260301",
+		transactionsToDate:          "20260331",
+	}
+	if got := m.chartCategoryBudgets(); got["groceries"] != 60000 {
+		t.Fatalf("chartCategoryBudgets() = %v, want the groceries budget", got)
+	}
+	m.transactionsChartByParent = true
+	if got := m.chartCategoryBudgets(); got != nil {
+		t.Fatalf("chartCategoryBudgets() by parent = %v, want nil", got)
+	}
+	spend := []transactionsCategorySpend{{category: "good-life", spendCents: 1200, percentOfSpend: 100}}
+	lines := renderTransactionsChartLines(spend, 80, -1, true, false, false, false, true, "", nil, false)
+	if !strings.Contains(lines[0], "spend by parent category") {
+		t.Fatalf("chart title = %q, want the parent category title", lines[0])
+	}
+}
+
+func TestTransactionsChartParentDrill(t *testing.T) {
+	t.Parallel()
+
+	m := model{
+		transactionsCategoryBudgets: map[string]int64{"restaurants-and-cafes": 40000},
+		transactionsFromDate:        "20260301",
+		transactionsToDate:          "20260331",
+		transactionsChartByParent:   true,
+	}
+	if !m.transactionsChartShowsParents() {
+		t.Fatalf("transactionsChartShowsParents() = false, want true before drilling in")
+	}
+	m.transactionsChartParentDrill = "good-life"
+	if m.transactionsChartShowsParents() {
+		t.Fatalf("transactionsChartShowsParents() = true, want false inside a parent")
+	}
+	if got := m.chartCategoryBudgets(); got["restaurants-and-cafes"] != 40000 {
+		t.Fatalf("chartCategoryBudgets() inside a parent = %v, want the child budgets", got)
+	}
+	spend := []transactionsCategorySpend{{category: "restaurants-and-cafes", spendCents: 1200, percentOfSpend: 100}}
+	lines := renderTransactionsChartLines(spend, 80, -1, true, false, false, false, false, "good-life", nil, false)
+	if !strings.Contains(lines[0], "spend by category in good-life") {
+		t.Fatalf("chart title = %q, want the drilled-in parent title", lines[0])
+	}
+}
+
+func TestParseTransactionsDefaultRange(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		raw    string
+		want   transactionsDefaultRange
+		wantOK bool
+	}{
+		{raw: "0", want: transactionsDefaultRange{quickIdx: 0}, wantOK: true},
+		{raw: " 5 ", want: transactionsDefaultRange{quickIdx: 5}, wantOK: true},
+		{raw: "6", want: transactionsDefaultRange{quickIdx: transactionsLastPayCycleIdx}, wantOK: true},
+		{raw: "7"},
+		{raw: "-1"},
+		{raw: "6m"},
+		{raw: "custom:20240101..20240331", want: transactionsDefaultRange{quickIdx: -1, fromDate: "20240101", toDate: "20240331"}, wantOK: true},
+		{raw: "custom:20240101..", want: transactionsDefaultRange{quickIdx: -1, fromDate: "20240101"}, wantOK: true},
+		{raw: "custom:20240331..20240101"},
+		{raw: "custom:20241301..20241302"},
+		{raw: "custom:.."},
+		{raw: "custom:20240101"},
+	} {
+		got, ok := parseTransactionsDefaultRange(tc.raw)
+		if ok != tc.wantOK || got != tc.want {
+			t.Fatalf("parseTransactionsDefaultRange(%q) = %+v, %v, want %+v, %v", tc.raw, got, ok, tc.want, tc.wantOK)
+		}
+		if ok {
+			if back, _ := parseTransactionsDefaultRange(formatTransactionsDefaultRange(got)); back != got {
+				t.Fatalf("round trip of %q = %+v, want %+v", tc.raw, back, got)
+			}
+		}
+	}
+}
+
+func TestTransactionsDefaultQuickRange(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		raw  string
+		want int
+	}{
+		{raw: "", want: transactionsDefaultQuickIdx},
+		{raw: "this week", want: 1},
+		{raw: " 6M ", want: 3},
+		{raw: "all", want: 5},
+		{raw: "fortnight", want: transactionsDefaultQuickIdx},
+	} {
+		if got := defaultRangeIndexFromValue(tc.raw); got != tc.want {
+			t.Fatalf("defaultRangeIndexFromValue(%q) = %d, want %d", tc.raw, got, tc.want)
+		}
+	}
+
+	now := time.Date(2026, 3, 18, 15, 4, 0, 0, time.Local)
+	from, to := transactionsQuickRangeDigits(3, now, payCycleSettings{})
+	if from != "20250918" || to != "20260318" {
+		t.Fatalf("transactionsQuickRangeDigits(6m) = %q, %q, want 20250918, 20260318", from, to)
+	}
+	if from, to := transactionsQuickRangeDigits(5, now, payCycleSettings{}); from != "" || to != "" {
+		t.Fatalf("transactionsQuickRangeDigits(all) = %q, %q, want empty", from, to)
+	}
+}
+
+func TestLastPayCycleQuickRange(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 18, 0, 0, 0, 0, time.Local)
+	for _, tc := range []struct {
+		cycle    payCycleSettings
+		wantFrom string
+		wantTo   string
+	}{
+		{cycle: payCycleSettings{nextDate: "2026-03-26", frequency: "fortnightly"}, wantFrom: "20260226", wantTo: "20260311"},
+		{cycle: payCycleSettings{nextDate: "2026-04-01", frequency: "Monthly"}, wantFrom: "20260201", wantTo: "20260228"},
+		{cycle: payCycleSettings{frequency: "weekly"}, wantFrom: "20260218", wantTo: "20260318"},
+		{cycle: payCycleSettings{nextDate: "2026-03-26"}, wantFrom: "20260218", wantTo: "20260318"},
+	} {
+		from, to := transactionsQuickRangeDigits(transactionsLastPayCycleIdx, now, tc.cycle)
+		if from != tc.wantFrom || to != tc.wantTo {
+			t.Fatalf("transactionsQuickRangeDigits(last pay cycle, %+v) = %q, %q, want %q, %q", tc.cycle, from, to, tc.wantFrom, tc.wantTo)
+		}
+	}
+	if got := transactionsQuickRanges()[transactionsLastPayCycleIdx].label; got != "last pay cycle" {
+		t.Fatalf("quick range %d = %q, want last pay cycle", transactionsLastPayCycleIdx, got)
+	}
+}
+
+func TestTransactionsDetailsCompact(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		toggled bool
+		height  int
+		want    bool
+	}{
+		{toggled: false, height: 8, want: true},
+		{toggled: true, height: 8, want: false},
+		{toggled: false, height: transactionsDetailsFullRows, want: false},
+		{toggled: true, height: transactionsDetailsFullRows, want: true},
+	} {
+		if got := transactionsDetailsCompact(tc.toggled, tc.height); got != tc.want {
+			t.Fatalf("transactionsDetailsCompact(%v, %d) = %v, want %v", tc.toggled, tc.height, got, tc.want)
+		}
+	}
+
+	d := transactionDetailFields{amount: "-12.50", createdAt: "2026-03-04T12:00:00Z", merchant: "Cafe", category: "restaurants-and-cafes"}
+	style := lipgloss.NewStyle()
+	compact := renderTransactionDetailFields(d, true, 40, style, style)
+	if len(compact) != 4 || !strings.Contains(compact[0], "-12.50") || !strings.Contains(compact[1], "2026-03-04") {
+		t.Fatalf("compact details = %q, want amount, date, merchant and category", compact)
+	}
+	if full := renderTransactionDetailFields(d, false, 40, style, style); len(full) != 12 || !strings.Contains(full[11], "none") {
+		t.Fatalf("full details = %q, want 12 lines ending with no receipt", full)
+	}
+	d.localNote = "work lunch"
+	if compact := renderTransactionDetailFields(d, true, 40, style, style); len(compact) != 5 || !strings.Contains(compact[4], "work lunch") {
+		t.Fatalf("compact details = %q, want your note last", compact)
+	}
+}
+
+func TestTransactionsFilterWhereChartBar(t *testing.T) {
+	t.Parallel()
+
+	where, args, err := transactionsFilterWhere(TransactionsFilter{IncludeInternal: true, ChartBar: " Groceries "})
+	if err != nil {
+		t.Fatalf("transactionsFilterWhere(chart bar) unexpected error: %v", err)
+	}
+	want := []string{"t.is_active = 1", "LOWER(COALESCE(NULLIF(TRIM(t.category_id), ''), 'uncategorized')) = ?"}
+	if !reflect.DeepEqual(where, want) || !reflect.DeepEqual(args, []any{"groceries"}) {
+		t.Fatalf("transactionsFilterWhere(chart bar) = %q %v, want %q [groceries]", where, args, want)
+	}
+}
+
+func TestExportFileSlug(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct{ in, want string }{
+		{in: "restaurants-and-cafes", want: "restaurants-and-cafes"},
+		{in: "Woolworths / Metro", want: "woolworths-metro"},
+		{in: "  ", want: "selection"},
+		{in: "7-Eleven!", want: "7-eleven"},
+	} {
+		if got := exportFileSlug(tc.in); got != tc.want {
+			t.Fatalf("exportFileSlug(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestRoundUpsByMonthSkipsGrouping(t *testing.T) {
+	t.Parallel()
+
+	months := []transactionsTimeSeriesPoint{
+		{date: "2026-01-01", spendCents: 50, count: 2},
+		{date: "2026-02-01", spendCents: 30, count: 1},
+	}
+	m := model{transactionsTimeSeriesRoundUps: true, transactionsRoundUpsMonthly: true, transactionsTimeSeriesGrouping: timeSeriesGroupDay}
+	if got := m.groupTransactionsTimeSeries(months); !reflect.DeepEqual(got, months) {
+		t.Fatalf("groupTransactionsTimeSeries(monthly round-ups) = %+v, want the months unchanged", got)
+	}
+	if got := roundUpsTotalCents(months); got != 80 {
+		t.Fatalf("roundUpsTotalCents() = %d, want 80", got)
+	}
+	lines := renderTransactionsTimeSeriesLines(months, 60, "", "", 1, true, true)
+	if !strings.Contains(lines[0], "by month") || !strings.Contains(lines[len(lines)-1], "$0.80") {
+		t.Fatalf("monthly round-ups render title %q and summary %q, want by month and $0.80", lines[0], lines[len(lines)-1])
+	}
+}
+
+func TestAppendTransactionsSearchClausesCashback(t *testing.T) {
+	t.Parallel()
+
+	where := []string{}
+	args := []any{}
+	if err := appendTransactionsSearchClauses("type: Cashback + amount: >10", &where, &args); err != nil {
+		t.Fatalf("appendTransactionsSearchClauses(type: Cashback) unexpected error: %v", err)
+	}
+	want := []string{transactionsCashbackSQL, "ABS(t.amount_value_in_base_units) > ?"}
+	if !reflect.DeepEqual(where, want) || !reflect.DeepEqual(args, []any{int64(1000)}) {
+		t.Fatalf("appendTransactionsSearchClauses(type: Cashback) = %q %v, want %q [1000]", where, args, want)
+	}
+}
+
+func TestCashbackTotalSQL(t *testing.T) {
+	t.Parallel()
+
+	q := cashbackTotalSQL("t.is_active = 1 AND date(t.created_at) >= date(?)")
+	for _, want := range []string{
+		"SUM(t.cashback_amount_value_in_base_units)",
+		"WHERE t.is_active = 1 AND date(t.created_at) >= date(?) AND " + transactionsCashbackSQL,
+	} {
+		if !strings.Contains(q, want) {
+			t.Fatalf("cashbackTotalSQL() = %q, want it to contain %q", q, want)
+		}
+	}
+}
+
+func TestFormatNetSummary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		summary transactionsNetSummary
+		want    string
+	}{
+		{transactionsNetSummary{incomeCents: 250000, expenseCents: 123450}, "income $2500  |  expenses $1234.50  |  net +$1265.50"},
+		{transactionsNetSummary{incomeCents: 1000, expenseCents: 4000}, "income $10  |  expenses $40  |  net -$30"},
+		{transactionsNetSummary{}, "income $0  |  expenses $0  |  net +$0"},
+	}
+	for _, tt := range tests {
+		if got := formatNetSummary(tt.summary); got != tt.want {
+			t.Fatalf("formatNetSummary(%+v) = %q, want %q", tt.summary, got, tt.want)
+		}
+	}
+	if q := netSummarySQL("t.is_active = 1"); !strings.Contains(q, "WHERE t.is_active = 1") {
+		t.Fatalf("netSummarySQL() = %q, want the filter WHERE clause", q)
+	}
+}
+
+func TestMoveCalendarCursor(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 1, 31, 0, 0, 0, 0, time.Local)
+	for _, tc := range []struct {
+		key    string
+		want   string
+		wantOK bool
+	}{
+		{key: "left", want: "2026-01-30", wantOK: true},
+		{key: "down", want: "2026-02-07", wantOK: true},
+		{key: "shift+right", want: "2026-02-28", wantOK: true},
+		{key: "shift+up", want: "2025-01-31", wantOK: true},
+		{key: "enter", want: "2026-01-31"},
+	} {
+		got, ok := moveCalendarCursor(start, tc.key)
+		if ok != tc.wantOK || got.Format("2006-01-02") != tc.want {
+			t.Fatalf("moveCalendarCursor(%q) = %s, %v, want %s, %v", tc.key, got.Format("2006-01-02"), ok, tc.want, tc.wantOK)
+		}
+	}
+	if got := calendarMonthOf(start); got.Day() != 1 || got.Month() != time.January {
+		t.Fatalf("calendarMonthOf(%s) = %s, want 2026-01-01", start.Format("2006-01-02"), got.Format("2006-01-02"))
+	}
+}
+
+func TestFormatTransactionSummary(t *testing.T) {
+	t.Parallel()
+
+	got := formatTransactionSummary(transactionDetailFields{
+		createdAt: "2026-03-04",
+		merchant:  "Woolworths",
+		amount:    "-42.10",
+		category:  "groceries",
+	})
+	want := "date: 2026-03-04\nmerchant: Woolworths\namount: -42.10\ncategory: groceries\nraw text: -"
+	if got != want {
+		t.Fatalf("formatTransactionSummary() = %q, want %q", got, want)
+	}
+}
+
+func TestOpenURLCommand(t *testing.T) {
+	t.Parallel()
+
+	const url = "up://transaction/abc"
+	for _, tc := range []struct {
+		goos string
+		want []string
+	}{
+		{goos: "darwin", want: []string{"open", url}},
+		{goos: "linux", want: []string{"xdg-open", url}},
+		{goos: "windows", want: []string{"rundll32", "url.dll,FileProtocolHandler", url}},
+	} {
+		name, args := openURLCommand(tc.goos, url)
+		if got := append([]string{name}, args...); !reflect.DeepEqual(got, tc.want) {
+			t.Fatalf("openURLCommand(%q) = %v, want %v", tc.goos, got, tc.want)
+		}
+	}
+}
+
+func TestAppendTransactionsSearchClausesNote(t *testing.T) {
+	t.Parallel()
+
+	where := []string{}
+	args := []any{}
+	if err := appendTransactionsSearchClauses("note: Refund", &where, &args); err != nil {
+		t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", "note: Refund", err)
+	}
+	if want := []string{transactionsNoteSQL}; !reflect.DeepEqual(where, want) {
+		t.Fatalf("appendTransactionsSearchClauses(%q) where = %v, want %v", "note: Refund", where, want)
+	}
+	if want := []any{"%refund%", "%refund%"}; !reflect.DeepEqual(args, want) {
+		t.Fatalf("appendTransactionsSearchClauses(%q) args = %v, want %v", "note: Refund", args, want)
+	}
+}
+
+func TestAppendTransactionsSearchClausesAttachment(t *testing.T) {
+	t.Parallel()
+
+	for _, query := range []string{"has: attachment", "has: Receipt"} {
+		where := []string{}
+		args := []any{}
+		if err := appendTransactionsSearchClauses(query, &where, &args); err != nil {
+			t.Fatalf("appendTransactionsSearchClauses(%q) unexpected error: %v", query, err)
+		}
+		if want := []string{"t.attachment_id IS NOT NULL"}; !reflect.DeepEqual(where, want) || len(args) != 0 {
+			t.Fatalf("appendTransactionsSearchClauses(%q) = %v %v, want %v and no args", query, where, args, want)
+		}
+	}
+	where := []string{}
+	args := []any{}
+	if err := appendTransactionsSearchClauses("has: photo", &where, &args); err == nil {
+		t.Fatalf("appendTransactionsSearchClauses(%q) = nil error, want one", "has: photo")
+	}
+}
+
+func TestMerchantAliasFor(t *testing.T) {
+	t.Parallel()
+
+	aliases := []merchantAlias{
+		{pattern: "SQ *", name: "Square"},
+		{pattern: "SQ *COFFEE*", name: "Coffee"},
+		{pattern: "SQ *COFFEE CLUB", name: "Coffee Club"},
+	}
+	for _, tc := range []struct {
+		merchant string
+		want     string
+	}{
+		{merchant: "SQ *COFFEE CLUB", want: "Coffee Club"},
+		{merchant: "sq  *coffee   club", want: "Coffee Club"},
+		{merchant: "SQ *COFFEE CART", want: "Coffee"},
+		{merchant: "SQ *BAKERY", want: "Square"},
+		{merchant: "Woolworths", want: "Woolworths"},
+		{merchant: "", want: ""},
+	} {
+		if got := displayMerchant(aliases, tc.merchant); got != tc.want {
+			t.Fatalf("displayMerchant(%q) = %q, want %q", tc.merchant, got, tc.want)
+		}
+	}
+}
+
+func TestParseMerchantAliasInput(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		input       string
+		wantPattern string
+		wantName    string
+	}{
+		{input: " Coffee Club ", wantPattern: "SQ *COFFEE CLUB", wantName: "Coffee Club"},
+		{input: "sq *coffee* = Coffee", wantPattern: "SQ *COFFEE*", wantName: "Coffee"},
+		{input: "", wantPattern: "SQ *COFFEE CLUB", wantName: ""},
+	} {
+		pattern, name := parseMerchantAliasInput("Sq *Coffee  Club", tc.input)
+		if pattern != tc.wantPattern || name != tc.wantName {
+			t.Fatalf("parseMerchantAliasInput(%q) = %q, %q, want %q, %q", tc.input, pattern, name, tc.wantPattern, tc.wantName)
+		}
+	}
+}
+
+func TestTransactionsSearchHistory(t *testing.T) {
+	t.Parallel()
+
+	history := pushTransactionsSearchHistory(nil, " merchant: WOOL ")
+	history = pushTransactionsSearchHistory(history, "merchant: WOOL")
+	history = pushTransactionsSearchHistory(history, "")
+	history = pushTransactionsSearchHistory(history, "amount: >60")
+	history = pushTransactionsSearchHistory(history, "merchant: WOOL")
+	if want := []string{"merchant: WOOL", "amount: >60", "merchant: WOOL"}; !reflect.DeepEqual(history, want) {
+		t.Fatalf("pushTransactionsSearchHistory() = %q, want %q", history, want)
+	}
+
+	for i := 0; i < transactionsSearchHistoryMax+5; i++ {
+		history = pushTransactionsSearchHistory(history, fmt.Sprintf("tag: %d", i))
+	}
+	if len(history) != transactionsSearchHistoryMax || history[len(history)-1] != fmt.Sprintf("tag: %d", transactionsSearchHistoryMax+4) {
+		t.Fatalf("pushTransactionsSearchHistory() kept %d entries ending %q, want the newest %d", len(history), history[len(history)-1], transactionsSearchHistoryMax)
+	}
+
+	if got := parseTransactionsSearchHistory(`["a", " ", "b"]`); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Fatalf("parseTransactionsSearchHistory() = %q, want [a b]", got)
+	}
+	if got := parseTransactionsSearchHistory("not json"); len(got) != 0 {
+		t.Fatalf("parseTransactionsSearchHistory(not json) = %q, want none", got)
+	}
+}
+
+func TestStepTransactionsSearchRecall(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		recall, delta, want int
+	}{
+		{recall: 0, delta: 1, want: 1},
+		{recall: 3, delta: 1, want: 3},
+		{recall: 2, delta: -1, want: 1},
+		{recall: 0, delta: -1, want: 0},
+	} {
+		if got := stepTransactionsSearchRecall(tc.recall, 3, tc.delta); got != tc.want {
+			t.Fatalf("stepTransactionsSearchRecall(%d, 3, %d) = %d, want %d", tc.recall, tc.delta, got, tc.want)
+		}
+	}
+}
+
+func TestTransactionsSearchRecallAfterOpeningSearch(t *testing.T) {
+	t.Parallel()
+
+	m := New(nil).(model)
+	m.screen = screenTransactions
+	m.transactionsViewMode = transactionsViewModeTable
+	m.transactionsSearchHistory = []string{"/coffee", "/merchant: woolworths"}
+
+	press := func(m model, key tea.KeyMsg) model {
+		next, _ := m.Update(key)
+		return next.(model)
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if got := m.transactionsSearchInput.Value(); got != "/" {
+		t.Fatalf("search input after / = %q, want the / prefix", got)
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyUp})
+	if got := m.transactionsSearchInput.Value(); got != "/merchant: woolworths" {
+		t.Fatalf("search input after / then up = %q, want the newest saved query", got)
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyUp})
+	if got := m.transactionsSearchInput.Value(); got != "/coffee" {
+		t.Fatalf("search input after a second up = %q, want the older query", got)
+	}
+	m = press(m, tea.KeyMsg{Type: tea.KeyDown})
+	m = press(m, tea.KeyMsg{Type: tea.KeyDown})
+	if got := m.transactionsSearchInput.Value(); got != "/" {
+		t.Fatalf("search input after stepping back down = %q, want the / prefix", got)
+	}
+}
+
+func TestRenderTransactionsSkeletonLines(t *testing.T) {
+	t.Parallel()
+
+	lines := renderTransactionsSkeletonLines(20, 60, 4)
+	if len(lines) != 5 {
+		t.Fatalf("renderTransactionsSkeletonLines() returned %d lines, want header + 4 rows", len(lines))
+	}
+	if !strings.Contains(lines[0], "merchant") {
+		t.Fatalf("first line = %q, want the column header", lines[0])
+	}
+	for _, line := range lines[1:] {
+		if !strings.Contains(line, "··········") || strings.Contains(line, "no transactions found") {
+			t.Fatalf("skeleton row = %q, want dotted placeholders", line)
+		}
+	}
+}
+
+func TestCategoryAliasesRelabelChartButKeepID(t *testing.T) {
+	t.Parallel()
+
+	m := model{transactionsCategoryAliases: map[string]string{"groceries": "Food shop"}}
+	if got := displayCategory(m.transactionsCategoryAliases, "groceries"); got != "Food shop" {
+		t.Fatalf("displayCategory(%q) = %q, want %q", "groceries", got, "Food shop")
+	}
+	if got := displayCategory(m.transactionsCategoryAliases, "takeaway"); got != "takeaway" {
+		t.Fatalf("displayCategory(%q) = %q, want the ID", "takeaway", got)
+	}
+
+	spend := m.aliasedChartSpend([]transactionsCategorySpend{{category: "groceries", spendCents: 70000, percentOfSpend: 100}})
+	if spend[0].category != "groceries" {
+		t.Fatalf("aliasedChartSpend() category = %q, want the ID kept", spend[0].category)
+	}
+	budgets := map[string]int64{"groceries": 60000}
+	lines := renderTransactionsChartLines(spend, 100, -1, false, false, false, false, false, "", budgets, false)
+	if !strings.Contains(lines[1], "Food shop") || !strings.Contains(lines[1], "(over)") {
+		t.Fatalf("aliased chart row = %q, want the alias and its budget marker", lines[1])
+	}
+
+	m.transactionsChartByMerchant = true
+	merchants := m.aliasedChartSpend([]transactionsCategorySpend{{category: "groceries"}})
+	if merchants[0].label != "" {
+		t.Fatalf("aliasedChartSpend() by merchant label = %q, want category aliases ignored", merchants[0].label)
+	}
+}
+
+func TestSearchByCategoryIDAfterAliasing(t *testing.T) {
+	t.Parallel()
+
+	// Aliases are display only, so the search clause still binds the stored ID.
+	where := []string{}
+	args := []any{}
+	if err := appendTransactionsSearchClauses("category: groceries", &where, &args); err != nil {
+		t.Fatalf("appendTransactionsSearchClauses() unexpected error: %v", err)
+	}
+	if len(where) != 1 || !strings.Contains(where[0], "t.category_id") {
+		t.Fatalf("where = %v, want one clause on t.category_id", where)
+	}
+	if want := []any{"%groceries%"}; !reflect.DeepEqual(args, want) {
+		t.Fatalf("args = %v, want %v", args, want)
+	}
+}
+
+func TestTransactionsAggregatesFor(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		viewMode int
+		want     transactionsAggregates
+	}{
+		{name: "table", viewMode: transactionsViewModeTable, want: transactionsAggregates{dailyCounts: true, netSummary: true}},
+		{name: "chart", viewMode: transactionsViewModeChart, want: transactionsAggregates{spend: true, netSummary: true}},
+		{name: "time series", viewMode: transactionsViewModeTimeSeries, want: transactionsAggregates{spend: true, timeSeries: true}},
+		{name: "weekly", viewMode: transactionsViewModeWeekly, want: transactionsAggregates{weekly: true}},
+		{name: "heatmap", viewMode: transactionsViewModeHeatmap, want: transactionsAggregates{heatmap: true}},
+	}
+	for _, tt := range tests {
+		if got := transactionsAggregatesFor(tt.viewMode); got != tt.want {
+			t.Fatalf("transactionsAggregatesFor(%s) = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadedTimeSeriesCategoryKeptWithoutSpend(t *testing.T) {
+	t.Parallel()
+
+	// A table load skips the spend aggregate, so it must not clear the
+	// selected time series category for lack of spend rows.
+	m := model{transactionsTimeSeriesCategory: "groceries", transactionsViewMode: transactionsViewModeTable}
+	next, _ := m.Update(loadTransactionsPreviewMsg{page: -1, aggregates: transactionsAggregatesFor(transactionsViewModeTable)})
+	if got := next.(model).transactionsTimeSeriesCategory; got != "groceries" {
+		t.Fatalf("transactionsTimeSeriesCategory = %q, want it kept", got)
+	}
+}
+
+func TestIsLargeTransaction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		amount    int64
+		threshold int64
+		want      bool
+	}{
+		{name: "debit over threshold", amount: -50000, threshold: 20000, want: true},
+		{name: "debit at threshold", amount: -20000, threshold: 20000, want: true},
+		{name: "debit under threshold", amount: -19999, threshold: 20000, want: false},
+		{name: "credit over threshold", amount: 50000, threshold: 20000, want: false},
+		{name: "alert disabled", amount: -50000, threshold: 0, want: false},
+	}
+	for _, tt := range tests {
+		if got := isLargeTransaction(tt.amount, tt.threshold); got != tt.want {
+			t.Fatalf("isLargeTransaction(%d, %d) [%s] = %v, want %v", tt.amount, tt.threshold, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseLargeThresholdCents(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw     string
+		want    int64
+		wantErr bool
+	}{
+		{raw: "", want: 0},
+		{raw: "  ", want: 0},
+		{raw: "200", want: 20000},
+		{raw: " 99.995 ", want: 10000},
+		{raw: "12.34", want: 1234},
+		{raw: "-5", wantErr: true},
+		{raw: "lots", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseLargeThresholdCents(tt.raw)
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("parseLargeThresholdCents(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Fatalf("parseLargeThresholdCents(%q) = %d, want %d", tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestBulkTagWhileOfflineDoesNotStart(t *testing.T) {
+	t.Parallel()
+
+	m := New(nil).(model)
+	m.offline = true
+	m.screen = screenTransactions
+	m.transactionsTagActive = true
+	m.transactionsTagInput.SetValue("coffee")
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	got := next.(model)
+	if got.transactionsTagActive {
+		t.Fatal("tag input still open after enter, want it closed")
+	}
+	if got.transactionsTagStatus != "" {
+		t.Fatalf("transactionsTagStatus = %q, want no bulk tag started while offline", got.transactionsTagStatus)
+	}
+	if got.commandText != "offline: /offline to sync again" {
+		t.Fatalf("commandText = %q, want the offline message", got.commandText)
+	}
+}
+
+func TestLargeTransactionsCountQuery(t *testing.T) {
+	t.Parallel()
+
+	args := []any{"2024-01-01"}
+	q, got := largeTransactionsCountQuery("t.is_active = 1 AND t.created_at >= ?", args, 20000)
+	if strings.Contains(q, "ABS(") {
+		t.Fatalf("query = %q, want credits excluded rather than compared by magnitude", q)
+	}
+	if !strings.HasSuffix(q, "AND t.amount_value_in_base_units <= ?") {
+		t.Fatalf("query = %q, want a debit bound on the amount", q)
+	}
+	if want := []any{"2024-01-01", int64(-20000)}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("args = %v, want %v", got, want)
+	}
+	if len(args) != 1 {
+		t.Fatalf("largeTransactionsCountQuery() modified the caller's args: %v", args)
+	}
+}
+
+func TestTransactionRecordResourceJSON(t *testing.T) {
+	t.Parallel()
+
+	str := func(v string) *string { return &v }
+	cents := func(v int64) *int64 { return &v }
+	rcd := storage.TransactionRecord{
+		ID:                            "tx-1",
+		ResourceType:                  "transactions",
+		Status:                        "SETTLED",
+		Description:                   "Coffee",
+		AmountCurrencyCode:            "AUD",
+		AmountValue:                   "-4.50",
+		AmountValueInBaseUnits:        -450,
+		RoundUpAmountCurrencyCode:     str("AUD"),
+		RoundUpAmountValue:            str("-0.50"),
+		RoundUpAmountValueInBaseUnits: cents(-50),
+		CreatedAt:                     "2024-05-01T08:00:00+10:00",
+		AccountID:                     "acc-1",
+		AccountResourceType:           str("accounts"),
+	}
+	raw, err := json.Marshal(transactionRecordResource(rcd))
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+	var got struct {
+		Attributes    map[string]any            `json:"attributes"`
+		Relationships map[string]map[string]any `json:"relationships"`
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("json.Unmarshal() unexpected error: %v", err)
+	}
+
+	wantAmount := map[string]any{"currencyCode": "AUD", "value": "-4.50", "valueInBaseUnits": float64(-450)}
+	if amount := got.Attributes["amount"]; !reflect.DeepEqual(amount, wantAmount) {
+		t.Fatalf("amount = %v, want %v", amount, wantAmount)
+	}
+	wantRoundUp := map[string]any{
+		"amount":       map[string]any{"currencyCode": "AUD", "value": "-0.50", "valueInBaseUnits": float64(-50)},
+		"boostPortion": nil,
+	}
+	if roundUp := got.Attributes["roundUp"]; !reflect.DeepEqual(roundUp, wantRoundUp) {
+		t.Fatalf("roundUp = %v, want %v", roundUp, wantRoundUp)
+	}
+	for _, key := range []string{"foreignAmount", "holdInfo", "cashback", "note"} {
+		if value, ok := got.Attributes[key]; !ok || value != nil {
+			t.Fatalf("attributes[%q] = %v (present %v), want null", key, value, ok)
+		}
+	}
+
+	wantAccount := map[string]any{"type": "accounts", "id": "acc-1"}
+	if data := got.Relationships["account"]["data"]; !reflect.DeepEqual(data, wantAccount) {
+		t.Fatalf("account data = %v, want %v", data, wantAccount)
+	}
+	for _, key := range []string{"transferAccount", "category", "parentCategory", "attachment"} {
+		rel, ok := got.Relationships[key]
+		if !ok {
+			t.Fatalf("relationships[%q] missing, want a null data entry", key)
+		}
+		if data, ok := rel["data"]; !ok || data != nil {
+			t.Fatalf("relationships[%q].data = %v (present %v), want null", key, data, ok)
+		}
+		if _, ok := rel["links"]; ok {
+			t.Fatalf("relationships[%q] has links, want them omitted when none are stored", key)
+		}
+	}
+}
+
+func TestTransactionsRawOverlayCloseKeys(t *testing.T) {
+	t.Parallel()
+
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune{'q'}},
+		{Type: tea.KeyEsc},
+	} {
+		m := model{screen: screenTransactions, transactionsRawOpen: true, transactionsRawTxID: "tx-1"}
+		next, cmd := m.Update(key)
+		got := next.(model)
+		if got.transactionsRawOpen || got.quitting {
+			t.Fatalf("Update(%q) raw open = %v, quitting = %v, want the overlay closed without quitting", key.String(), got.transactionsRawOpen, got.quitting)
+		}
+		if cmd != nil {
+			t.Fatalf("Update(%q) returned a command, want none", key.String())
+		}
+	}
+}
+
+func TestChartFooterHelpTextFitsOneLine(t *testing.T) {
+	t.Parallel()
+
+	for mode, name := range transactionsViewModeNames {
+		footer := chartFooterHelpText(mode)
+		if width := lipgloss.Width(footer); width > 80 {
+			t.Fatalf("chartFooterHelpText(%s) is %d columns wide, want at most 80: %q", name, width, footer)
+		}
+		if !strings.HasSuffix(footer, "? more keys") {
+			t.Fatalf("chartFooterHelpText(%s) = %q, want it to point at the ? overlay", name, footer)
+		}
+	}
+}
+
+func TestTransactionsKeyBindingsListEveryKey(t *testing.T) {
+	t.Parallel()
+
+	bindings := transactionsKeyBindings(transactionsViewModeTable)
+	for _, want := range []string{"/ search", "s sort", "J raw json", "H hours", "a merchant alias"} {
+		if !slices.Contains(bindings, want) {
+			t.Fatalf("transactionsKeyBindings(table) = %v, want it to include %q", bindings, want)
+		}
+	}
+	if slices.Contains(bindings, "? more keys") {
+		t.Fatalf("transactionsKeyBindings(table) = %v, want the ? hint left out", bindings)
+	}
+
+	m := model{screen: screenTransactions, transactionsViewMode: transactionsViewModeChart, showHelpOverlay: true, helpOverlayViewKeys: true}
+	overlay := m.renderHelpOverlay(100)
+	if !strings.Contains(overlay, "Transactions chart keys") || !strings.Contains(overlay, "v vs last period") {
+		t.Fatalf("renderHelpOverlay() = %q, want the chart keys", overlay)
+	}
+}
@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type transactionsWeeklySpend struct {
+	week       string
+	spendCents int64
+}
+
+// queryWeeklySpend totals debits per week, oldest first. Weeks use SQLite's
+// Monday-start %W numbering on the wall-clock date, labelled like 2024-W07.
+func queryWeeklySpend(ctx context.Context, db *sql.DB, whereSQL string, args []any) ([]transactionsWeeklySpend, error) {
+	q := fmt.Sprintf(
+		`SELECT
+			strftime('%%Y-W%%W', substr(t.created_at, 1, 19)) AS week,
+			SUM(-t.amount_value_in_base_units)
+		 FROM transactions t
+		 WHERE %s
+		   AND t.amount_value_in_base_units < 0
+		 GROUP BY week
+		 ORDER BY week`,
+		whereSQL,
+	)
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]transactionsWeeklySpend, 0, 16)
+	for rows.Next() {
+		var week sql.NullString
+		var spend int64
+		if err := rows.Scan(&week, &spend); err != nil {
+			return nil, err
+		}
+		if !week.Valid {
+			continue
+		}
+		out = append(out, transactionsWeeklySpend{week: week.String, spendCents: spend})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (m model) transactionsWeeklyVisibleRows() int {
+	return 15
+}
+
+// transactionsWeeklyWindow returns the slice bounds of the weeks on screen.
+// scroll counts weeks hidden after the window, so 0 pins the latest week.
+func transactionsWeeklyWindow(total, scroll, visible int) (int, int) {
+	visible = max(1, visible)
+	if total <= visible {
+		return 0, total
+	}
+	scroll = min(max(0, scroll), total-visible)
+	end := total - scroll
+	return end - visible, end
+}
+
+func (m *model) scrollTransactionsWeekly(delta int) {
+	maxScroll := max(0, len(m.transactionsWeeklySpend)-m.transactionsWeeklyVisibleRows())
+	m.transactionsWeeklyScroll = min(maxScroll, max(0, m.transactionsWeeklyScroll+delta))
+}
+
+func averageWeeklySpendCents(weeks []transactionsWeeklySpend) int64 {
+	if len(weeks) == 0 {
+		return 0
+	}
+	var total int64
+	for _, w := range weeks {
+		total += w.spendCents
+	}
+	return int64(math.Round(float64(total) / float64(len(weeks))))
+}
+
+func renderTransactionsWeeklyLines(weeks []transactionsWeeklySpend, contentWidth int) []string {
+	out := []string{
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#87CEEB")).Bold(true).Render("spend by week"),
+	}
+	if len(weeks) == 0 {
+		return append(out, lipgloss.NewStyle().Foreground(lipgloss.Color("#9CA3AF")).Render("no transactions found"))
+	}
+
+	maxSpendCents := int64(1)
+	for _, w := range weeks {
+		maxSpendCents = max(maxSpendCents, w.spendCents)
+	}
+	average := averageWeeklySpendCents(weeks)
+	// prefix + week label + amount column and spacing
+	const fixed = 2 + 8 + 2 + 9 + 2
+	const rightSlack = 5
+	barWidth := max(3, contentWidth-fixed-rightSlack)
+	overStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#F15B5B"))
+	underStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#6CBFE6"))
+	for _, w := range weeks {
+		barLen := int(math.Round(float64(w.spendCents) / float64(maxSpendCents) * float64(barWidth)))
+		if w.spendCents > 0 {
+			barLen = max(1, barLen)
+		}
+		if barWidth > 1 {
+			barLen = min(barLen, barWidth-1)
+		}
+		line := fmt.Sprintf("  %-8s  %9.2f  %s", w.week, float64(w.spendCents)/100.0, strings.Repeat("█", barLen))
+		line = truncateDisplayWidth(line, max(8, contentWidth))
+		// Weeks above the average are the ones worth a second look.
+		style := underStyle
+		if w.spendCents > average {
+			style = overStyle
+		}
+		out = append(out, style.Render(line))
+	}
+	return out
+}
@@ -1,8 +1,10 @@
 package upapi
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -16,21 +18,47 @@ const defaultPageSize = 15
 const accountsPageSize = 50
 const transactionsPageSize = 50
 
+// ErrUnauthorized is wrapped by errors from 401 responses, which mean the
+// personal access token is invalid, expired or revoked.
+var ErrUnauthorized = errors.New("Up API rejected the personal access token")
+
+// defaultRequestTimeout bounds a single HTTP call when Options leaves
+// RequestTimeout unset.
+const defaultRequestTimeout = 15 * time.Second
+
 // Client is a minimal Up API client.
 type Client struct {
-	baseURL    string
-	token      string
-	httpClient *http.Client
+	baseURL        string
+	token          string
+	httpClient     *http.Client
+	requestTimeout time.Duration
+}
+
+// Options tunes a Client built with NewWithOptions.
+type Options struct {
+	// RequestTimeout bounds each HTTP attempt, including reading the
+	// response body, so one stalled page fetch cannot use up the caller's
+	// whole sync deadline. Zero means defaultRequestTimeout.
+	RequestTimeout time.Duration
 }
 
 // New creates a client using the default Up API base URL.
 func New(token string) *Client {
+	return NewWithOptions(token, Options{})
+}
+
+// NewWithOptions creates a client using the default Up API base URL and the
+// given options.
+func NewWithOptions(token string, opts Options) *Client {
+	timeout := opts.RequestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
+	}
 	return &Client{
-		baseURL: defaultBaseURL,
-		token:   token,
-		httpClient: &http.Client{
-			Timeout: 15 * time.Second,
-		},
+		baseURL:        defaultBaseURL,
+		token:          token,
+		httpClient:     &http.Client{},
+		requestTimeout: timeout,
 	}
 }
 
@@ -43,7 +71,11 @@ func NewWithBaseURL(token, baseURL string) *Client {
 }
 
 func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
-	return c.do(ctx, http.MethodGet, path, query, out, http.StatusOK)
+	return c.do(ctx, http.MethodGet, path, query, nil, out, http.StatusOK)
+}
+
+func (c *Client) post(ctx context.Context, path string, payload any, out any, okStatus ...int) error {
+	return c.do(ctx, http.MethodPost, path, nil, payload, out, okStatus...)
 }
 
 func (c *Client) getURL(ctx context.Context, fullURL string, out any) error {
@@ -55,6 +87,7 @@ func (c *Client) do(
 	method string,
 	path string,
 	query url.Values,
+	payload any,
 	out any,
 	okStatus ...int,
 ) error {
@@ -63,37 +96,59 @@ func (c *Client) do(
 		fullURL = fullURL + "?" + query.Encode()
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, nil)
-	if err != nil {
-		return fmt.Errorf("build %s request: %w", method, err)
+	var body []byte
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("encode request body: %w", err)
+		}
+		body = encoded
 	}
+	return c.request(ctx, method, fullURL, path, body, out, okStatus...)
+}
 
-	req.Header.Set("Authorization", "Bearer "+c.token)
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("call %s %s: %w", method, path, err)
-	}
-	defer resp.Body.Close()
+func (c *Client) doURL(
+	ctx context.Context,
+	method string,
+	fullURL string,
+	out any,
+	okStatus ...int,
+) error {
+	return c.request(ctx, method, fullURL, fullURL, nil, out, okStatus...)
+}
 
-	respBody, err := io.ReadAll(resp.Body)
+// request sends the call, checks the status and decodes the response into
+// out. label names the call in errors.
+func (c *Client) request(
+	ctx context.Context,
+	method string,
+	fullURL string,
+	label string,
+	body []byte,
+	out any,
+	okStatus ...int,
+) error {
+	status, respBody, err := c.send(ctx, method, fullURL, label, body)
 	if err != nil {
-		return fmt.Errorf("read response body: %w", err)
+		return err
 	}
 
 	statusOK := false
-	for _, status := range okStatus {
-		if resp.StatusCode == status {
+	for _, s := range okStatus {
+		if status == s {
 			statusOK = true
 			break
 		}
 	}
+	if !statusOK && status == http.StatusUnauthorized {
+		return fmt.Errorf("%s %s failed with status %d: %w", method, label, status, ErrUnauthorized)
+	}
 	if !statusOK {
 		return fmt.Errorf(
 			"%s %s failed with status %d: %s",
 			method,
-			path,
-			resp.StatusCode,
+			label,
+			status,
 			strings.TrimSpace(string(respBody)),
 		)
 	}
@@ -109,55 +164,64 @@ func (c *Client) do(
 	return nil
 }
 
-func (c *Client) doURL(
-	ctx context.Context,
-	method string,
-	fullURL string,
-	out any,
-	okStatus ...int,
-) error {
-	req, err := http.NewRequestWithContext(ctx, method, fullURL, nil)
-	if err != nil {
-		return fmt.Errorf("build %s request: %w", method, err)
-	}
-
-	req.Header.Set("Authorization", "Bearer "+c.token)
+// send performs the HTTP call, waiting out 429 responses for the server's
+// Retry-After before trying again, up to maxRateLimitRetries times.
+func (c *Client) send(ctx context.Context, method, fullURL, label string, body []byte) (int, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		resp, respBody, err := c.attempt(ctx, method, fullURL, label, body)
+		if err != nil {
+			return 0, nil, err
+		}
+		if resp.StatusCode != http.StatusTooManyRequests {
+			return resp.StatusCode, respBody, nil
+		}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("call %s %s: %w", method, fullURL, err)
+		wait := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if attempt > maxRateLimitRetries {
+			return 0, nil, &RateLimitError{Method: method, Target: label, Attempts: attempt, RetryAfter: wait}
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return 0, nil, ctx.Err()
+		case <-timer.C:
+		}
 	}
-	defer resp.Body.Close()
+}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("read response body: %w", err)
+// attempt makes one HTTP call under the client's per-request timeout and
+// reads the whole response body before that timeout is released.
+func (c *Client) attempt(ctx context.Context, method, fullURL, label string, body []byte) (*http.Response, []byte, error) {
+	timeout := c.requestTimeout
+	if timeout <= 0 {
+		timeout = defaultRequestTimeout
 	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	statusOK := false
-	for _, status := range okStatus {
-		if resp.StatusCode == status {
-			statusOK = true
-			break
-		}
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
 	}
-	if !statusOK {
-		return fmt.Errorf(
-			"%s %s failed with status %d: %s",
-			method,
-			fullURL,
-			resp.StatusCode,
-			strings.TrimSpace(string(respBody)),
-		)
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, reqBody)
+	if err != nil {
+		return nil, nil, fmt.Errorf("build %s request: %w", method, err)
 	}
 
-	if out == nil || len(respBody) == 0 {
-		return nil
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
-	if err := json.Unmarshal(respBody, out); err != nil {
-		return fmt.Errorf("decode response body: %w", err)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("call %s %s: %w", method, label, err)
 	}
-
-	return nil
+	respBody, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read response body: %w", err)
+	}
+	return resp, respBody, nil
 }
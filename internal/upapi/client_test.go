@@ -2,10 +2,13 @@ package upapi
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 )
 
 type roundTripFunc func(*http.Request) (*http.Response, error)
@@ -65,6 +68,26 @@ func TestPingNon200Fails(t *testing.T) {
 	}
 }
 
+func TestPingUnauthorizedReturnsSentinel(t *testing.T) {
+	client := NewWithBaseURL("revoked-token", "https://example.test")
+	client.httpClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusUnauthorized,
+				Body: io.NopCloser(strings.NewReader(
+					`{"errors":[{"status":"401","title":"Not Authorized","detail":"The request was not authenticated because no valid credential was found in the Authorization header, or the Authorization header was not present."}]}`,
+				)),
+				Header: make(http.Header),
+			}, nil
+		}),
+	}
+
+	err := client.Ping(context.Background())
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Ping() error = %v, want ErrUnauthorized", err)
+	}
+}
+
 func TestPaginatedRoutesUsePageSize15(t *testing.T) {
 	tests := []struct {
 		name string
@@ -196,3 +219,69 @@ func TestListAccountsFollowsPagination(t *testing.T) {
 		t.Fatalf("requests = %d, want 2", len(requests))
 	}
 }
+
+func TestAddTagsToTransactionPostsTagIdentifiers(t *testing.T) {
+	var seenReq *http.Request
+	var seenBody string
+	client := NewWithBaseURL("test-token", "https://example.test")
+	client.httpClient = &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			seenReq = req
+			raw, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("read request body: %v", err)
+			}
+			seenBody = string(raw)
+			return &http.Response{
+				StatusCode: http.StatusNoContent,
+				Body:       io.NopCloser(strings.NewReader("")),
+				Header:     make(http.Header),
+			}, nil
+		}),
+	}
+
+	if err := client.AddTagsToTransaction(context.Background(), "tx-1", "Holiday"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if seenReq == nil {
+		t.Fatal("no request captured")
+	}
+	if seenReq.Method != http.MethodPost {
+		t.Fatalf("method = %q, want %q", seenReq.Method, http.MethodPost)
+	}
+	if seenReq.URL.Path != "/transactions/tx-1/relationships/tags" {
+		t.Fatalf("path = %q, want %q", seenReq.URL.Path, "/transactions/tx-1/relationships/tags")
+	}
+	if seenReq.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("Content-Type = %q, want %q", seenReq.Header.Get("Content-Type"), "application/json")
+	}
+	want := `{"data":[{"type":"tags","id":"Holiday"}]}`
+	if seenBody != want {
+		t.Fatalf("body = %s, want %s", seenBody, want)
+	}
+}
+
+func TestStalledRequestTripsRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := NewWithOptions("test-token", Options{RequestTimeout: 50 * time.Millisecond})
+	client.baseURL = server.URL
+
+	started := time.Now()
+	err := client.Ping(context.Background())
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Ping() error = %v, want it to wrap context.DeadlineExceeded", err)
+	}
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("Ping() took %s, want it cut off by the request timeout", elapsed)
+	}
+}
+
+func TestNewDefaultsRequestTimeout(t *testing.T) {
+	if got := New("test-token").requestTimeout; got != defaultRequestTimeout {
+		t.Fatalf("New().requestTimeout = %s, want %s", got, defaultRequestTimeout)
+	}
+}
@@ -0,0 +1,60 @@
+package upapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRateLimitRetries is how many times a 429 response is retried before the
+// call gives up with a RateLimitError.
+const maxRateLimitRetries = 3
+
+// defaultRetryAfter is the wait used when a 429 has no usable Retry-After.
+const defaultRetryAfter = time.Second
+
+// maxRetryAfter caps the wait so a large Retry-After can't stall a sync.
+const maxRetryAfter = 30 * time.Second
+
+// ErrRateLimited is wrapped by RateLimitError, so callers that only see the
+// error text (e.g. via sync_state) can still recognise it.
+var ErrRateLimited = errors.New("rate limited by the Up API")
+
+// RateLimitError reports a call that was still rate limited after every retry.
+type RateLimitError struct {
+	Method     string
+	Target     string
+	Attempts   int
+	RetryAfter time.Duration
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf(
+		"%s %s: %s after %d attempts (retry after %s)",
+		e.Method,
+		e.Target,
+		ErrRateLimited,
+		e.Attempts,
+		e.RetryAfter,
+	)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// parseRetryAfter reads a Retry-After header given either as seconds or as
+// an HTTP date, capped at maxRetryAfter.
+func parseRetryAfter(raw string, now time.Time) time.Duration {
+	raw = strings.TrimSpace(raw)
+	wait := defaultRetryAfter
+	if seconds, err := strconv.Atoi(raw); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(raw); err == nil {
+		wait = max(0, at.Sub(now))
+	}
+	return min(wait, maxRetryAfter)
+}
@@ -0,0 +1,80 @@
+package upapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitedRequestRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewWithBaseURL("test-token", server.URL)
+	if err := client.Ping(context.Background()); err != nil {
+		t.Fatalf("Ping() unexpected error: %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("calls = %d, want 2", got)
+	}
+}
+
+func TestRateLimitedRequestGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewWithBaseURL("test-token", server.URL)
+	err := client.Ping(context.Background())
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("Ping() error = %v, want a RateLimitError", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("Ping() error = %v, want it to wrap ErrRateLimited", err)
+	}
+	if got := calls.Load(); got != maxRateLimitRetries+1 {
+		t.Fatalf("calls = %d, want %d", got, maxRateLimitRetries+1)
+	}
+	if rateErr.Attempts != maxRateLimitRetries+1 {
+		t.Fatalf("Attempts = %d, want %d", rateErr.Attempts, maxRateLimitRetries+1)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		raw  string
+		want time.Duration
+	}{
+		{raw: "", want: defaultRetryAfter},
+		{raw: "soon", want: defaultRetryAfter},
+		{raw: "0", want: 0},
+		{raw: "5", want: 5 * time.Second},
+		{raw: "3600", want: maxRetryAfter},
+		{raw: now.Add(10 * time.Second).Format(http.TimeFormat), want: 10 * time.Second},
+		{raw: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+	}
+	for _, tc := range tests {
+		if got := parseRetryAfter(tc.raw, now); got != tc.want {
+			t.Fatalf("parseRetryAfter(%q) = %s, want %s", tc.raw, got, tc.want)
+		}
+	}
+}
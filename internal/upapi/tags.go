@@ -1,6 +1,10 @@
 package upapi
 
-import "context"
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
 
 // ListTags calls GET /tags with page[size]=15.
 func (c *Client) ListTags(ctx context.Context) (*ListResponse, error) {
@@ -10,3 +14,23 @@ func (c *Client) ListTags(ctx context.Context) (*ListResponse, error) {
 	}
 	return &out, nil
 }
+
+type tagIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+type tagsRelationshipRequest struct {
+	Data []tagIdentifier `json:"data"`
+}
+
+// AddTagsToTransaction calls POST /transactions/{id}/relationships/tags.
+// Tags that do not exist yet are created by Up.
+func (c *Client) AddTagsToTransaction(ctx context.Context, transactionID string, tagIDs ...string) error {
+	payload := tagsRelationshipRequest{Data: make([]tagIdentifier, 0, len(tagIDs))}
+	for _, id := range tagIDs {
+		payload.Data = append(payload.Data, tagIdentifier{Type: "tags", ID: id})
+	}
+	path := "/transactions/" + url.PathEscape(transactionID) + "/relationships/tags"
+	return c.post(ctx, path, payload, nil, http.StatusNoContent)
+}